@@ -0,0 +1,122 @@
+package responder
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ResponseRecorderWriter is a response writer recording the status code,
+// the size and the duration of the response, e.g. for access logs and metrics.
+type ResponseRecorderWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+	start  time.Time
+}
+
+// WrapWriter wraps the response writer so that the response it writes is recorded.
+// The writers already wrapped are returned as is.
+func WrapWriter(w http.ResponseWriter) *ResponseRecorderWriter {
+	if rw, ok := w.(*ResponseRecorderWriter); ok {
+		return rw
+	}
+
+	return &ResponseRecorderWriter{ResponseWriter: w, start: time.Now()}
+}
+
+// WriteHeader records the status code of the response and sends its header.
+func (w *ResponseRecorderWriter) WriteHeader(code int) {
+	if w.status == 0 && (code >= status200 || code == http.StatusSwitchingProtocols) {
+		w.status = code
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the size of the body and writes it.
+func (w *ResponseRecorderWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = status200
+	}
+
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += n
+
+	return n, err
+}
+
+// Status returns the status code of the response, 0 when it is not written.
+func (w *ResponseRecorderWriter) Status() int {
+	return w.status
+}
+
+// BytesWritten returns the number of bytes of the body written so far.
+func (w *ResponseRecorderWriter) BytesWritten() int {
+	return w.bytes
+}
+
+// Duration returns the time elapsed since the writer was wrapped.
+func (w *ResponseRecorderWriter) Duration() time.Duration {
+	return time.Since(w.start)
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (w *ResponseRecorderWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// AccessLog returns a middleware logging the requests at the info level with the
+// logger of the responder, along with the status code, the size and the duration
+// of their responses, the request ID and the attributes of WithLogAttrs.
+// The responders without logger log nothing. It must wrap the Recoverer
+// middleware for the requests whose handler panicked to be logged.
+func AccessLog(r Responder) func(http.Handler) http.Handler {
+	rr, _ := r.(*responder)
+
+	return func(next http.Handler) http.Handler {
+		if rr == nil || rr.options == nil || rr.options.logger == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			rw := WrapWriter(w)
+			next.ServeHTTP(rw, req)
+
+			c := *rr
+			c.request = req
+			c.logAccess(rw)
+		})
+	}
+}
+
+// logAccess logs the response recorded by the writer to the request the responder is bound to.
+func (r *responder) logAccess(w *ResponseRecorderWriter) {
+	status := w.Status()
+	if status == 0 {
+		// net/http sends a 200 OK when the handler writes nothing.
+		status = status200
+	}
+
+	attrs := []any{
+		"method", r.request.Method,
+		"path", r.request.URL.Path,
+		"status", status,
+		"bytes", w.BytesWritten(),
+		"duration", w.Duration(),
+	}
+
+	if id := r.requestID(); id != "" {
+		attrs = append(attrs, "request_id", id)
+	}
+
+	ctx := r.context()
+
+	if r.options.logAttrs != nil {
+		for _, a := range r.options.logAttrs(ctx) {
+			attrs = append(attrs, a)
+		}
+	}
+
+	r.options.logger.Log(ctx, slog.LevelInfo, "request served", attrs...)
+}
@@ -0,0 +1,134 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWrapWriter(t *testing.T) {
+	t.Run("records the status code and the size of the response", func(t *testing.T) {
+		w := WrapWriter(httptest.NewRecorder())
+
+		JSONResponder().Send201(w, map[string]int{"id": 1})
+
+		if w.Status() != http.StatusCreated {
+			t.Errorf("expected status 201, got %d", w.Status())
+		}
+
+		if w.BytesWritten() != len(`{"id":1}`) {
+			t.Errorf("expected 8 bytes, got %d", w.BytesWritten())
+		}
+
+		if w.Duration() <= 0 {
+			t.Error("expected the duration to be recorded")
+		}
+	})
+
+	t.Run("records the implicit status of the writes", func(t *testing.T) {
+		w := WrapWriter(httptest.NewRecorder())
+
+		if w.Status() != 0 {
+			t.Errorf("expected no status, got %d", w.Status())
+		}
+
+		_, _ = w.Write([]byte("ok"))
+		w.WriteHeader(http.StatusNotFound)
+
+		if w.Status() != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Status())
+		}
+	})
+
+	t.Run("returns the wrapped writers as is", func(t *testing.T) {
+		w := WrapWriter(httptest.NewRecorder())
+
+		if WrapWriter(w) != w {
+			t.Error("expected the wrapped writer to be returned")
+		}
+	})
+
+	t.Run("lets the guarded writers be found", func(t *testing.T) {
+		g := Guard(httptest.NewRecorder())
+		w := WrapWriter(g)
+
+		TextResponder().Send200(w, "first")
+		TextResponder().Send200(w, "second")
+
+		if w.BytesWritten() != len("first") {
+			t.Errorf("expected the second response to be suppressed, got %d bytes", w.BytesWritten())
+		}
+	})
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Run("logs the requests", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		responder := TextResponder(
+			WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+			WithLogAttrs(func(context.Context) []slog.Attr { return []slog.Attr{slog.String("route", "/users")} }),
+		)
+
+		h := AccessLog(responder)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			responder.Send404(w, nil, "not found")
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		req.Header.Set(RequestIDHeader, "req-1")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		var entry map[string]any
+		if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+			t.Fatalf("failed to decode the log entry %q: %v", logs.String(), err)
+		}
+
+		want := map[string]any{
+			"level":      "INFO",
+			"msg":        "request served",
+			"method":     "GET",
+			"path":       "/users/1",
+			"status":     float64(http.StatusNotFound),
+			"bytes":      float64(len("not found")),
+			"request_id": "req-1",
+			"route":      "/users",
+		}
+
+		for k, v := range want {
+			if entry[k] != v {
+				t.Errorf("expected %s to be %v, got %v", k, v, entry[k])
+			}
+		}
+
+		if _, ok := entry["duration"]; !ok {
+			t.Error("expected the duration to be logged")
+		}
+	})
+
+	t.Run("logs a 200 when the handler writes nothing", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		h := AccessLog(TextResponder(WithLogger(slog.New(slog.NewJSONHandler(&logs, nil)))))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !bytes.Contains(logs.Bytes(), []byte(`"status":200`)) {
+			t.Errorf("expected status 200 to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("leaves the handler untouched without logger", func(t *testing.T) {
+		next := http.NewServeMux()
+
+		if h := AccessLog(TextResponder())(next); h != next {
+			t.Errorf("expected the handler to be returned, got %T", h)
+		}
+
+		if h := AccessLog(Noop())(next); h != next {
+			t.Errorf("expected the handler to be returned, got %T", h)
+		}
+	})
+}
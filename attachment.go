@@ -0,0 +1,134 @@
+package responder
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// WithAttachmentFilename makes the successful responses attachments saved
+// under the given filename by the browsers, with a Content-Disposition header.
+// A Content-Disposition header already set on the writer takes precedence.
+func WithAttachmentFilename(filename string) OptionsModifier {
+	return func(o *options) {
+		o.attachment = filename
+	}
+}
+
+// WithFileETag sets the function computing the ETag of the files sent
+// with SendFile, e.g. WeakFileETag or StrongFileETag.
+// No ETag is sent when none is provided.
+func WithFileETag(f ETagFunc) OptionsModifier {
+	return func(o *options) {
+		o.fileETag = f
+	}
+}
+
+// setContentDisposition sets the Content-Disposition header of the attachments.
+func (r *responder) setContentDisposition(h http.Header, code int) {
+	if r.options.attachment == "" || !Is2xx(code) || h.Get("Content-Disposition") != "" {
+		return
+	}
+
+	h.Set("Content-Disposition", contentDisposition("attachment", r.options.attachment))
+}
+
+// contentDisposition formats the value of a Content-Disposition header. The filenames
+// that are not printable ASCII are encoded as a filename* parameter, as described by
+// RFC 5987, along with an ASCII filename parameter for the older clients.
+func contentDisposition(disposition, filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+
+	ascii := true
+
+	fallback := strings.Map(func(c rune) rune {
+		if c > unicode.MaxASCII || !unicode.IsPrint(c) {
+			ascii = false
+
+			return '_'
+		}
+
+		return c
+	}, filename)
+
+	if ascii {
+		return mime.FormatMediaType(disposition, map[string]string{"filename": filename})
+	}
+
+	return mime.FormatMediaType(disposition, map[string]string{"filename": fallback}) +
+		"; filename*=UTF-8''" + url.PathEscape(filename)
+}
+
+// sendFile serves the content inline under the name.
+func (r *responder) sendFile(rw responseWriter, req *http.Request, name string, content io.ReadSeeker, modtime time.Time) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	if rw.Header().Get("Content-Disposition") == "" {
+		rw.Header().Set("Content-Disposition", contentDisposition("inline", name))
+	}
+
+	return r.serveContent(rw, req, name, content, modtime)
+}
+
+// serveContent serves the content with http.ServeContent.
+func (r *responder) serveContent(rw responseWriter, req *http.Request, name string, content io.ReadSeeker, modtime time.Time) error {
+	h := rw.Header()
+
+	if r.options.fileETag != nil && h.Get("ETag") == "" {
+		size, err := content.Seek(0, io.SeekEnd)
+		if err == nil {
+			_, err = content.Seek(0, io.SeekStart)
+		}
+
+		var etag string
+		if err == nil {
+			etag, err = r.options.fileETag(size, modtime, content)
+		}
+
+		if err != nil {
+			return r.sendFailure(rw, err)
+		}
+
+		h.Set("ETag", etag)
+	}
+
+	r.applyDefaultHeaders(rw)
+	r.setCacheControl(h, status200)
+
+	w := &ResponseRecorderWriter{ResponseWriter: r.withDeadline(rw), start: time.Now()}
+	http.ServeContent(w, req, name, modtime, content)
+
+	r.recordMetrics(rw, w.Status(), w.BytesWritten(), 0, w.Duration())
+	r.afterSend(w.Status(), w.BytesWritten(), nil)
+
+	return nil
+}
+
+func (r *responder) SendFile(rw responseWriter, req *http.Request, name string, content io.ReadSeeker, modtime time.Time) {
+	_ = r.sendFile(rw, req, name, content, modtime)
+}
+
+// sendAttachment sends the data as an attachment saved under the filename.
+func (r *responder) sendAttachment(rw responseWriter, filename string, data any) error {
+	resp := Success(status200, data).WithHeader("Content-Disposition", contentDisposition("attachment", filename))
+
+	switch data.(type) {
+	case string, []byte, io.Reader, io.WriterTo:
+		if ct := mime.TypeByExtension(path.Ext(filename)); ct != "" {
+			resp = resp.WithContentType(ct)
+		}
+	}
+
+	return r.sendResponse(rw, resp)
+}
+
+func (r *responder) SendAttachment(rw responseWriter, filename string, data any) {
+	_ = r.sendAttachment(rw, filename, data)
+}
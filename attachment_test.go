@@ -0,0 +1,141 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContentDisposition(t *testing.T) {
+	testCases := []struct {
+		filename string
+		want     string
+	}{
+		{"report.pdf", "attachment; filename=report.pdf"},
+		{"my report.pdf", `attachment; filename="my report.pdf"`},
+		{"../../etc/passwd", "attachment; filename=passwd"},
+		{`C:\tmp\report.pdf`, "attachment; filename=report.pdf"},
+		{"résumé.pdf", `attachment; filename=r_sum_.pdf; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filename, func(t *testing.T) {
+			if got := contentDisposition("attachment", tc.filename); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSendFile(t *testing.T) {
+	modtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	content := "hello, world"
+
+	t.Run("serves the file inline", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/files/hello.txt", nil)
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendFile(w, req, "hello.txt", strings.NewReader(content), modtime)
+
+		if w.Code != http.StatusOK || w.Body.String() != content {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+			t.Errorf("unexpected Content-Type %q", ct)
+		}
+
+		if cd := w.Header().Get("Content-Disposition"); cd != "inline; filename=hello.txt" {
+			t.Errorf("unexpected Content-Disposition %q", cd)
+		}
+
+		if lm := w.Header().Get("Last-Modified"); lm != modtime.Format(http.TimeFormat) {
+			t.Errorf("unexpected Last-Modified %q", lm)
+		}
+	})
+
+	t.Run("handles the range requests", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Range", "bytes=7-")
+
+		w := httptest.NewRecorder()
+
+		TextResponder().SendFile(w, req, "hello.txt", strings.NewReader(content), modtime)
+
+		if w.Code != http.StatusPartialContent || w.Body.String() != "world" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("handles the conditional requests with the ETag", func(t *testing.T) {
+		responder := TextResponder(WithFileETag(StrongFileETag))
+
+		w := httptest.NewRecorder()
+		responder.SendFile(w, httptest.NewRequest(http.MethodGet, "/", nil), "hello.txt", strings.NewReader(content), modtime)
+
+		etag := w.Header().Get("ETag")
+		if etag == "" || w.Body.String() != content {
+			t.Fatalf("unexpected response %q with ETag %q", w.Body.String(), etag)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", etag)
+
+		w = httptest.NewRecorder()
+		responder.SendFile(w, req, "hello.txt", strings.NewReader(content), modtime)
+
+		if w.Code != http.StatusNotModified {
+			t.Errorf("expected status %d, got %d", http.StatusNotModified, w.Code)
+		}
+	})
+
+	t.Run("reports the ETag failures", func(t *testing.T) {
+		failing := func(int64, time.Time, io.ReadSeeker) (string, error) { return "", errors.New("read error") }
+		w := httptest.NewRecorder()
+
+		err := Checked(TextResponder(WithFileETag(failing))).
+			SendFile(w, httptest.NewRequest(http.MethodGet, "/", nil), "hello.txt", strings.NewReader(content), modtime)
+		if err == nil || w.Body.Len() != 0 {
+			t.Errorf("expected the failure to be reported, got %v and %q", err, w.Body.String())
+		}
+	})
+}
+
+func TestSendAttachment(t *testing.T) {
+	t.Run("sends raw data with the content type of the filename", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendAttachment(w, "export.csv", bytes.NewReader([]byte("a,b\n1,2\n")))
+
+		if ct := w.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+			t.Errorf("unexpected Content-Type %q", ct)
+		}
+
+		if cd := w.Header().Get("Content-Disposition"); cd != "attachment; filename=export.csv" {
+			t.Errorf("unexpected Content-Disposition %q", cd)
+		}
+
+		if w.Body.String() != "a,b\n1,2\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("formats the structured data with the responder", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendAttachment(w, "user.txt", map[string]int{"id": 1})
+
+		if ct := w.Header().Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("unexpected Content-Type %q", ct)
+		}
+
+		if w.Body.String() != `{"id":1}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
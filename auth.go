@@ -0,0 +1,103 @@
+package responder
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// WithPrincipalKey sets the context key under which the authentication middleware
+// stores the authenticated principal. The principal is added to the logs of
+// the 401 Unauthorized and 403 Forbidden responses, along with the subject of
+// the TLS client certificate, making the authorization denials auditable.
+// It only applies to responders bound to a request with WithRequest.
+func WithPrincipalKey(key any) OptionsModifier {
+	return func(o *options) {
+		o.principalKey = key
+	}
+}
+
+// authAttrs returns the attributes describing the client authentication
+// for the logs of the authorization denials.
+func (r *responder) authAttrs(code int) []any {
+	if r.request == nil || (code != status401 && code != status403) {
+		return nil
+	}
+
+	var attrs []any
+
+	if tls := r.request.TLS; tls != nil && len(tls.PeerCertificates) > 0 {
+		attrs = append(attrs, slog.String("tls_client_subject", tls.PeerCertificates[0].Subject.String()))
+	}
+
+	if p := r.principal(); p != nil {
+		attrs = append(attrs, slog.String("principal", fmt.Sprint(p)))
+	}
+
+	return attrs
+}
+
+// WithWWWAuthenticate sets the challenges sent in the WWW-Authenticate header
+// of the 401 Unauthorized responses, e.g. BasicChallenge("admin").
+// A WWW-Authenticate header already set on the writer or attached to
+// the Response takes precedence, which allows sending per-response challenges
+// such as a BearerChallenge reporting an invalid token.
+func WithWWWAuthenticate(challenges ...string) OptionsModifier {
+	return func(o *options) {
+		o.challenges = append([]string(nil), challenges...)
+	}
+}
+
+// BasicChallenge returns the challenge of the Basic authentication scheme (RFC 7617).
+func BasicChallenge(realm string) string {
+	return "Basic realm=" + quote(realm) + `, charset="UTF-8"`
+}
+
+// BearerChallenge is the challenge of the Bearer authentication scheme (RFC 6750).
+// The empty fields are omitted.
+type BearerChallenge struct {
+	Realm            string
+	Scope            string
+	Error            string
+	ErrorDescription string
+	ErrorURI         string
+}
+
+// String returns the challenge as sent in the WWW-Authenticate header.
+func (c BearerChallenge) String() string {
+	var params []string
+
+	for _, p := range [][2]string{
+		{"realm", c.Realm},
+		{"scope", c.Scope},
+		{"error", c.Error},
+		{"error_description", c.ErrorDescription},
+		{"error_uri", c.ErrorURI},
+	} {
+		if p[1] != "" {
+			params = append(params, p[0]+"="+quote(p[1]))
+		}
+	}
+
+	if len(params) == 0 {
+		return "Bearer"
+	}
+
+	return "Bearer " + strings.Join(params, ", ")
+}
+
+// quote returns the value as a quoted string.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// setChallenges sets the WWW-Authenticate header of the 401 responses.
+func (r *responder) setChallenges(h http.Header, code int) {
+	if code != status401 || len(r.options.challenges) == 0 || h.Get("WWW-Authenticate") != "" {
+		return
+	}
+
+	c := r.options.challenges
+	h[http.CanonicalHeaderKey("WWW-Authenticate")] = c[:len(c):len(c)]
+}
@@ -0,0 +1,145 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type principalKey struct{}
+
+func TestAuthLogs(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{
+				{Subject: pkix.Name{CommonName: "billing-service", Organization: []string{"Acme"}}},
+			},
+		}
+
+		return req.WithContext(context.WithValue(req.Context(), principalKey{}, "user-42"))
+	}
+
+	t.Run("logs the client identity of authorization denials", func(t *testing.T) {
+		for _, send := range []func(Responder, http.ResponseWriter){
+			func(r Responder, w http.ResponseWriter) { r.Send401(w, errors.New("expired token"), "unauthorized") },
+			func(r Responder, w http.ResponseWriter) { r.Send403(w, errors.New("missing role"), "forbidden") },
+		} {
+			var logs bytes.Buffer
+
+			responder := TextResponder(
+				WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+				WithPrincipalKey(principalKey{}),
+			)
+
+			send(responder.WithRequest(newRequest()), httptest.NewRecorder())
+
+			if !strings.Contains(logs.String(), `tls_client_subject="CN=billing-service,O=Acme"`) {
+				t.Errorf("expected the certificate subject to be logged, got %q", logs.String())
+			}
+
+			if !strings.Contains(logs.String(), "principal=user-42") {
+				t.Errorf("expected the principal to be logged, got %q", logs.String())
+			}
+		}
+	})
+
+	t.Run("does not log the client identity of other errors", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		responder := TextResponder(
+			WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			WithPrincipalKey(principalKey{}),
+		)
+
+		responder.WithRequest(newRequest()).Send404(httptest.NewRecorder(), errors.New("no such page"), "not found")
+
+		if strings.Contains(logs.String(), "principal") || strings.Contains(logs.String(), "tls_client_subject") {
+			t.Errorf("expected no client identity, got %q", logs.String())
+		}
+	})
+
+	t.Run("requires a request", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		responder := TextResponder(
+			WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			WithPrincipalKey(principalKey{}),
+		)
+
+		responder.Send403(httptest.NewRecorder(), errors.New("missing role"), "forbidden")
+
+		if strings.Contains(logs.String(), "principal") {
+			t.Errorf("expected no principal, got %q", logs.String())
+		}
+	})
+}
+
+func TestWWWAuthenticate(t *testing.T) {
+	t.Run("formats the challenges", func(t *testing.T) {
+		testCases := []struct {
+			challenge string
+			want      string
+		}{
+			{challenge: BasicChallenge("admin"), want: `Basic realm="admin", charset="UTF-8"`},
+			{challenge: BasicChallenge(`say "hi"`), want: `Basic realm="say \"hi\"", charset="UTF-8"`},
+			{challenge: BearerChallenge{}.String(), want: "Bearer"},
+			{
+				challenge: BearerChallenge{
+					Realm:            "api",
+					Error:            "invalid_token",
+					ErrorDescription: "The access token expired",
+				}.String(),
+				want: `Bearer realm="api", error="invalid_token", error_description="The access token expired"`,
+			},
+		}
+
+		for _, tc := range testCases {
+			if tc.challenge != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, tc.challenge)
+			}
+		}
+	})
+
+	t.Run("sets the challenges of 401 responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		responder := JSONResponder(WithWWWAuthenticate(BasicChallenge("admin"), BearerChallenge{Realm: "api"}.String()))
+
+		responder.Send401(w, nil, "unauthorized")
+
+		want := []string{`Basic realm="admin", charset="UTF-8"`, `Bearer realm="api"`}
+		if got := w.Header().Values("WWW-Authenticate"); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("expected WWW-Authenticate %q, got %q", want, got)
+		}
+	})
+
+	t.Run("lets the response override the challenges", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		responder := JSONResponder(WithWWWAuthenticate(BearerChallenge{Realm: "api"}.String()))
+		challenge := BearerChallenge{Realm: "api", Error: "invalid_token"}.String()
+
+		responder.Send(w, Error(http.StatusUnauthorized, nil, "invalid token").WithHeader("WWW-Authenticate", challenge))
+
+		if got := w.Header().Values("WWW-Authenticate"); len(got) != 1 || got[0] != challenge {
+			t.Errorf("expected WWW-Authenticate %q, got %q", challenge, got)
+		}
+	})
+
+	t.Run("ignores other statuses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithWWWAuthenticate(BasicChallenge("admin"))).Send403(w, nil, "forbidden")
+
+		if got := w.Header().Get("WWW-Authenticate"); got != "" {
+			t.Errorf("expected no WWW-Authenticate, got %q", got)
+		}
+	})
+}
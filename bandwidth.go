@@ -0,0 +1,75 @@
+package responder
+
+import (
+	"io"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// WithBandwidthLimit limits the throughput of each response body to the given
+// number of bytes per second, e.g. for large downloads and exports.
+// The first second worth of bytes is written immediately.
+// A zero or negative limit disables it.
+func WithBandwidthLimit(bytesPerSec int) OptionsModifier {
+	return func(o *options) {
+		o.bandwidth = bytesPerSec
+	}
+}
+
+// WithGlobalBandwidthLimit limits the throughput of the response bodies to
+// the given number of bytes per second, the limit being shared by all the
+// responses of the responder so that bulk endpoints cannot saturate egress.
+// A zero or negative limit disables it.
+func WithGlobalBandwidthLimit(bytesPerSec int) OptionsModifier {
+	return func(o *options) {
+		o.globalBandwidth = nil
+
+		if bytesPerSec > 0 {
+			o.globalBandwidth = internal.NewLimiter(bytesPerSec)
+		}
+	}
+}
+
+// throttle returns the writer limiting the throughput of the body written to w,
+// or w itself when no bandwidth limit is set. The writes are interrupted when
+// the request the responder is bound to is canceled.
+func (r *responder) throttle(w io.Writer) io.Writer {
+	var limiters []*internal.Limiter
+
+	if r.options.bandwidth > 0 {
+		limiters = append(limiters, internal.NewLimiter(r.options.bandwidth))
+	}
+
+	if r.options.globalBandwidth != nil {
+		limiters = append(limiters, r.options.globalBandwidth)
+	}
+
+	if len(limiters) == 0 {
+		return w
+	}
+
+	return internal.NewThrottledWriter(r.context(), w, limiters...)
+}
+
+// countingWriter counts the bytes of the body written to w, for the
+// after-send hooks of the responses whose body is streamed.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+
+	return n, err
+}
+
+// ReadFrom copies the reader to w, keeping the io.ReaderFrom
+// optimization of the response writers, e.g. sendfile.
+func (c *countingWriter) ReadFrom(r io.Reader) (int64, error) {
+	n, err := io.Copy(c.w, r)
+	c.n += int(n)
+
+	return n, err
+}
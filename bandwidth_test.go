@@ -0,0 +1,95 @@
+package responder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithBandwidthLimit(t *testing.T) {
+	body := strings.Repeat("a", 2500)
+
+	t.Run("throttles the response bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		start := time.Now()
+
+		TextResponder(WithBandwidthLimit(2000)).Send200(w, body)
+
+		if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+			t.Errorf("expected the response to be throttled, took %v", elapsed)
+		}
+
+		if w.Body.String() != body {
+			t.Errorf("expected the whole body to be written, got %d bytes", w.Body.Len())
+		}
+	})
+
+	t.Run("shares the global limit between responses", func(t *testing.T) {
+		responder := TextResponder(WithGlobalBandwidthLimit(2000))
+		start := time.Now()
+
+		for range 2 {
+			responder.Send200(httptest.NewRecorder(), body[:1250])
+		}
+
+		if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+			t.Errorf("expected the responses to be throttled, took %v", elapsed)
+		}
+	})
+
+	t.Run("stops writing when the request is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/export", nil).WithContext(ctx)
+		w := &cancelingWriter{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+
+		err := Checked(TextResponder(WithBandwidthLimit(1000))).WithRequest(req).Send200(w, body)
+		if err == nil || w.Body.Len() != 1000 {
+			t.Errorf("expected the write to stop after 1000 bytes, got %d bytes and %v", w.Body.Len(), err)
+		}
+	})
+
+	t.Run("counts the bytes written in the journal", func(t *testing.T) {
+		journal := &memoryJournal{}
+
+		TextResponder(WithJournal(journal)).Send200(httptest.NewRecorder(), body)
+
+		if n := journal.entries[0].BytesWritten; n != int64(len(body)) {
+			t.Errorf("expected %d bytes, got %d", len(body), n)
+		}
+	})
+
+	t.Run("exposes the bytes of the downloads and exports to the after-send hooks", func(t *testing.T) {
+		var written []int
+
+		responder := JSONResponder(
+			WithDirectEncoding(true),
+			WithAfterSend(func(_, n int, _ error) { written = append(written, n) }),
+		)
+
+		responder.Send200(httptest.NewRecorder(), strings.NewReader(body))
+		responder.SendSeq(httptest.NewRecorder(), slices.Values([]any{1, 2}))
+		responder.Send200(httptest.NewRecorder(), []int{1, 2})
+
+		if expected := []int{len(body), len("1\n2\n"), len("[1,2]")}; !slices.Equal(written, expected) {
+			t.Errorf("expected %v bytes, got %v", expected, written)
+		}
+	})
+}
+
+// cancelingWriter cancels the request once the first chunk is written.
+type cancelingWriter struct {
+	*httptest.ResponseRecorder
+	cancel func()
+}
+
+func (w *cancelingWriter) Write(b []byte) (int, error) {
+	defer w.cancel()
+
+	return w.ResponseRecorder.Write(b)
+}
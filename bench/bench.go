@@ -0,0 +1,186 @@
+// Package bench provides standardized benchmarks for the responder send path
+// together with helpers to compare the allocations of different configurations.
+// It is meant to evaluate performance-affecting features (buffer pooling, codecs,
+// compression) and to catch regressions from regular tests.
+package bench
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mickaelvieira/responder"
+)
+
+// Scenario describes a single send operation exercised by the benchmarks.
+type Scenario struct {
+	// Name identifies the scenario in benchmark and test output.
+	Name string
+	// Send performs the send operation against the given responder.
+	Send func(responder.Responder, http.ResponseWriter)
+}
+
+type item struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Tags  []string `json:"tags"`
+}
+
+// SmallJSON sends a single small object.
+var SmallJSON = Scenario{
+	Name: "small JSON",
+	Send: func(r responder.Responder, w http.ResponseWriter) {
+		r.Send200(w, item{ID: 1, Name: "John Doe", Email: "john@example.com", Tags: []string{"a", "b"}})
+	},
+}
+
+// LargeJSON sends a list of objects weighting roughly 1MB once encoded.
+var LargeJSON = Scenario{
+	Name: "1MB JSON",
+	Send: func(r responder.Responder, w http.ResponseWriter) {
+		r.Send200(w, largePayload)
+	},
+}
+
+// CSVStream sends a large CSV document.
+var CSVStream = Scenario{
+	Name: "CSV stream",
+	Send: func(r responder.Responder, w http.ResponseWriter) {
+		r.Send200(w, csvPayload)
+	},
+}
+
+// ErrorPath sends an error response, going through the error formatter and the logger.
+var ErrorPath = Scenario{
+	Name: "error path",
+	Send: func(r responder.Responder, w http.ResponseWriter) {
+		r.Send500(w, errBenchmark, "something went wrong")
+	},
+}
+
+var errBenchmark = errors.New("benchmark error")
+
+var largePayload = func() []item {
+	items := make([]item, 10000)
+	for i := range items {
+		items[i] = item{ID: i, Name: "John Doe", Email: "john@example.com", Tags: []string{"alpha", "beta"}}
+	}
+
+	return items
+}()
+
+var csvPayload = func() string {
+	var b strings.Builder
+
+	b.WriteString("id,name,email\n")
+
+	for range 20000 {
+		b.WriteString("1,John Doe,john@example.com\n")
+	}
+
+	return b.String()
+}()
+
+// Scenarios returns all the standard scenarios.
+func Scenarios() []Scenario {
+	return []Scenario{SmallJSON, LargeJSON, CSVStream, ErrorPath}
+}
+
+// Run benchmarks the scenario against the responder.
+func Run(b *testing.B, r responder.Responder, s Scenario) {
+	b.Helper()
+	b.ReportAllocs()
+
+	w := NewDiscardWriter()
+
+	for b.Loop() {
+		w.Reset()
+		s.Send(r, w)
+	}
+}
+
+// AllocsPerSend returns the average number of allocations of a single send.
+func AllocsPerSend(r responder.Responder, s Scenario, runs int) float64 {
+	w := NewDiscardWriter()
+
+	return testing.AllocsPerRun(runs, func() {
+		w.Reset()
+		s.Send(r, w)
+	})
+}
+
+// Comparison holds the allocations per send of two configurations.
+type Comparison struct {
+	Scenario  string
+	Baseline  float64
+	Candidate float64
+}
+
+// Ratio returns the candidate allocations relative to the baseline ones.
+func (c Comparison) Ratio() float64 {
+	if c.Baseline == 0 {
+		if c.Candidate == 0 {
+			return 1
+		}
+
+		return c.Candidate
+	}
+
+	return c.Candidate / c.Baseline
+}
+
+// Regressed reports whether the candidate allocates more than the baseline
+// beyond the given tolerance, e.g. 0.1 allows 10% more allocations.
+func (c Comparison) Regressed(tolerance float64) bool {
+	return c.Ratio() > 1+tolerance
+}
+
+// CompareAllocs measures the allocations per send of both responders for the scenario.
+func CompareAllocs(baseline, candidate responder.Responder, s Scenario, runs int) Comparison {
+	return Comparison{
+		Scenario:  s.Name,
+		Baseline:  AllocsPerSend(baseline, s, runs),
+		Candidate: AllocsPerSend(candidate, s, runs),
+	}
+}
+
+// DiscardWriter is an http.ResponseWriter discarding the body
+// while keeping track of the status code and the number of bytes written.
+type DiscardWriter struct {
+	header http.Header
+	// Status is the status code written by the responder.
+	Status int
+	// Written is the number of body bytes written by the responder.
+	Written int
+}
+
+// NewDiscardWriter creates a new DiscardWriter.
+func NewDiscardWriter() *DiscardWriter {
+	return &DiscardWriter{header: make(http.Header)}
+}
+
+// Header returns the response headers.
+func (w *DiscardWriter) Header() http.Header {
+	return w.header
+}
+
+// Write discards the data.
+func (w *DiscardWriter) Write(b []byte) (int, error) {
+	w.Written += len(b)
+
+	return len(b), nil
+}
+
+// WriteHeader records the status code.
+func (w *DiscardWriter) WriteHeader(code int) {
+	w.Status = code
+}
+
+// Reset prepares the writer to be reused for another send.
+func (w *DiscardWriter) Reset() {
+	clear(w.header)
+	w.Status = 0
+	w.Written = 0
+}
@@ -0,0 +1,96 @@
+package bench
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mickaelvieira/responder"
+)
+
+func BenchmarkJSONResponder(b *testing.B) {
+	r := responder.JSONResponder()
+
+	for _, s := range Scenarios() {
+		b.Run(s.Name, func(b *testing.B) {
+			Run(b, r, s)
+		})
+	}
+}
+
+func BenchmarkTextResponderWithLogger(b *testing.B) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := responder.TextResponder(responder.WithLogger(logger))
+
+	for _, s := range Scenarios() {
+		b.Run(s.Name, func(b *testing.B) {
+			Run(b, r, s)
+		})
+	}
+}
+
+func BenchmarkJSONResponderWithCompression(b *testing.B) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	r := responder.JSONResponder(responder.WithCompression()).WithRequest(req)
+
+	for _, s := range Scenarios() {
+		b.Run(s.Name, func(b *testing.B) {
+			Run(b, r, s)
+		})
+	}
+}
+
+func TestAllocationBudget(t *testing.T) {
+	// Budgets are deliberately loose, they are here to catch
+	// a feature accidentally adding allocations per element or per byte.
+	budgets := map[string]float64{
+		SmallJSON.Name: 20,
+		LargeJSON.Name: 50,
+		CSVStream.Name: 20,
+		ErrorPath.Name: 20,
+	}
+
+	r := responder.JSONResponder()
+
+	for _, s := range Scenarios() {
+		t.Run(s.Name, func(t *testing.T) {
+			if allocs := AllocsPerSend(r, s, 10); allocs > budgets[s.Name] {
+				t.Errorf("expected at most %.0f allocations per send, got %.0f", budgets[s.Name], allocs)
+			}
+		})
+	}
+}
+
+func TestCompareAllocs(t *testing.T) {
+	r := responder.JSONResponder()
+	c := CompareAllocs(r, r, SmallJSON, 10)
+
+	if c.Regressed(0.1) {
+		t.Errorf("expected identical configurations not to regress, got ratio %.2f", c.Ratio())
+	}
+
+	c = Comparison{Baseline: 10, Candidate: 12}
+	if !c.Regressed(0.1) {
+		t.Errorf("expected a 20%% increase to be reported as a regression")
+	}
+}
+
+func TestDiscardWriter(t *testing.T) {
+	w := NewDiscardWriter()
+
+	SmallJSON.Send(responder.JSONResponder(), w)
+
+	if w.Status != 200 || w.Written == 0 {
+		t.Errorf("expected a 200 with a body, got status %d and %d bytes", w.Status, w.Written)
+	}
+
+	w.Reset()
+
+	if w.Status != 0 || w.Written != 0 || len(w.Header()) != 0 {
+		t.Errorf("expected the writer to be reset")
+	}
+}
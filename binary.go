@@ -0,0 +1,51 @@
+package responder
+
+import (
+	"net/http"
+	"strings"
+)
+
+// BinaryContentType is the content type for binary responses.
+const BinaryContentType = "application/octet-stream"
+
+// BinaryResponder creates a new responder sending the data as binary content,
+// e.g. images, PDF documents or archives. The byte slices, strings and readers
+// are sent as is, see SendBlob to send them with their own content type.
+// The error messages are sent as plain text.
+func BinaryResponder(options ...OptionsModifier) Responder {
+	return New(BinaryContentType, options...)
+}
+
+// sendBlob sends the data as is with the content type, detected from the data when empty.
+func (r *responder) sendBlob(rw responseWriter, data []byte, contentType string) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	return r.withContentType(contentType).send(rw, status200, data, 0)
+}
+
+func (r *responder) SendBlob(rw responseWriter, data []byte, contentType string) {
+	_ = r.sendBlob(rw, data, contentType)
+}
+
+// binaryContentType reports whether the content type is a binary one, e.g. a workbook,
+// an image or an archive, in which the error messages cannot be formatted.
+func binaryContentType(contentType string) bool {
+	switch mt := mediaType(contentType); mt {
+	case BinaryContentType, XLSXContentType, "application/pdf", "application/zip", "application/gzip":
+		return true
+	default:
+		for _, prefix := range []string{"image/", "audio/", "video/", "font/"} {
+			if strings.HasPrefix(mt, prefix) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBinaryResponder(t *testing.T) {
+	t.Run("sends the bytes as octet-stream", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		BinaryResponder().Send200(w, []byte{0x00, 0x01, 0x02})
+
+		if ct := w.Header().Get("Content-Type"); ct != BinaryContentType {
+			t.Errorf("expected Content-Type %q, got %q", BinaryContentType, ct)
+		}
+
+		if !bytes.Equal(w.Body.Bytes(), []byte{0x00, 0x01, 0x02}) {
+			t.Errorf("unexpected body %v", w.Body.Bytes())
+		}
+	})
+
+	t.Run("sends errors as text", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		BinaryResponder().Send500(w, errors.New("storage down"), "Internal Server Error")
+
+		if ct := w.Header().Get("Content-Type"); ct != TextContentType {
+			t.Errorf("expected Content-Type %q, got %q", TextContentType, ct)
+		}
+
+		if b := w.Body.String(); b != "Internal Server Error" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+}
+
+func TestSendBlob(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	t.Run("sends the bytes with the content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().SendBlob(w, []byte("%PDF-1.7"), "application/pdf")
+
+		if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+			t.Errorf("expected Content-Type %q, got %q", "application/pdf", ct)
+		}
+
+		if b := w.Body.String(); b != "%PDF-1.7" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("detects the content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		BinaryResponder().SendBlob(w, png, "")
+
+		if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+			t.Errorf("expected Content-Type %q, got %q", "image/png", ct)
+		}
+	})
+
+	t.Run("bypasses the data formatter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendBlob(w, png, "image/png")
+
+		if !bytes.Equal(w.Body.Bytes(), png) {
+			t.Errorf("unexpected body %q", w.Body.Bytes())
+		}
+	})
+
+	t.Run("reports the nil writers", func(t *testing.T) {
+		if err := Checked(BinaryResponder()).SendBlob(nil, png, ""); !errors.Is(err, ErrNilWriter) {
+			t.Errorf("expected ErrNilWriter, got %v", err)
+		}
+	})
+}
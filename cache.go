@@ -0,0 +1,143 @@
+package responder
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultNoStore is the default policy of WithNoStore. It prevents caching
+// of error responses (4xx and 5xx) and of the 201, 202 and 204 responses
+// acknowledging state-changing requests.
+func DefaultNoStore(status int) bool {
+	switch {
+	case Is4xx(status), Is5xx(status):
+		return true
+	case status == status201, status == status202, status == status204:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithNoStore sets the policy deciding which status codes are sent with
+// a Cache-Control: no-store header, replacing DefaultNoStore.
+// A nil policy disables the header. A Cache-Control header already set
+// on the writer is never overridden.
+func WithNoStore(f func(status int) bool) OptionsModifier {
+	return func(o *options) {
+		o.noStore = f
+	}
+}
+
+// CacheControl describes the caching policy of a response, producing
+// its Cache-Control, Expires and Vary headers.
+type CacheControl struct {
+	// Public allows shared caches to store the responses, even authenticated ones.
+	Public bool
+	// Private restricts the storage of the responses to the private caches.
+	Private bool
+	// NoCache requires the caches to revalidate the responses before reusing them.
+	NoCache bool
+	// NoStore forbids the caches from storing the responses.
+	NoStore bool
+	// MaxAge is the time during which the responses are fresh, omitted when zero.
+	MaxAge time.Duration
+	// SharedMaxAge overrides the MaxAge for the shared caches, omitted when zero.
+	SharedMaxAge time.Duration
+	// MustRevalidate forbids the caches from reusing the stale responses without revalidation.
+	MustRevalidate bool
+	// Immutable signals that the responses do not change while they are fresh.
+	Immutable bool
+	// StaleWhileRevalidate is the time during which the stale responses may be
+	// reused while they are revalidated in the background, omitted when zero.
+	StaleWhileRevalidate time.Duration
+	// StaleIfError is the time during which the stale responses may be
+	// reused when revalidating them fails, omitted when zero.
+	StaleIfError time.Duration
+	// Vary lists the request headers the responses vary on, e.g. Accept-Language.
+	Vary []string
+}
+
+// String returns the value of the Cache-Control header.
+func (c CacheControl) String() string {
+	var d []string
+
+	flag := func(set bool, directive string) {
+		if set {
+			d = append(d, directive)
+		}
+	}
+
+	seconds := func(v time.Duration, directive string) {
+		if v > 0 {
+			d = append(d, directive+"="+strconv.FormatInt(int64(v/time.Second), 10))
+		}
+	}
+
+	flag(c.Public, "public")
+	flag(c.Private, "private")
+	flag(c.NoCache, "no-cache")
+	flag(c.NoStore, "no-store")
+	seconds(c.MaxAge, "max-age")
+	seconds(c.SharedMaxAge, "s-maxage")
+	flag(c.MustRevalidate, "must-revalidate")
+	flag(c.Immutable, "immutable")
+	seconds(c.StaleWhileRevalidate, "stale-while-revalidate")
+	seconds(c.StaleIfError, "stale-if-error")
+
+	return strings.Join(d, ", ")
+}
+
+// WithCacheControl sets the caching policy of the cacheable responses, i.e.
+// the 2xx responses the no-store policy does not apply to and the 304 responses,
+// see WithNoStore. The policy of a response, see Response.WithCacheControl,
+// takes precedence. A Cache-Control header already set on the writer is never overridden.
+func WithCacheControl(c CacheControl) OptionsModifier {
+	return func(o *options) {
+		o.cacheControl = &c
+	}
+}
+
+// withCacheControl returns a copy of the responder applying the caching policy.
+func (r *responder) withCacheControl(c *CacheControl) *responder {
+	if c == nil {
+		return r
+	}
+
+	cp := *r
+	cp.cacheControl = c
+
+	return &cp
+}
+
+// setCacheControl sets the headers of the caching policy of the response.
+func (r *responder) setCacheControl(h http.Header, code int) {
+	c := r.cacheControl
+
+	if c == nil {
+		c = r.options.cacheControl
+		cacheable := code == status304 || (Is2xx(code) && (r.options.noStore == nil || !r.options.noStore(code)))
+
+		if c == nil || !cacheable {
+			return
+		}
+	}
+
+	if h.Get("Cache-Control") != "" {
+		return
+	}
+
+	if v := c.String(); v != "" {
+		h.Set("Cache-Control", v)
+	}
+
+	if c.MaxAge > 0 && !c.NoStore && !c.NoCache {
+		h.Set("Expires", time.Now().Add(c.MaxAge).UTC().Format(http.TimeFormat))
+	}
+
+	for _, v := range c.Vary {
+		addVary(h, v)
+	}
+}
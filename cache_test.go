@@ -0,0 +1,167 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNoStore(t *testing.T) {
+	testCases := []struct {
+		name string
+		send func(Responder, http.ResponseWriter)
+		want string
+	}{
+		{name: "200", send: func(r Responder, w http.ResponseWriter) { r.Send200(w, "ok") }, want: ""},
+		{name: "201", send: func(r Responder, w http.ResponseWriter) { r.Send201(w, "created") }, want: "no-store"},
+		{name: "202", send: func(r Responder, w http.ResponseWriter) { r.Send202(w, "accepted") }, want: "no-store"},
+		{name: "204", send: func(r Responder, w http.ResponseWriter) { r.Send204(w) }, want: "no-store"},
+		{name: "404", send: func(r Responder, w http.ResponseWriter) { r.Send404(w, nil, "not found") }, want: "no-store"},
+		{
+			name: "500",
+			send: func(r Responder, w http.ResponseWriter) { r.Send500(w, errors.New("boom"), "error") },
+			want: "no-store",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			tc.send(TextResponder(), w)
+
+			if v := w.Header().Get("Cache-Control"); v != tc.want {
+				t.Errorf("expected Cache-Control %q, got %q", tc.want, v)
+			}
+		})
+	}
+
+	t.Run("does not override an existing header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Set("Cache-Control", "private, max-age=60")
+
+		TextResponder().Send404(w, nil, "not found")
+
+		if v := w.Header().Get("Cache-Control"); v != "private, max-age=60" {
+			t.Errorf("expected the existing Cache-Control to be kept, got %q", v)
+		}
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithNoStore(nil)).Send500(w, nil, "error")
+
+		if v := w.Header().Get("Cache-Control"); v != "" {
+			t.Errorf("expected no Cache-Control, got %q", v)
+		}
+	})
+
+	t.Run("uses a custom policy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		onlyServerErrors := func(status int) bool { return status >= 500 }
+
+		TextResponder(WithNoStore(onlyServerErrors)).Send404(w, nil, "not found")
+
+		if v := w.Header().Get("Cache-Control"); v != "" {
+			t.Errorf("expected no Cache-Control on 404, got %q", v)
+		}
+	})
+}
+
+func TestCacheControl(t *testing.T) {
+	c := CacheControl{
+		Public:               true,
+		MaxAge:               time.Hour,
+		SharedMaxAge:         2 * time.Hour,
+		MustRevalidate:       true,
+		StaleWhileRevalidate: time.Minute,
+		StaleIfError:         24 * time.Hour,
+	}
+
+	expected := "public, max-age=3600, s-maxage=7200, must-revalidate, stale-while-revalidate=60, stale-if-error=86400"
+	if c.String() != expected {
+		t.Errorf("expected %q, got %q", expected, c.String())
+	}
+
+	if s := (CacheControl{}).String(); s != "" {
+		t.Errorf("expected an empty policy, got %q", s)
+	}
+}
+
+func TestWithCacheControl(t *testing.T) {
+	policy := CacheControl{Public: true, MaxAge: time.Hour, Vary: []string{"Accept-Language"}}
+
+	t.Run("sets the headers of the cacheable responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithCacheControl(policy)).Send200(w, "ok")
+
+		if v := w.Header().Get("Cache-Control"); v != "public, max-age=3600" {
+			t.Errorf("unexpected Cache-Control %q", v)
+		}
+
+		expires, err := http.ParseTime(w.Header().Get("Expires"))
+		if err != nil || time.Until(expires) < 59*time.Minute {
+			t.Errorf("unexpected Expires %q", w.Header().Get("Expires"))
+		}
+
+		if v := w.Header().Get("Vary"); v != "Accept-Language" {
+			t.Errorf("unexpected Vary %q", v)
+		}
+	})
+
+	t.Run("leaves the other responses to the no-store policy", func(t *testing.T) {
+		responder := JSONResponder(WithCacheControl(policy))
+
+		for _, send := range []func(http.ResponseWriter){
+			func(w http.ResponseWriter) { responder.Send201(w, "created") },
+			func(w http.ResponseWriter) { responder.Send404(w, nil, "not found") },
+		} {
+			w := httptest.NewRecorder()
+			send(w)
+
+			if v := w.Header().Get("Cache-Control"); v != "no-store" {
+				t.Errorf("expected Cache-Control %q, got %q", "no-store", v)
+			}
+
+			if v := w.Header().Get("Expires"); v != "" {
+				t.Errorf("expected no Expires, got %q", v)
+			}
+		}
+	})
+
+	t.Run("gives precedence to the policy of the response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithCacheControl(policy)).
+			Send(w, Error(http.StatusNotFound, nil, "not found").WithCacheControl(CacheControl{MaxAge: time.Minute}))
+
+		if v := w.Header().Get("Cache-Control"); v != "max-age=60" {
+			t.Errorf("unexpected Cache-Control %q", v)
+		}
+	})
+
+	t.Run("does not override the header set on the writer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Set("Cache-Control", "private")
+
+		JSONResponder(WithCacheControl(policy)).Send200(w, "ok")
+
+		if v := w.Header().Get("Cache-Control"); v != "private" {
+			t.Errorf("unexpected Cache-Control %q", v)
+		}
+	})
+
+	t.Run("omits Expires for the responses requiring revalidation", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithCacheControl(CacheControl{NoCache: true, MaxAge: time.Hour})).Send200(w, "ok")
+
+		if v := w.Header().Get("Expires"); v != "" {
+			t.Errorf("expected no Expires, got %q", v)
+		}
+	})
+}
@@ -0,0 +1,379 @@
+package responder
+
+import (
+	"errors"
+	"image"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNilWriter is returned when a response is sent to a nil writer.
+	ErrNilWriter = errors.New("responder: nil response writer")
+	// ErrInvalidContent is returned when the data cannot be formatted, either
+	// because it cannot be marshaled, the formatter panicked or the encryption failed.
+	ErrInvalidContent = errors.New("responder: invalid content")
+)
+
+// errNoResponder reports a send attempted with a nil or zero-value responder.
+var errNoResponder = errors.New("responder: nil responder")
+
+// CheckedResponder sends the same responses as a Responder but reports
+// the failures to the caller instead of only logging them.
+// The errors cover nil writers, responses already written to guarded writers,
+// formatting failures and write failures.
+// When the data cannot be formatted, nothing is written to the client,
+// leaving the caller free to send another response.
+type CheckedResponder interface {
+	// Send200 sends a 200 OK response, see Responder.
+	Send200(responseWriter, any) error
+
+	// Send201 sends a 201 Created response, see Responder.
+	Send201(responseWriter, any) error
+
+	// Send202 sends a 202 Accepted response, see Responder.
+	Send202(responseWriter, any) error
+
+	// Send204 sends a 204 No Content response, see Responder.
+	Send204(responseWriter) error
+
+	// Send205 sends a 205 Reset Content response, see Responder.
+	Send205(responseWriter) error
+
+	// Send304 sends a 304 Not Modified response, see Responder.
+	Send304(responseWriter) error
+
+	// Redirect301 sends a 301 Moved Permanently response, see Responder.
+	Redirect301(responseWriter, *http.Request, string) error
+
+	// Redirect302 sends a 302 Found response, see Responder.
+	Redirect302(responseWriter, *http.Request, string) error
+
+	// Redirect303 sends a 303 See Other response, see Responder.
+	Redirect303(responseWriter, *http.Request, string) error
+
+	// Redirect307 sends a 307 Temporary Redirect response, see Responder.
+	Redirect307(responseWriter, *http.Request, string) error
+
+	// Redirect308 sends a 308 Permanent Redirect response, see Responder.
+	Redirect308(responseWriter, *http.Request, string) error
+
+	// Redirect sends a redirect response with the given 3xx status code, see Responder.
+	Redirect(responseWriter, *http.Request, string, int) error
+
+	// Send400 sends a 400 Bad Request response, see Responder.
+	Send400(responseWriter, error, any) error
+
+	// Send401 sends a 401 Unauthorized response, see Responder.
+	Send401(responseWriter, error, any) error
+
+	// Send403 sends a 403 Forbidden response, see Responder.
+	Send403(responseWriter, error, any) error
+
+	// Send404 sends a 404 Not Found response, see Responder.
+	Send404(responseWriter, error, any) error
+
+	// Send405 sends a 405 Method Not Allowed response, see Responder.
+	Send405(responseWriter, error, any) error
+
+	// Send406 sends a 406 Not Acceptable response, see Responder.
+	Send406(responseWriter, error, any) error
+
+	// Send409 sends a 409 Conflict response, see Responder.
+	Send409(responseWriter, error, any) error
+
+	// Send410 sends a 410 Gone response, see Responder.
+	Send410(responseWriter, error, any) error
+
+	// Send415 sends a 415 Unsupported Media Type response, see Responder.
+	Send415(responseWriter, error, any) error
+
+	// Send422 sends a 422 Unprocessable Entity response, see Responder.
+	Send422(responseWriter, error, any) error
+
+	// Send429 sends a 429 Too Many Requests response, see Responder.
+	Send429(responseWriter, error, any, time.Duration) error
+
+	// Send500 sends a 500 Internal Server Error response, see Responder.
+	Send500(responseWriter, error, any) error
+
+	// Send501 sends a 501 Not Implemented response, see Responder.
+	Send501(responseWriter, error, any) error
+
+	// Send502 sends a 502 Bad Gateway response, see Responder.
+	Send502(responseWriter, error, any) error
+
+	// Send503 sends a 503 Service Unavailable response, see Responder.
+	Send503(responseWriter, error, any) error
+
+	// Send504 sends a 504 Gateway Timeout response, see Responder.
+	Send504(responseWriter, error, any) error
+
+	// SendError sends the error response the error maps to, see Responder.
+	SendError(responseWriter, error) error
+
+	// SendValidation sends a 422 Unprocessable Entity response, see Responder.
+	SendValidation(responseWriter, []FieldError) error
+
+	// Send206 sends a range of the content, see Responder.
+	Send206(responseWriter, *http.Request, io.ReadSeeker, int64) error
+
+	// SendSuccessStatus sends a response with the given 2xx status code, see Responder.
+	SendSuccessStatus(responseWriter, int, any) error
+
+	// SendErrorStatus sends a response with the given 4xx or 5xx status code, see Responder.
+	SendErrorStatus(responseWriter, int, error, any) error
+
+	// SendQueued reports the position of a job in a processing queue, see Responder.
+	SendQueued(responseWriter, int, time.Duration) error
+
+	// SendPage sends a page of results, see Responder.
+	SendPage(responseWriter, Paginated) error
+
+	// SendSitemap sends the sitemap of the URLs, see Responder.
+	SendSitemap(responseWriter, []SitemapURL) error
+
+	// SendEcho sends a 200 OK response describing the request, see Responder.
+	SendEcho(responseWriter, *http.Request) error
+
+	// SendFile serves the content of a file, see Responder.
+	SendFile(responseWriter, *http.Request, string, io.ReadSeeker, time.Time) error
+
+	// SendAttachment sends the data as an attachment, see Responder.
+	SendAttachment(responseWriter, string, any) error
+
+	// SendBlob sends the bytes as is, see Responder.
+	SendBlob(responseWriter, []byte, string) error
+
+	// SendImage sends the encoded image, see Responder.
+	SendImage(responseWriter, image.Image, string) error
+
+	// SendStream streams the values received from the channel, see Responder.
+	SendStream(responseWriter, <-chan any) error
+
+	// SendSeq streams the values of the iterator, see Responder.
+	SendSeq(responseWriter, iter.Seq[any]) error
+
+	// Send sends a response with the given status code and body.
+	Send(responseWriter, Response) error
+
+	// WithRequest returns a copy of the responder bound to the request
+	// being handled, enabling the features depending on it, see Responder.
+	WithRequest(*http.Request) CheckedResponder
+}
+
+// Checked returns a CheckedResponder sending the responses of the responder.
+// Responders that were not created by this package, such as Noop,
+// result in a CheckedResponder that never writes anything nor fails.
+func Checked(r Responder) CheckedResponder {
+	rr, ok := r.(*responder)
+	if !ok || rr == nil {
+		return checked{}
+	}
+
+	c := *rr
+	c.checked = true
+
+	return checked{r: &c}
+}
+
+type checked struct {
+	r *responder
+}
+
+// result hides the errors of the responders unable to send anything.
+func result(err error) error {
+	if errors.Is(err, errNoResponder) {
+		return nil
+	}
+
+	return err
+}
+
+func (c checked) WithRequest(req *http.Request) CheckedResponder {
+	if c.r == nil {
+		return c
+	}
+
+	r, _ := c.r.WithRequest(req).(*responder)
+
+	return checked{r: r}
+}
+
+func (c checked) Send(rw responseWriter, resp Response) error {
+	return result(c.r.sendResponse(rw, resp))
+}
+
+func (c checked) Send200(rw responseWriter, data any) error {
+	return result(c.r.sendData(rw, status200, data))
+}
+
+func (c checked) Send201(rw responseWriter, data any) error {
+	return result(c.r.sendData(rw, status201, data))
+}
+
+func (c checked) Send202(rw responseWriter, data any) error {
+	return result(c.r.sendData(rw, status202, data))
+}
+
+func (c checked) Send204(rw responseWriter) error {
+	return result(c.r.sendEmpty(rw, status204))
+}
+
+func (c checked) Send205(rw responseWriter) error {
+	return result(c.r.sendEmpty(rw, status205))
+}
+
+func (c checked) Send304(rw responseWriter) error {
+	return result(c.r.sendEmpty(rw, status304))
+}
+
+func (c checked) Send206(rw responseWriter, req *http.Request, content io.ReadSeeker, total int64) error {
+	return result(c.r.send206(rw, req, content, total))
+}
+
+func (c checked) SendSuccessStatus(rw responseWriter, code int, data any) error {
+	return result(c.r.sendSuccessStatus(rw, code, data))
+}
+
+func (c checked) SendErrorStatus(rw responseWriter, code int, err error, message any) error {
+	return result(c.r.sendErrorStatus(rw, code, err, message))
+}
+
+func (c checked) SendQueued(rw responseWriter, position int, eta time.Duration) error {
+	return result(c.r.sendQueued(rw, position, eta))
+}
+
+func (c checked) SendPage(rw responseWriter, p Paginated) error {
+	return result(c.r.sendPage(rw, p))
+}
+
+func (c checked) SendSitemap(rw responseWriter, urls []SitemapURL) error {
+	return result(c.r.sendSitemap(rw, urls))
+}
+
+func (c checked) SendEcho(rw responseWriter, req *http.Request) error {
+	return result(c.r.sendEcho(rw, req))
+}
+
+func (c checked) SendFile(rw responseWriter, req *http.Request, name string, content io.ReadSeeker, modtime time.Time) error {
+	return result(c.r.sendFile(rw, req, name, content, modtime))
+}
+
+func (c checked) SendAttachment(rw responseWriter, filename string, data any) error {
+	return result(c.r.sendAttachment(rw, filename, data))
+}
+
+func (c checked) SendBlob(rw responseWriter, data []byte, contentType string) error {
+	return result(c.r.sendBlob(rw, data, contentType))
+}
+
+func (c checked) SendImage(rw responseWriter, img image.Image, format string) error {
+	return result(c.r.sendImage(rw, img, format))
+}
+
+func (c checked) SendStream(rw responseWriter, ch <-chan any) error {
+	return result(c.r.streamNDJSON(rw, channelSeq(ch), func(int) bool { return len(ch) == 0 }))
+}
+
+func (c checked) SendSeq(rw responseWriter, seq iter.Seq[any]) error {
+	return result(c.r.streamNDJSON(rw, seq, func(n int) bool { return n%streamFlushEvery == 0 }))
+}
+
+func (c checked) Redirect301(rw responseWriter, req *http.Request, loc string) error {
+	return result(c.r.redirect(rw, req, loc, status301))
+}
+
+func (c checked) Redirect302(rw responseWriter, req *http.Request, loc string) error {
+	return result(c.r.redirect(rw, req, loc, status302))
+}
+
+func (c checked) Redirect303(rw responseWriter, req *http.Request, loc string) error {
+	return result(c.r.redirect(rw, req, loc, status303))
+}
+
+func (c checked) Redirect307(rw responseWriter, req *http.Request, loc string) error {
+	return result(c.r.redirect(rw, req, loc, status307))
+}
+
+func (c checked) Redirect308(rw responseWriter, req *http.Request, loc string) error {
+	return result(c.r.redirect(rw, req, loc, status308))
+}
+
+func (c checked) Redirect(rw responseWriter, req *http.Request, loc string, code int) error {
+	return result(c.r.redirectStatus(rw, req, loc, code))
+}
+
+func (c checked) Send400(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status400, err, message))
+}
+
+func (c checked) Send401(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status401, err, message))
+}
+
+func (c checked) Send403(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status403, err, message))
+}
+
+func (c checked) Send404(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status404, err, message))
+}
+
+func (c checked) Send405(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status405, err, message))
+}
+
+func (c checked) Send406(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status406, err, message))
+}
+
+func (c checked) Send409(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status409, err, message))
+}
+
+func (c checked) Send410(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status410, err, message))
+}
+
+func (c checked) Send415(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status415, err, message))
+}
+
+func (c checked) Send422(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status422, err, message))
+}
+
+func (c checked) Send429(rw responseWriter, err error, message any, after time.Duration) error {
+	return result(c.r.sendTooManyRequests(rw, err, message, after))
+}
+
+func (c checked) Send500(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status500, err, message))
+}
+
+func (c checked) Send501(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status501, err, message))
+}
+
+func (c checked) Send502(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status502, err, message))
+}
+
+func (c checked) Send503(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status503, err, message))
+}
+
+func (c checked) Send504(rw responseWriter, err error, message any) error {
+	return result(c.r.sendError(rw, status504, err, message))
+}
+
+func (c checked) SendError(rw responseWriter, err error) error {
+	return result(c.r.sendMappedError(rw, err))
+}
+
+func (c checked) SendValidation(rw responseWriter, fieldErrs []FieldError) error {
+	return result(c.r.sendResponse(rw, Unprocessable(fieldErrs...)))
+}
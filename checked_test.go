@@ -0,0 +1,111 @@
+package responder
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestChecked(t *testing.T) {
+	t.Run("returns nil on success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := Checked(JSONResponder()).Send200(w, map[string]int{"id": 1}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if w.Body.String() != `{"id":1}` {
+			t.Errorf("expected the body to be sent, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("reports marshaling failures without writing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(JSONResponder()).Send200(w, math.Inf(1))
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Fatalf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Body.Len() != 0 || len(w.Header()) != 0 {
+			t.Errorf("expected nothing to be written, got headers %v and body %q", w.Header(), w.Body.String())
+		}
+	})
+
+	t.Run("reports formatter panics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		formatter := func(any) []byte { panic("boom") }
+
+		err := Checked(TextResponder(WithDataFormatter(formatter))).Send200(w, "data")
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Fatalf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+
+	t.Run("reports error formatter panics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		formatter := func(any) any { panic("boom") }
+
+		err := Checked(TextResponder(WithErrorFormatter(formatter))).Send400(w, nil, "bad request")
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Fatalf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+
+	t.Run("reports write failures", func(t *testing.T) {
+		w := failingWriter{httptest.NewRecorder()}
+
+		if err := Checked(TextResponder()).Send500(w, nil, "error"); err == nil {
+			t.Error("expected the write failure to be reported")
+		}
+	})
+
+	t.Run("reports nil writers", func(t *testing.T) {
+		if err := Checked(TextResponder()).Send(nil, Success(http.StatusOK, "data")); !errors.Is(err, ErrNilWriter) {
+			t.Errorf("expected ErrNilWriter, got %v", err)
+		}
+	})
+
+	t.Run("reports nil responses", func(t *testing.T) {
+		if err := Checked(TextResponder()).Send(httptest.NewRecorder(), nil); err == nil {
+			t.Error("expected the nil response to be reported")
+		}
+	})
+
+	t.Run("keeps being checked once bound to a request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		err := Checked(JSONResponder()).WithRequest(req).Send201(httptest.NewRecorder(), math.NaN())
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+
+	t.Run("does not change the unchecked responder", func(t *testing.T) {
+		responder := JSONResponder()
+		_ = Checked(responder)
+
+		w := httptest.NewRecorder()
+		responder.Send200(w, math.Inf(1))
+
+		if w.Code != http.StatusInternalServerError || w.Body.Len() == 0 {
+			t.Errorf("expected the error response to be sent, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("noop never fails", func(t *testing.T) {
+		if err := Checked(Noop()).Send200(nil, "data"); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
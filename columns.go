@@ -0,0 +1,134 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ColumnsParam is the query parameter selecting the columns of a Table.
+const ColumnsParam = "columns"
+
+// ErrUnknownColumn is returned when the request selects a column that is not allowed.
+var ErrUnknownColumn = errors.New("responder: unknown column")
+
+// ParseColumns returns the columns selected by the request with the ?columns=a,b,c
+// query parameter, in the requested order and without duplicates. The columns
+// are validated against the allowed ones, which are all returned when the request
+// does not select any. The error wraps ErrUnknownColumn and is meant to be sent
+// in a 400 Bad Request response.
+func ParseColumns(r *http.Request, allowed ...string) ([]string, error) {
+	param := r.URL.Query().Get(ColumnsParam)
+	if strings.TrimSpace(param) == "" {
+		return allowed, nil
+	}
+
+	var columns []string
+
+	for c := range strings.SplitSeq(param, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" || slices.Contains(columns, c) {
+			continue
+		}
+
+		if !slices.Contains(allowed, c) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, c)
+		}
+
+		columns = append(columns, c)
+	}
+
+	return columns, nil
+}
+
+// Table is tabular data restricted to a selection of columns, e.g. from ParseColumns.
+// It is formatted as CSV, with a header row, by the CSV responders, with their
+// delimiter, quoting and locale, and as an array of objects by the other responders.
+type Table struct {
+	// Columns lists the columns to send, in order.
+	Columns []string
+	// Rows holds the values of the rows by column.
+	Rows []map[string]any
+}
+
+// MarshalJSON encodes the table as an array of objects whose keys follow the order of the columns.
+func (t Table) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('[')
+
+	for i, row := range t.Rows {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteByte('{')
+
+		for j, c := range t.Columns {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+
+			k, err := json.Marshal(c)
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := json.Marshal(row[c])
+			if err != nil {
+				return nil, err
+			}
+
+			b.Write(k)
+			b.WriteByte(':')
+			b.Write(v)
+		}
+
+		b.WriteByte('}')
+	}
+
+	b.WriteByte(']')
+
+	return b.Bytes(), nil
+}
+
+// MarshalCSV encodes the table as CSV, the missing values being empty.
+func (t Table) MarshalCSV() ([]byte, error) {
+	records, err := t.csvRecords(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return csvOptions{}.encode(records)
+}
+
+// csvRecords returns the records of the table, the floats and the dates
+// being formatted with the conventions of the locale, if any.
+func (t Table) csvRecords(l *Locale) ([][]string, error) {
+	records := make([][]string, 0, len(t.Rows)+1)
+	records = append(records, t.Columns)
+
+	for _, row := range t.Rows {
+		record := make([]string, len(t.Columns))
+
+		for i, c := range t.Columns {
+			if v, ok := row[c]; ok && v != nil {
+				s, err := csvValue(reflect.ValueOf(v), l)
+				if err != nil {
+					return nil, err
+				}
+
+				record[i] = s
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,88 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseColumns(t *testing.T) {
+	allowed := []string{"id", "name", "email"}
+
+	testCases := []struct {
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{query: "", want: allowed},
+		{query: "?columns=", want: allowed},
+		{query: "?columns=email,id", want: []string{"email", "id"}},
+		{query: "?columns=name,%20name,,id", want: []string{"name", "id"}},
+		{query: "?columns=id,password", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			got, err := ParseColumns(httptest.NewRequest(http.MethodGet, "/export"+tc.query, nil), allowed...)
+
+			if tc.wantErr {
+				if !errors.Is(err, ErrUnknownColumn) {
+					t.Errorf("expected ErrUnknownColumn, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	table := Table{
+		Columns: []string{"name", "id"},
+		Rows: []map[string]any{
+			{"id": 1, "name": "Ada", "email": "ada@example.com"},
+			{"id": 2, "name": "Grace, Hopper"},
+			{"id": 3},
+		},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send200(w, table)
+
+		want := `[{"name":"Ada","id":1},{"name":"Grace, Hopper","id":2},{"name":null,"id":3}]`
+		if w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder().Send200(w, table)
+
+		want := "name,id\nAda,1\n\"Grace, Hopper\",2\n,3\n"
+		if w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("CSV with the options of the responder", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		table := Table{Columns: []string{"a", "b"}, Rows: []map[string]any{{"a": 1, "b": 1234.5}}}
+		CSVResponder(WithCSVDelimiter(';'), WithLocale(LocaleFrFR)).Send200(w, table)
+
+		want := "a;b\n1;1\u202f234,5\n"
+		if w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+}
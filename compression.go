@@ -0,0 +1,279 @@
+package responder
+
+import (
+	"compress/flate"
+	"io"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// compressibleTypes holds the built-in compression defaults per media type.
+// Textual formats compress well whereas images and archives are already compressed
+// and only waste CPU cycles when compressed a second time.
+var compressibleTypes = map[string]bool{
+	"application/json":         true,
+	"application/xml":          true,
+	"application/javascript":   true,
+	"application/x-ndjson":     true,
+	"image/svg+xml":            true,
+	"text/csv":                 true,
+	"text/html":                true,
+	"text/plain":               true,
+	"text/css":                 true,
+	"application/gzip":         false,
+	"application/octet-stream": false,
+	"application/pdf":          false,
+	"application/zip":          false,
+	"image/gif":                false,
+	"image/jpeg":               false,
+	"image/png":                false,
+	"image/webp":               false,
+}
+
+// compressible reports whether a response with the given content type should be compressed.
+// The overrides take precedence over the built-in defaults. Media types unknown to both
+// are compressed when they are textual (text/*, +json and +xml suffixes).
+func compressible(contentType string, overrides map[string]bool) bool {
+	mt := mediaType(contentType)
+	if mt == "" {
+		return false
+	}
+
+	if v, ok := overrides[mt]; ok {
+		return v
+	}
+
+	if v, ok := compressibleTypes[mt]; ok {
+		return v
+	}
+
+	return strings.HasPrefix(mt, "text/") ||
+		strings.HasSuffix(mt, "+json") ||
+		strings.HasSuffix(mt, "+xml")
+}
+
+// Compressible reports whether the built-in defaults consider content of the given type
+// worth compressing. It can be used by compression middlewares sitting in front of
+// the responders to apply the same policy.
+func Compressible(contentType string) bool {
+	return compressible(contentType, nil)
+}
+
+// defaultCompressionThreshold is the size under which bodies are not compressed,
+// the saving being too small to be worth the CPU time and the encoding overhead.
+const defaultCompressionThreshold = 1024
+
+// Compressor creates a writer compressing the data written to w.
+type Compressor func(w io.Writer) (io.WriteCloser, error)
+
+// encodingPreference lists the content codings from the most to the least preferred,
+// used to break ties between codings the client accepts with the same quality.
+var encodingPreference = []string{"br", "zstd", "gzip", "deflate"}
+
+// defaultCompressors returns the compressors available out of the box.
+// Brotli and Zstandard are not part of the standard library,
+// they can be registered with WithCompressor.
+func defaultCompressors() map[string]Compressor {
+	return map[string]Compressor{
+		"gzip": func(w io.Writer) (io.WriteCloser, error) {
+			return newPooledGzipWriter(w), nil
+		},
+		"deflate": func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.DefaultCompression)
+		},
+	}
+}
+
+// WithCompression enables the compression of the response bodies,
+// negotiated from the Accept-Encoding header of the request. The bodies are
+// compressed when they exceed the threshold (1KB by default) and their content
+// type is worth compressing, see Compressible. It only applies to responders
+// bound to a request with WithRequest, and not to streamed bodies.
+// The responses carry the Content-Encoding and Vary: Accept-Encoding headers.
+func WithCompression() OptionsModifier {
+	return func(o *options) {
+		o.compression.enabled = true
+	}
+}
+
+// WithCompressionThreshold sets the minimum size of the bodies to compress.
+func WithCompressionThreshold(size int) OptionsModifier {
+	return func(o *options) {
+		o.compression.threshold = size
+	}
+}
+
+// WithCompressor registers the compressor of a content coding, e.g. "br" or "zstd".
+// It replaces the built-in compressor of the coding, if any.
+func WithCompressor(encoding string, c Compressor) OptionsModifier {
+	return func(o *options) {
+		o.compression.compressors[strings.ToLower(encoding)] = c
+	}
+}
+
+// WithCompressibleTypes overrides the built-in compression defaults
+// of the given media types, e.g. {"image/svg+xml": false}.
+func WithCompressibleTypes(types map[string]bool) OptionsModifier {
+	return func(o *options) {
+		maps.Copy(o.compression.types, types)
+	}
+}
+
+// compressionOptions holds the configuration of the compression layer.
+type compressionOptions struct {
+	enabled     bool
+	threshold   int
+	compressors map[string]Compressor
+	types       map[string]bool
+}
+
+func newCompressionOptions() compressionOptions {
+	return compressionOptions{
+		threshold:   defaultCompressionThreshold,
+		compressors: defaultCompressors(),
+		types:       make(map[string]bool),
+	}
+}
+
+// negotiateEncoding picks the content coding to use among the available ones
+// given the Accept-Encoding header. It returns an empty string when the body
+// must be sent as is.
+func negotiateEncoding(header string, available map[string]Compressor) string {
+	var (
+		best      string
+		bestQ     float64
+		anyQ      = -1.0
+		qualities = make(map[string]float64)
+	)
+
+	for part := range strings.SplitSeq(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+
+		q := 1.0
+
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.EqualFold(k, "q") {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+
+		if coding == "*" {
+			anyQ = q
+		} else if coding != "" {
+			qualities[coding] = q
+		}
+	}
+
+	for _, coding := range encodingPreference {
+		if _, ok := available[coding]; !ok {
+			continue
+		}
+
+		q, ok := qualities[coding]
+		if !ok {
+			q = anyQ
+		}
+
+		if q > bestQ {
+			best, bestQ = coding, q
+		}
+	}
+
+	return best
+}
+
+// negotiateCompression returns the content coding of the response, or an empty
+// string when it must be sent as is. It adds Accept-Encoding to the Vary header
+// of the compressible responses.
+func (r *responder) negotiateCompression(rw responseWriter, code int, contentType string) string {
+	c := &r.options.compression
+
+	if !c.enabled || r.request == nil || !bodyAllowed(code) || !compressible(contentType, c.types) {
+		return ""
+	}
+
+	addVary(rw.Header(), "Accept-Encoding")
+
+	if rw.Header().Get("Content-Encoding") != "" {
+		return ""
+	}
+
+	return negotiateEncoding(r.request.Header.Get("Accept-Encoding"), c.compressors)
+}
+
+// compresses reports whether the body of a 200 OK response would be compressed,
+// without touching the header of the response.
+func (r *responder) compresses(rw responseWriter, body []byte) bool {
+	c := &r.options.compression
+
+	contentType := r.contentType
+	if r.options.encrypter != nil {
+		contentType = r.options.encrypter.ContentType()
+	}
+
+	return c.enabled && r.request != nil && len(body) >= c.threshold &&
+		compressible(contentType, c.types) && rw.Header().Get("Content-Encoding") == "" &&
+		negotiateEncoding(r.request.Header.Get("Accept-Encoding"), c.compressors) != ""
+}
+
+// compress compresses the body according to the request when it is worth it.
+// It returns the body unchanged when it is not.
+func (r *responder) compress(rw responseWriter, code int, contentType string, body []byte) []byte {
+	c := &r.options.compression
+
+	encoding := r.negotiateCompression(rw, code, contentType)
+	if encoding == "" || len(body) < c.threshold {
+		return body
+	}
+
+	buf := getBuffer()
+
+	w, err := c.compressors[encoding](buf)
+	if err == nil {
+		_, err = w.Write(body)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	if err != nil {
+		if r.options.logger != nil {
+			r.options.logger.Error("failed to compress response",
+				"status", code,
+				"encoding", encoding,
+				"error", err,
+			)
+		}
+
+		return body
+	}
+
+	rw.Header().Set("Content-Encoding", encoding)
+
+	return releaseBytes(buf)
+}
+
+// addVary adds the fields to the Vary header, except the ones already listed.
+func addVary(h http.Header, fields ...string) {
+	for _, field := range fields {
+		if !hasVary(h, field) {
+			h.Add("Vary", field)
+		}
+	}
+}
+
+// hasVary reports whether the Vary header lists the field.
+func hasVary(h http.Header, field string) bool {
+	for _, v := range h.Values("Vary") {
+		for f := range strings.SplitSeq(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(f), field) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,192 @@
+package responder
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCompressible(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		overrides   map[string]bool
+		want        bool
+	}{
+		{name: "JSON", contentType: JSONContentType, want: true},
+		{name: "HTML", contentType: HTMLContentType, want: true},
+		{name: "CSV", contentType: CSVContentType, want: true},
+		{name: "XML", contentType: XMLContentType, want: true},
+		{name: "PNG", contentType: "image/png", want: false},
+		{name: "zip", contentType: "application/zip", want: false},
+		{name: "unknown text type", contentType: "text/markdown", want: true},
+		{name: "unknown JSON suffix", contentType: "application/problem+json", want: true},
+		{name: "unknown binary type", contentType: "application/x-custom", want: false},
+		{name: "invalid content type", contentType: "/", want: false},
+		{
+			name:        "override disables a default",
+			contentType: JSONContentType,
+			overrides:   map[string]bool{"application/json": false},
+			want:        false,
+		},
+		{
+			name:        "override enables a default",
+			contentType: "image/png",
+			overrides:   map[string]bool{"image/png": true},
+			want:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := compressible(tc.contentType, tc.overrides); got != tc.want {
+				t.Errorf("expected compressible(%q) to be %t, got %t", tc.contentType, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	available := defaultCompressors()
+
+	testCases := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "gzip", want: "gzip"},
+		{header: "deflate, gzip", want: "gzip"},
+		{header: "gzip;q=0.5, deflate", want: "deflate"},
+		{header: "gzip;q=0", want: ""},
+		{header: "br", want: ""},
+		{header: "*", want: "gzip"},
+		{header: "*;q=0.1, gzip;q=0", want: "deflate"},
+		{header: "identity", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			if got := negotiateEncoding(tc.header, available); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWithCompression(t *testing.T) {
+	large := strings.Repeat("compress me please ", 200)
+
+	newRequest := func(acceptEncoding string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+
+		return req
+	}
+
+	t.Run("compresses large bodies with gzip", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithCompression()).WithRequest(newRequest("gzip, deflate")).Send200(w, large)
+
+		if v := w.Header().Get("Content-Encoding"); v != "gzip" {
+			t.Fatalf("expected Content-Encoding %q, got %q", "gzip", v)
+		}
+
+		if v := w.Header().Get("Vary"); v != "Accept-Encoding" {
+			t.Errorf("expected Vary %q, got %q", "Accept-Encoding", v)
+		}
+
+		if v := w.Header().Get("Content-Length"); v != strconv.Itoa(w.Body.Len()) {
+			t.Errorf("expected Content-Length to match the compressed body, got %q", v)
+		}
+
+		zr, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("failed to read gzip body: %v", err)
+		}
+
+		body, _ := io.ReadAll(zr)
+		if string(body) != large {
+			t.Error("expected the decompressed body to match the original")
+		}
+	})
+
+	t.Run("does not compress small bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithCompression()).WithRequest(newRequest("gzip")).Send200(w, "small")
+
+		if v := w.Header().Get("Content-Encoding"); v != "" {
+			t.Errorf("expected no Content-Encoding, got %q", v)
+		}
+
+		if v := w.Header().Get("Vary"); v != "Accept-Encoding" {
+			t.Errorf("expected Vary %q, got %q", "Accept-Encoding", v)
+		}
+	})
+
+	t.Run("honors the threshold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithCompression(), WithCompressionThreshold(1)).WithRequest(newRequest("gzip")).Send200(w, "small")
+
+		if v := w.Header().Get("Content-Encoding"); v != "gzip" {
+			t.Errorf("expected Content-Encoding %q, got %q", "gzip", v)
+		}
+	})
+
+	t.Run("does not compress incompressible types", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		New("image/png", WithCompression()).WithRequest(newRequest("gzip")).Send200(w, large)
+
+		if v := w.Header().Get("Content-Encoding"); v != "" {
+			t.Errorf("expected no Content-Encoding, got %q", v)
+		}
+	})
+
+	t.Run("honors type overrides", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		responder := TextResponder(WithCompression(), WithCompressibleTypes(map[string]bool{"text/plain": false}))
+
+		responder.WithRequest(newRequest("gzip")).Send200(w, large)
+
+		if v := w.Header().Get("Content-Encoding"); v != "" {
+			t.Errorf("expected no Content-Encoding, got %q", v)
+		}
+	})
+
+	t.Run("uses registered compressors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		identity := func(w io.Writer) (io.WriteCloser, error) {
+			return nopWriteCloser{w}, nil
+		}
+
+		responder := TextResponder(WithCompression(), WithCompressor("br", identity))
+		responder.WithRequest(newRequest("gzip, br")).Send200(w, large)
+
+		if v := w.Header().Get("Content-Encoding"); v != "br" {
+			t.Errorf("expected Content-Encoding %q, got %q", "br", v)
+		}
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().WithRequest(newRequest("gzip")).Send200(w, large)
+
+		if v := w.Header().Get("Content-Encoding"); v != "" {
+			t.Errorf("expected no Content-Encoding, got %q", v)
+		}
+	})
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
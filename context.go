@@ -0,0 +1,66 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrRequestCanceled is returned when the request the responder is bound to
+// was canceled before the successful response was sent.
+var ErrRequestCanceled = errors.New("responder: request canceled")
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the responder.
+func NewContext(ctx context.Context, r Responder) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the responder carried by the context, if any.
+func FromContext(ctx context.Context) (Responder, bool) {
+	r, ok := ctx.Value(contextKey{}).(Responder)
+
+	return r, ok
+}
+
+// Bind returns a middleware storing the responder in the request context,
+// bound to the request, where handlers retrieve it with FromContext.
+// It gives the handlers a responder aware of the request without
+// calling WithRequest themselves, see Routes for mixed content.
+func Bind(r Responder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(NewContext(req.Context(), r.WithRequest(req))))
+		})
+	}
+}
+
+// context returns the context of the request the responder is bound to,
+// or the background context when it is not bound to any.
+func (r *responder) context() context.Context {
+	if r.request == nil {
+		return context.Background()
+	}
+
+	return r.request.Context()
+}
+
+// canceled returns an ErrRequestCanceled error when the request the responder
+// is bound to was canceled, net/http canceling it once the client is gone.
+// The requests exceeding their deadline are still answered, e.g. with
+// the 504 Gateway Timeout of the handlers hitting their own timeout, and
+// so are the error responses, sparing the client an empty 200 OK.
+func (r *responder) canceled(code int) error {
+	cause := context.Cause(r.context())
+	if cause == nil || code >= status400 || !errors.Is(cause, context.Canceled) {
+		return nil
+	}
+
+	if r.options.logger != nil {
+		r.options.logger.Warn("request canceled before the response was sent", "status", code, "cause", cause)
+	}
+
+	return fmt.Errorf("%w: %w", ErrRequestCanceled, cause)
+}
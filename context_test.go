@@ -0,0 +1,113 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	t.Run("stores the responder bound to the request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		var bound *responder
+
+		h := Bind(JSONResponder())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp, ok := FromContext(r.Context())
+			if !ok {
+				t.Fatal("expected a responder in the context")
+			}
+
+			bound, _ = resp.(*responder)
+		}))
+
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		if bound == nil || bound.request == nil || bound.request.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected the responder to be bound to the request, got %+v", bound)
+		}
+	})
+}
+
+func TestCanceledRequest(t *testing.T) {
+	t.Run("does not send responses to canceled requests", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := Checked(JSONResponder()).WithRequest(req).Send200(w, "ok")
+		if !errors.Is(err, ErrRequestCanceled) || !errors.Is(err, context.Canceled) {
+			t.Errorf("expected ErrRequestCanceled wrapping context.Canceled, got %v", err)
+		}
+
+		if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("does not send streams to canceled requests", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		responder := Checked(JSONResponder()).WithRequest(httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+		for name, send := range map[string]func(w http.ResponseWriter) error{
+			"reader": func(w http.ResponseWriter) error { return responder.Send200(w, strings.NewReader("report")) },
+			"seq":    func(w http.ResponseWriter) error { return responder.SendSeq(w, slices.Values([]any{1})) },
+		} {
+			w := httptest.NewRecorder()
+
+			if err := send(w); !errors.Is(err, ErrRequestCanceled) {
+				t.Errorf("%s: expected ErrRequestCanceled, got %v", name, err)
+			}
+
+			if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+				t.Errorf("%s: expected nothing to be written, got %q", name, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("sends the responses of requests exceeding their deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		JSONResponder().WithRequest(req).Send504(w, context.Cause(ctx), "timeout")
+
+		if w.Code != http.StatusGatewayTimeout || w.Body.String() != `{"error":"timeout"}` {
+			t.Errorf("expected the 504 to be sent, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("sends the error responses of canceled requests", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := Checked(TextResponder()).WithRequest(req).Send500(w, errors.New("failed"), "error")
+		if err != nil || w.Code != http.StatusInternalServerError {
+			t.Errorf("expected the 500 to be sent, got %d %v", w.Code, err)
+		}
+	})
+
+	t.Run("sends the responses of live requests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).Send200(w, "ok")
+
+		if w.Body.String() != "ok" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,242 @@
+package responder
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// errInvalidDelimiter is returned when the CSV delimiter cannot separate fields.
+var errInvalidDelimiter = errors.New("responder: invalid CSV delimiter")
+
+// CSVMarshaler is implemented by the values able to encode themselves as CSV.
+// The CSV responders use it in preference to the other encodings,
+// encoding its records again with their delimiter and quoting.
+type CSVMarshaler interface {
+	// MarshalCSV returns the CSV encoding of the value.
+	MarshalCSV() ([]byte, error)
+}
+
+// csvRecorder is implemented by the values of the package encoded as CSV,
+// providing their records so that the CSV options and the locale apply.
+type csvRecorder interface {
+	csvRecords(l *Locale) ([][]string, error)
+}
+
+// WithCSVDelimiter sets the field delimiter of the documents encoded
+// by the CSV responders, e.g. ';' or '\t'. It defaults to a comma.
+func WithCSVDelimiter(delimiter rune) OptionsModifier {
+	return func(o *options) {
+		o.csv.delimiter = delimiter
+	}
+}
+
+// WithCSVQuoteAll quotes all the fields of the documents encoded by the CSV
+// responders rather than only the fields requiring it, as some spreadsheet
+// imports expect.
+func WithCSVQuoteAll(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.csv.quoteAll = enabled
+	}
+}
+
+// csvOptions holds the configuration of the CSV encoding.
+type csvOptions struct {
+	delimiter rune
+	quoteAll  bool
+}
+
+// formatCSV is the default data formatter of the CSV responders.
+// Slices of structs are encoded with a header row holding the names of the fields,
+// taken from their csv tag when they have one, the fields tagged "-" being skipped.
+// Slices of string slices are encoded as is, and the other values as by formatText.
+func (o *options) formatCSV(c any) ([]byte, error) {
+	return o.formatLocalizedCSV(nil, c)
+}
+
+// formatLocalizedCSV formats the data as formatCSV does, the fields holding
+// floats and dates being formatted with the conventions of the locale, if any.
+func (o *options) formatLocalizedCSV(l *Locale, c any) ([]byte, error) {
+	if m, ok := c.(csvRecorder); ok {
+		records, err := m.csvRecords(l)
+		if err != nil {
+			return nil, err
+		}
+
+		return o.csv.encode(records)
+	}
+
+	if m, ok := c.(CSVMarshaler); ok {
+		return o.csv.marshal(m)
+	}
+
+	if records, ok := c.([][]string); ok {
+		return o.csv.encode(records)
+	}
+
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return formatText(c)
+	}
+
+	t := indirectType(v.Type().Elem())
+	if t.Kind() != reflect.Struct {
+		return formatText(c)
+	}
+
+	fields, header := csvFields(t)
+	records := make([][]string, 0, v.Len()+1)
+	records = append(records, header)
+
+	for i := range v.Len() {
+		elem := reflect.Indirect(v.Index(i))
+		record := make([]string, len(fields))
+
+		if elem.IsValid() {
+			for j, f := range fields {
+				fv, err := elem.FieldByIndexErr(f.Index)
+				if err != nil {
+					continue
+				}
+
+				if record[j], err = csvValue(fv, l); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return o.csv.encode(records)
+}
+
+// csvFields returns the fields of the struct type to encode, along with the header row.
+func csvFields(t reflect.Type) ([]reflect.StructField, []string) {
+	var (
+		fields []reflect.StructField
+		header []string
+	)
+
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, f)
+		header = append(header, name)
+	}
+
+	return fields, header
+}
+
+// indirectType returns the type the pointer type points to, or the type itself.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// csvValue returns the text of the field value, the floats and the dates being
+// formatted with the conventions of the locale, if any. Nil values are empty.
+func csvValue(v reflect.Value, l *Locale) (string, error) {
+	for {
+		indirect := v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface
+		if indirect && v.IsNil() {
+			return "", nil
+		}
+
+		if s, ok := formatLocaleValue(l, v); ok {
+			return s, nil
+		}
+
+		if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+			b, err := m.MarshalText()
+
+			return string(b), err
+		}
+
+		if !indirect {
+			return fmt.Sprint(v.Interface()), nil
+		}
+
+		v = v.Elem()
+	}
+}
+
+// marshal encodes the value with its MarshalCSV method, its records being
+// encoded again with the configured delimiter and quoting when they are set.
+func (o csvOptions) marshal(m CSVMarshaler) ([]byte, error) {
+	b, err := m.MarshalCSV()
+	if err != nil || o == (csvOptions{}) {
+		return b, err
+	}
+
+	r := csv.NewReader(bytes.NewReader(b))
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return o.encode(records)
+}
+
+// encode encodes the records with the configured delimiter and quoting.
+func (o csvOptions) encode(records [][]string) ([]byte, error) {
+	delimiter := o.delimiter
+	if delimiter == 0 {
+		delimiter = ','
+	}
+
+	if delimiter == '"' || delimiter == '\r' || delimiter == '\n' ||
+		!utf8.ValidRune(delimiter) || delimiter == utf8.RuneError {
+		return nil, fmt.Errorf("%w: %q", errInvalidDelimiter, delimiter)
+	}
+
+	b := getBuffer()
+
+	if !o.quoteAll {
+		w := csv.NewWriter(b)
+		w.Comma = delimiter
+
+		if err := w.WriteAll(records); err != nil {
+			return nil, err
+		}
+
+		return releaseBytes(b), nil
+	}
+
+	for _, record := range records {
+		for i, field := range record {
+			if i > 0 {
+				b.WriteRune(delimiter)
+			}
+
+			b.WriteByte('"')
+			b.WriteString(strings.ReplaceAll(field, `"`, `""`))
+			b.WriteByte('"')
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return releaseBytes(b), nil
+}
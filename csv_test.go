@@ -0,0 +1,139 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type csvAddress struct {
+	City string `csv:"city"`
+}
+
+type csvUser struct {
+	ID       int       `csv:"id"`
+	Name     string    `csv:"name,omitempty"`
+	Password string    `csv:"-"`
+	Joined   time.Time `csv:"joined"`
+	Manager  *string   `csv:"manager"`
+	Nickname string
+	secret   string
+	*csvAddress
+}
+
+// CSVBase is embedded by pointer in csvRecord.
+type CSVBase struct {
+	ID int `csv:"id"`
+}
+
+type csvRecord struct {
+	*CSVBase
+	Name string `csv:"name"`
+}
+
+// csvRows is CSV encoding itself.
+type csvRows string
+
+func (r csvRows) MarshalCSV() ([]byte, error) {
+	return []byte(r), nil
+}
+
+func TestCSVMarshaling(t *testing.T) {
+	joined := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	manager := "Jane"
+	users := []csvUser{
+		{ID: 1, Name: "John, Jr.", Password: "x", Joined: joined, Manager: &manager, Nickname: `"JJ"`, secret: "y", csvAddress: &csvAddress{City: "London"}},
+		{ID: 2, Name: "Ann", Joined: joined},
+	}
+
+	t.Run("encodes slices of structs with a header row", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder().Send200(w, users)
+
+		expected := "id,name,joined,manager,Nickname,city\n" +
+			"1,\"John, Jr.\",2024-05-01T10:00:00Z,Jane,\"\"\"JJ\"\"\",London\n" +
+			"2,Ann,2024-05-01T10:00:00Z,,,\n"
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("encodes slices of pointers to structs", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder().Send200(w, []*csvAddress{{City: "Paris"}, nil})
+
+		if w.Body.String() != "city\nParis\n\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("flattens the structs embedded by pointer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder().Send200(w, []csvRecord{{CSVBase: &CSVBase{ID: 1}, Name: "a"}, {Name: "b"}})
+
+		if w.Body.String() != "id,name\n1,a\n,b\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("encodes string records", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder(WithCSVDelimiter(';')).Send200(w, [][]string{{"a", "b;c"}, {"1", "2"}})
+
+		if w.Body.String() != "a;\"b;c\"\n1;2\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("quotes all the fields", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder(WithCSVQuoteAll(true), WithCSVDelimiter('\t')).Send200(w, [][]string{{"a", `say "hi"`}})
+
+		if w.Body.String() != "\"a\"\t\"say \"\"hi\"\"\"\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("applies the options to the CSV marshalers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder(WithCSVDelimiter(';'), WithCSVQuoteAll(true)).Send200(w, csvRows("a,b\n1,\"2;3\"\n"))
+
+		if w.Body.String() != "\"a\";\"b\"\n\"1\";\"2;3\"\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("passes strings through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder(WithCSVQuoteAll(true)).Send200(w, "a,b\n")
+
+		if w.Body.String() != "a,b\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("reports invalid delimiters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(CSVResponder(WithCSVDelimiter('"'))).Send200(w, [][]string{{"a"}})
+		if !errors.Is(err, errInvalidDelimiter) {
+			t.Errorf("expected errInvalidDelimiter, got %v", err)
+		}
+
+		w = httptest.NewRecorder()
+		CSVResponder(WithCSVDelimiter('\n')).Send200(w, [][]string{{"a"}})
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected the invalid content to be reported, got %d %q", w.Code, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package responder
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithWriteDeadline extends the write deadline of the connection to the given
+// duration before each write of the response bodies, flushing them after each
+// write, so that long responses such as large exports are not cut by the
+// WriteTimeout of the server as long as they make progress.
+// It relies on http.ResponseController, the writers supporting neither
+// deadlines nor flushes being written to as usual.
+// A zero or negative duration disables it.
+func WithWriteDeadline(d time.Duration) OptionsModifier {
+	return func(o *options) {
+		o.writeDeadline = d
+	}
+}
+
+// deadlineWriter extends the write deadline of the response before each write
+// and flushes it after each write.
+type deadlineWriter struct {
+	w       io.Writer
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	_ = d.rc.SetWriteDeadline(time.Now().Add(d.timeout))
+
+	n, err := d.w.Write(p)
+	if err == nil {
+		_ = d.rc.Flush()
+	}
+
+	return n, err
+}
+
+// extendDeadline returns the writer extending the write deadline of the response
+// before the writes to w, or w itself when no write deadline is set.
+func (r *responder) extendDeadline(rw responseWriter, w io.Writer) io.Writer {
+	if r.options.writeDeadline <= 0 {
+		return w
+	}
+
+	return &deadlineWriter{w: w, rc: http.NewResponseController(rw), timeout: r.options.writeDeadline}
+}
+
+// deadlineResponseWriter is a response writer whose body is written
+// through a deadlineWriter, for the bodies written by the standard library.
+type deadlineResponseWriter struct {
+	http.ResponseWriter
+	w io.Writer
+}
+
+func (d deadlineResponseWriter) Write(p []byte) (int, error) {
+	return d.w.Write(p)
+}
+
+// Unwrap returns the original response writer, for http.ResponseController.
+func (d deadlineResponseWriter) Unwrap() http.ResponseWriter {
+	return d.ResponseWriter
+}
+
+// withDeadline returns the response writer extending its write deadline
+// before each write, or rw itself when no write deadline is set.
+func (r *responder) withDeadline(rw responseWriter) responseWriter {
+	if r.options.writeDeadline <= 0 {
+		return rw
+	}
+
+	return deadlineResponseWriter{ResponseWriter: rw, w: r.extendDeadline(rw, rw)}
+}
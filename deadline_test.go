@@ -0,0 +1,84 @@
+package responder
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// deadlineRecorder records the write deadlines set through http.ResponseController.
+type deadlineRecorder struct {
+	*httptest.ResponseRecorder
+	deadlines []time.Time
+}
+
+func (d *deadlineRecorder) SetWriteDeadline(t time.Time) error {
+	d.deadlines = append(d.deadlines, t)
+
+	return nil
+}
+
+func TestWithWriteDeadline(t *testing.T) {
+	t.Run("extends the deadline before writing the body", func(t *testing.T) {
+		w := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+		before := time.Now()
+
+		CSVResponder(WithWriteDeadline(time.Minute)).Send200(w, [][]string{{"a", "b"}})
+
+		if len(w.deadlines) != 1 || w.deadlines[0].Before(before.Add(time.Minute)) {
+			t.Errorf("expected the deadline to be extended by a minute, got %v", w.deadlines)
+		}
+
+		if !w.Flushed {
+			t.Error("expected the body to be flushed")
+		}
+
+		if b := w.Body.String(); b != "a,b\n" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("extends the deadline of each write of the streams", func(t *testing.T) {
+		w := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+		body := strings.Repeat("x", 100_000)
+
+		TextResponder(WithWriteDeadline(time.Minute)).Send200(w, struct{ io.Reader }{strings.NewReader(body)})
+
+		if len(w.deadlines) < 2 {
+			t.Errorf("expected the deadline to be extended for each chunk, got %d extensions", len(w.deadlines))
+		}
+
+		if w.Body.String() != body {
+			t.Error("unexpected body")
+		}
+	})
+
+	t.Run("extends the deadline of the files", func(t *testing.T) {
+		w := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+		req := httptest.NewRequest(http.MethodGet, "/report.csv", nil)
+
+		TextResponder(WithWriteDeadline(time.Minute)).SendFile(w, req, "report.csv", bytes.NewReader([]byte("a,b")), time.Time{})
+
+		if len(w.deadlines) == 0 {
+			t.Error("expected the deadline to be extended")
+		}
+
+		if b := w.Body.String(); b != "a,b" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		w := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+		TextResponder().Send200(w, "ok")
+
+		if len(w.deadlines) != 0 {
+			t.Errorf("expected no deadline, got %v", w.deadlines)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package responder
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// WithDebugToken enables the verbose error mode for the requests carrying
+// a valid token in the given header, e.g. X-Debug-Token. The validator
+// decides whether the token is valid, typically checking a signature or comparing
+// it to a secret in constant time. In verbose mode, the error responses carry
+// the chain of causes of the internal error and the stack trace of the panics,
+// which helps debugging customer-specific failures in production.
+// It only applies to responders bound to a request with WithRequest.
+func WithDebugToken(header string, valid func(token string) bool) OptionsModifier {
+	return func(o *options) {
+		o.debugHeader = header
+		o.debugToken = valid
+	}
+}
+
+// WithDebug enables the verbose error mode for every response, e.g. in development,
+// see WithDebugToken. It must not be enabled in production since the internal
+// errors are exposed to the clients.
+func WithDebug(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.debug = enabled
+	}
+}
+
+// DebugMessage is the message of the error responses sent in verbose mode.
+// It is passed to the ErrorFormatter in place of the original message.
+type DebugMessage struct {
+	// Message is the original message of the response.
+	Message any
+	// Causes holds the messages of the internal error and of the errors it wraps.
+	Causes []string
+	// Stack is the stack trace of the panic the error was recovered from, if any.
+	Stack string
+}
+
+// String returns the message followed by the causes and the stack trace.
+func (m DebugMessage) String() string {
+	var b strings.Builder
+
+	b.WriteString(internal.MessageToString(m.Message))
+
+	if len(m.Causes) > 0 {
+		b.WriteString("\n\ncauses:")
+
+		for _, c := range m.Causes {
+			b.WriteString("\n- " + c)
+		}
+	}
+
+	if m.Stack != "" {
+		b.WriteString("\n\nstack:\n" + m.Stack)
+	}
+
+	return b.String()
+}
+
+// verbose reports whether the verbose error mode is enabled,
+// either globally or by the request.
+func (r *responder) verbose() bool {
+	if r.options.debug {
+		return true
+	}
+
+	if r.options.debugToken == nil || r.request == nil {
+		return false
+	}
+
+	token := r.request.Header.Get(r.options.debugHeader)
+
+	return token != "" && r.options.debugToken(token)
+}
+
+// debugMessage returns the verbose message of the error
+// when the verbose mode is enabled, or the message unchanged.
+func (r *responder) debugMessage(err error, message any) any {
+	if err == nil || !r.verbose() {
+		return message
+	}
+
+	m := DebugMessage{Message: message}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		m.Causes = append(m.Causes, e.Error())
+	}
+
+	var pe *panicError
+	if errors.As(err, &pe) {
+		m.Stack = string(pe.stack)
+	}
+
+	return m
+}
@@ -0,0 +1,110 @@
+package responder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithDebugToken(t *testing.T) {
+	valid := func(token string) bool { return token == "s3cr3t" }
+	err := fmt.Errorf("loading invoice: %w", errors.New("connection refused"))
+
+	newRequest := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/invoices/1", nil)
+		if token != "" {
+			req.Header.Set("X-Debug-Token", token)
+		}
+
+		return req
+	}
+
+	t.Run("sends the causes with a valid token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithDebugToken("X-Debug-Token", valid)).
+			WithRequest(newRequest("s3cr3t")).
+			Send500(w, err, "Internal Server Error")
+
+		var body jsonError
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode body %q: %v", w.Body.String(), err)
+		}
+
+		if body.Error != "Internal Server Error" {
+			t.Errorf("expected error %q, got %q", "Internal Server Error", body.Error)
+		}
+
+		want := []string{"loading invoice: connection refused", "connection refused"}
+		if body.Detail == nil || fmt.Sprint(body.Detail.Causes) != fmt.Sprint(want) {
+			t.Errorf("expected causes %v, got %+v", want, body.Detail)
+		}
+	})
+
+	t.Run("sends the stack trace of panics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithDebugToken("X-Debug-Token", valid)).
+			WithRequest(newRequest("s3cr3t")).
+			Send(w, recoveredResponse("boom", []byte("goroutine 1 [running]")))
+
+		for _, want := range []string{"Internal Server Error", "- panic: boom", "stack:\ngoroutine 1 [running]"} {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("expected the body to contain %q, got %q", want, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("sends the regular message otherwise", func(t *testing.T) {
+		for _, token := range []string{"", "invalid"} {
+			w := httptest.NewRecorder()
+
+			JSONResponder(WithDebugToken("X-Debug-Token", valid)).
+				WithRequest(newRequest(token)).
+				Send500(w, err, "Internal Server Error")
+
+			if want := `{"error":"Internal Server Error"}`; w.Body.String() != want {
+				t.Errorf("expected body %q with token %q, got %q", want, token, w.Body.String())
+			}
+		}
+	})
+}
+
+func TestWithDebug(t *testing.T) {
+	err := fmt.Errorf("loading invoice: %w", errors.New("connection refused"))
+
+	t.Run("sends the causes without a request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithDebug(true)).Send500(w, err, "Internal Server Error")
+
+		want := "Internal Server Error\n\ncauses:\n- loading invoice: connection refused\n- connection refused"
+		if w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("sends the regular message when disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithDebug(true), WithDebug(false)).Send500(w, err, "Internal Server Error")
+
+		if want := `{"error":"Internal Server Error"}`; w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("leaves the responses without error untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithDebug(true)).Send404(w, nil, "Not Found")
+
+		if w.Body.String() != "Not Found" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
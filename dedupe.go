@@ -0,0 +1,90 @@
+package responder
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DefaultDedupeKey identifies the requests by URL, host included, along with
+// the headers the response commonly depends on: the content negotiation headers
+// and the credentials, so that users never receive the response of another user.
+func DefaultDedupeKey(r *http.Request) string {
+	parts := []string{requestURL(r)}
+
+	for _, h := range []string{"Accept", "Accept-Encoding", "Accept-Language", "Authorization", "Cookie"} {
+		parts = append(parts, strings.Join(r.Header.Values(h), ","))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// Dedupe returns a middleware suppressing the duplicate concurrent GET requests.
+// While the handler is serving a request, the identical requests, as identified
+// by the key function, wait for it and receive the same response instead of
+// executing the handler, which reduces the load of thundering herds on expensive
+// endpoints. The responses setting cookies are not shared, the handler serving
+// each waiting request instead. DefaultDedupeKey is used when the key function is nil.
+func Dedupe(key func(*http.Request) string) func(http.Handler) http.Handler {
+	if key == nil {
+		key = DefaultDedupeKey
+	}
+
+	var (
+		mu      sync.Mutex
+		flights = make(map[string]*flight)
+	)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodGet {
+				next.ServeHTTP(w, req)
+
+				return
+			}
+
+			k := key(req)
+
+			mu.Lock()
+			if f, ok := flights[k]; ok {
+				mu.Unlock()
+
+				select {
+				case <-f.done:
+					if f.served && f.rec.header.Get("Set-Cookie") == "" {
+						f.rec.replay(w)
+					} else {
+						next.ServeHTTP(w, req)
+					}
+				case <-req.Context().Done():
+				}
+
+				return
+			}
+
+			f := &flight{done: make(chan struct{}), rec: newCacheRecorder()}
+			flights[k] = f
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				delete(flights, k)
+				mu.Unlock()
+
+				close(f.done)
+			}()
+
+			next.ServeHTTP(f.rec, req)
+			f.served = true
+			f.rec.replay(w)
+		})
+	}
+}
+
+// flight is a request being served, whose response is shared with the identical requests.
+// When the handler panics or sets cookies, the waiting requests are served by the handler.
+type flight struct {
+	done   chan struct{}
+	rec    *cacheRecorder
+	served bool
+}
@@ -0,0 +1,192 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDedupe(t *testing.T) {
+	t.Run("shares the response between concurrent identical requests", func(t *testing.T) {
+		var (
+			calls atomic.Int32
+			keyed sync.WaitGroup
+			wg    sync.WaitGroup
+		)
+
+		const n = 5
+
+		release := make(chan struct{})
+
+		keyed.Add(n)
+
+		key := func(r *http.Request) string {
+			defer keyed.Done()
+
+			return DefaultDedupeKey(r)
+		}
+
+		h := Dedupe(key)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			<-release
+			JSONResponder().Send200(w, map[string]string{"report": "ready"})
+		}))
+
+		recorders := make([]*httptest.ResponseRecorder, n)
+
+		for i := range recorders {
+			recorders[i] = httptest.NewRecorder()
+
+			wg.Go(func() {
+				h.ServeHTTP(recorders[i], httptest.NewRequest(http.MethodGet, "/report", nil))
+			})
+		}
+
+		// Let the requests join the flight before releasing the handler.
+		keyed.Wait()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if calls.Load() != 1 {
+			t.Errorf("expected the handler to be called once, got %d", calls.Load())
+		}
+
+		for i, w := range recorders {
+			if w.Code != http.StatusOK || w.Body.String() != `{"report":"ready"}` {
+				t.Errorf("request %d: expected the shared response, got %d %q", i, w.Code, w.Body.String())
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != JSONContentType {
+				t.Errorf("request %d: expected Content-Type %q, got %q", i, JSONContentType, ct)
+			}
+		}
+	})
+
+	t.Run("does not share responses between users", func(t *testing.T) {
+		a := httptest.NewRequest(http.MethodGet, "/report", nil)
+		a.Header.Set("Authorization", "Bearer a")
+
+		b := httptest.NewRequest(http.MethodGet, "/report", nil)
+		b.Header.Set("Authorization", "Bearer b")
+
+		if DefaultDedupeKey(a) == DefaultDedupeKey(b) {
+			t.Error("expected different keys for different credentials")
+		}
+	})
+
+	t.Run("does not share responses between hosts", func(t *testing.T) {
+		a := httptest.NewRequest(http.MethodGet, "/report", nil)
+		a.Host = "a.example.com"
+
+		b := httptest.NewRequest(http.MethodGet, "/report", nil)
+		b.Host = "b.example.com"
+
+		if DefaultDedupeKey(a) == DefaultDedupeKey(b) {
+			t.Error("expected different keys for different hosts")
+		}
+	})
+
+	t.Run("serves the waiting requests when the response sets cookies", func(t *testing.T) {
+		var calls atomic.Int32
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		h := Dedupe(nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			n := calls.Add(1)
+			if n == 1 {
+				close(started)
+				<-release
+			}
+
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: strconv.Itoa(int(n))})
+			TextResponder().Send200(w, "ok")
+		}))
+
+		var wg sync.WaitGroup
+
+		wg.Go(func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report", nil))
+		})
+
+		<-started
+
+		w := httptest.NewRecorder()
+
+		wg.Go(func() {
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", nil))
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if calls.Load() != 2 {
+			t.Errorf("expected the handler to be called twice, got %d", calls.Load())
+		}
+
+		if c := w.Header().Get("Set-Cookie"); c != "session=2" {
+			t.Errorf("expected the cookie of the waiting request, got %q", c)
+		}
+	})
+
+	t.Run("ignores other methods", func(t *testing.T) {
+		var calls atomic.Int32
+
+		h := Dedupe(nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			calls.Add(1)
+		}))
+
+		for range 2 {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/report", nil))
+		}
+
+		if calls.Load() != 2 {
+			t.Errorf("expected the handler to be called twice, got %d", calls.Load())
+		}
+	})
+
+	t.Run("serves the waiting requests when the handler panics", func(t *testing.T) {
+		var calls atomic.Int32
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		h := Recoverer(TextResponder())(Dedupe(nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-release
+				panic("boom")
+			}
+
+			TextResponder().Send200(w, "ok")
+		})))
+
+		var wg sync.WaitGroup
+
+		wg.Go(func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report", nil))
+		})
+
+		<-started
+
+		w := httptest.NewRecorder()
+
+		wg.Go(func() {
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", nil))
+		})
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if w.Code != http.StatusOK || w.Body.String() != "ok" {
+			t.Errorf("expected the handler to serve the waiting request, got %d %q", w.Code, w.Body.String())
+		}
+	})
+}
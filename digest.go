@@ -0,0 +1,32 @@
+package responder
+
+import (
+	"io"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// WithDigestTrailer makes the responses whose body is streamed, i.e. the readers,
+// the NDJSON streams and the bodies encoded directly, carry a Content-Digest
+// trailer (RFC 9530) computed incrementally while the body is written, since
+// it cannot be sent as a header before the whole content is known.
+// The bodies are sent with chunked encoding, which carries the trailers.
+func WithDigestTrailer(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.digestTrailer = enabled
+	}
+}
+
+// bodyWriter returns the writer a streamed body must be written to, along with
+// the digest writer computing its Content-Digest trailer when it is enabled.
+// It must be called before the header is written.
+func (r *responder) bodyWriter(rw responseWriter) (io.Writer, *internal.DigestWriter) {
+	if !r.options.digestTrailer {
+		return rw, nil
+	}
+
+	digest := internal.NewDigestWriter(rw)
+	internal.DeclareDigestTrailer(rw.Header())
+
+	return digest, digest
+}
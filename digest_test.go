@@ -0,0 +1,63 @@
+package responder
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithDigestTrailer(t *testing.T) {
+	responder := JSONResponder(WithDigestTrailer(true), WithDirectEncoding(true))
+
+	tests := []struct {
+		name string
+		send func(http.ResponseWriter)
+	}{
+		{"NDJSON streams", func(w http.ResponseWriter) { responder.SendSeq(w, slices.Values([]any{1, 2})) }},
+		{"readers", func(w http.ResponseWriter) { responder.Send200(w, io.LimitReader(strings.NewReader("report"), 3)) }},
+		{"readers reporting their length", func(w http.ResponseWriter) { responder.Send200(w, strings.NewReader("report")) }},
+		{"direct encodings", func(w http.ResponseWriter) { responder.Send200(w, []int{1, 2}) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				tc.send(w)
+			}))
+			defer server.Close()
+
+			res, err := http.Get(server.URL)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer res.Body.Close()
+
+			body, _ := io.ReadAll(res.Body)
+			sum := sha256.Sum256(body)
+			expected := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+
+			if len(body) == 0 {
+				t.Error("expected a body")
+			}
+
+			if v := res.Trailer.Get("Content-Digest"); v != expected {
+				t.Errorf("expected Content-Digest trailer %q, got %q", expected, v)
+			}
+		})
+	}
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendSeq(w, slices.Values([]any{1}))
+
+		if v := w.Header().Get("Trailer"); v != "" {
+			t.Errorf("expected no trailer, got %q", v)
+		}
+	})
+}
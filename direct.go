@@ -0,0 +1,181 @@
+package responder
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// WithDirectEncoding encodes the data of the JSON and XML responders straight to
+// the response writer with json.Encoder and xml.Encoder, or with their Encoder,
+// instead of formatting them in memory first, so that large payloads are not held
+// twice in memory. The responses are sent without Content-Length, with chunked
+// encoding, and they are compressed whatever their size when the compression is enabled.
+//
+// The built-in encoders only apply to the values the responders marshal, the strings,
+// byte slices and the values with a dedicated encoding being sent as usual, and the
+// responders with a data formatter format their data as usual. The responses relying
+// on their whole body, i.e. with ETags, JSON transforms and indentation, watermarks,
+// encryption, preferences or fault injection, are formatted as usual.
+// As for the streams, the before and after send hooks are not called.
+//
+// The response is written once the encoding produces its first bytes: the values
+// failing to encode before that are formatted and sent as usual, whereas the
+// failures happening later on leave a truncated body, the error being logged
+// and returned by the checked responders.
+func WithDirectEncoding(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.directEncoding = enabled
+	}
+}
+
+// encodesDirectly reports whether the data sent with the status code
+// are encoded straight to the response writer.
+func (r *responder) encodesDirectly(code int) bool {
+	o := r.options
+
+	return o.directEncoding && o.directEncoder != nil && bodyAllowed(code) &&
+		!o.etag && o.jsonTransform == nil && o.jsonIndent == nil && o.watermarker == nil &&
+		o.encrypter == nil && !o.prefer && o.fault == nil
+}
+
+// directWriter writes the header of the response on the first write of the body,
+// leaving the response untouched until the encoding produces its first bytes.
+type directWriter struct {
+	w       io.Writer
+	open    func() io.Writer
+	discard bool
+	n       int
+}
+
+func (d *directWriter) Write(p []byte) (int, error) {
+	if d.discard {
+		return len(p), nil
+	}
+
+	if d.w == nil {
+		d.w = d.open()
+	}
+
+	n, err := d.w.Write(p)
+	d.n += n
+
+	return n, err
+}
+
+// started reports whether the header of the response is written.
+func (d *directWriter) started() bool {
+	return d.w != nil
+}
+
+// encodeDirect encodes the data straight to the response writer,
+// falling back to the regular path when the encoder does not handle them
+// or fails before writing anything.
+func (r *responder) encodeDirect(rw responseWriter, code int, data any) error {
+	if err := r.canceled(code); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	encoding := r.negotiateCompression(rw, code, r.contentType)
+
+	var journaled *internal.DigestWriter
+
+	w, digest := r.bodyWriter(rw)
+	dw := &directWriter{open: func() io.Writer {
+		r.prepareHeader(rw, code, r.contentType)
+		rw.Header().Del("Content-Length")
+
+		if encoding != "" {
+			rw.Header().Set("Content-Encoding", encoding)
+		}
+
+		var jw io.Writer
+
+		jw, journaled = r.journalWriter(r.throttle(r.extendDeadline(rw, w)))
+		rw.WriteHeader(code)
+
+		return jw
+	}}
+
+	ok, err := r.encodeTo(dw, encoding, data)
+	if !ok || (err != nil && !dw.started()) {
+		return r.sendFormatted(rw, code, data)
+	}
+
+	if err != nil {
+		if r.options.logger != nil {
+			r.options.logger.Error("failed to encode response",
+				"status", code,
+				"error", err,
+			)
+		}
+
+		r.record(rw, code, journaled, err, "")
+		elapsed := time.Since(start)
+		r.recordMetrics(rw, code, dw.n, elapsed, elapsed)
+		r.afterSend(code, dw.n, err)
+
+		return fmt.Errorf("%w: %w", ErrInvalidContent, err)
+	}
+
+	if !dw.started() {
+		// The value encoded to nothing, the header is still due.
+		dw.open()
+	}
+
+	if digest != nil {
+		internal.SetDigestTrailer(rw.Header(), digest)
+	}
+
+	r.record(rw, code, journaled, nil, "")
+	elapsed := time.Since(start)
+	r.recordMetrics(rw, code, dw.n, elapsed, elapsed)
+	r.afterSend(code, dw.n, nil)
+
+	return nil
+}
+
+// encodeTo encodes the data to the direct writer, compressed with the content coding
+// when it is not empty. The writer discards the output when the encoding fails
+// before writing the header, so that the regular path can take over.
+func (r *responder) encodeTo(dw *directWriter, encoding string, data any) (bool, error) {
+	if encoding == "" {
+		ok, err := r.options.directEncoder(dw, data)
+		if err != nil && !dw.started() {
+			dw.discard = true
+		}
+
+		return ok, err
+	}
+
+	cw, err := r.options.compression.compressors[encoding](dw)
+	if err != nil {
+		return false, nil
+	}
+
+	ok, err := r.options.directEncoder(cw, data)
+	if !ok || (err != nil && !dw.started()) {
+		dw.discard = true
+	}
+
+	if cerr := cw.Close(); err == nil {
+		err = cerr
+	}
+
+	return ok, err
+}
+
+// sendFormatted formats the data and sends them as usual.
+func (r *responder) sendFormatted(rw responseWriter, code int, data any) error {
+	started := time.Now()
+
+	body, err := r.format(data)
+	if err != nil {
+		return r.sendFailure(rw, err)
+	}
+
+	return r.send(rw, code, body, time.Since(started))
+}
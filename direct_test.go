@@ -0,0 +1,158 @@
+package responder
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type directItem struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func TestWithDirectEncoding(t *testing.T) {
+	items := []directItem{{Name: "<a>"}, {Name: "b"}}
+
+	t.Run("encodes the JSON data without Content-Length", func(t *testing.T) {
+		buffered := httptest.NewRecorder()
+		JSONResponder().Send200(buffered, items)
+
+		w := httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true)).Send200(w, items)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+
+		if cl := w.Header().Get("Content-Length"); cl != "" {
+			t.Errorf("expected no Content-Length, got %q", cl)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("expected the JSON content type, got %q", ct)
+		}
+
+		if w.Body.String() != buffered.Body.String() {
+			t.Errorf("expected the body %q, got %q", buffered.Body.String(), w.Body.String())
+		}
+	})
+
+	t.Run("applies the JSON encoder settings", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true), WithJSONEscapeHTML(false)).Send200(w, items)
+
+		if b := w.Body.String(); b != `[{"name":"<a>"},{"name":"b"}]` {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("encodes the XML data", func(t *testing.T) {
+		buffered := httptest.NewRecorder()
+		XMLResponder().Send200(buffered, items)
+
+		w := httptest.NewRecorder()
+		XMLResponder(WithDirectEncoding(true)).Send200(w, items)
+
+		if cl := w.Header().Get("Content-Length"); cl != "" {
+			t.Errorf("expected no Content-Length, got %q", cl)
+		}
+
+		if w.Body.String() != buffered.Body.String() {
+			t.Errorf("expected the body %q, got %q", buffered.Body.String(), w.Body.String())
+		}
+	})
+
+	t.Run("sends the strings as usual", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true)).Send200(w, `{"raw":true}`)
+
+		if cl := w.Header().Get("Content-Length"); cl != "12" {
+			t.Errorf("expected the Content-Length, got %q", cl)
+		}
+	})
+
+	t.Run("formats the data as usual when the body is needed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true), WithJSONIndent("", "  ")).Send200(w, items)
+
+		if cl := w.Header().Get("Content-Length"); cl == "" {
+			t.Error("expected the Content-Length")
+		}
+	})
+
+	t.Run("formats the data as usual with a custom data formatter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true), WithDataFormatter(func(any) []byte { return []byte("custom") })).Send200(w, items)
+
+		if b := w.Body.String(); b != "custom" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("compresses the body whatever its size", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		w := httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true), WithCompression()).WithRequest(req).Send200(w, items)
+
+		if ce := w.Header().Get("Content-Encoding"); ce != "gzip" {
+			t.Fatalf("expected gzip encoding, got %q", ce)
+		}
+
+		zr, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != `[{"name":"\u003ca\u003e"},{"name":"b"}]` {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("sends the encoding failures as usual", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := Checked(JSONResponder(WithDirectEncoding(true))).Send200(w, map[string]any{"f": func() {}})
+
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		w = httptest.NewRecorder()
+		JSONResponder(WithDirectEncoding(true)).Send200(w, map[string]any{"f": func() {}})
+
+		if cl := w.Header().Get("Content-Length"); cl == "" {
+			t.Error("expected the failure to be formatted as usual")
+		}
+	})
+
+	t.Run("reports the failures once the body is written", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := Checked(XMLResponder(WithDirectEncoding(true))).Send200(w, []any{directItem{Name: "a"}, func() {}})
+
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("expected the body to be partially written, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		JSONResponder().Send200(w, items)
+
+		if cl := w.Header().Get("Content-Length"); cl == "" {
+			t.Error("expected the Content-Length")
+		}
+	})
+}
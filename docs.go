@@ -0,0 +1,154 @@
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Docs collects examples of the responses sent by the routes, rendered with
+// their responders, so that they can be exported as OpenAPI response examples.
+type Docs struct {
+	mu     sync.Mutex
+	routes map[string]map[string]map[int]documentedResponse
+}
+
+// documentedResponse is an example response rendered by a responder.
+type documentedResponse struct {
+	mediaType string
+	example   any
+}
+
+// NewDocs creates an empty documentation.
+func NewDocs() *Docs {
+	return &Docs{
+		routes: make(map[string]map[string]map[int]documentedResponse),
+	}
+}
+
+// RouteDocs collects the examples of the responses of a route.
+type RouteDocs struct {
+	docs      *Docs
+	path      string
+	method    string
+	responder Responder
+}
+
+// Route returns the collector of the examples of the route, rendered with the responder.
+// The route is a pattern as accepted by http.ServeMux, e.g. "GET /users/{id}",
+// the GET method being assumed when it has none.
+func (d *Docs) Route(route string, r Responder) *RouteDocs {
+	method, path, ok := strings.Cut(route, " ")
+	if !ok {
+		method, path = http.MethodGet, route
+	}
+
+	return &RouteDocs{
+		docs:      d,
+		path:      strings.TrimSpace(path),
+		method:    strings.ToLower(method),
+		responder: r,
+	}
+}
+
+// Describe registers an example of the response sent with the status code.
+// The example is the body of the successful responses and the message of
+// the error responses, formatted by the encoders of the responder. Nothing is
+// sent, so that the logs, the metrics and the hooks of the responder are not
+// triggered, and the transformations applied when sending are left out.
+func (rd *RouteDocs) Describe(status int, example any) *RouteDocs {
+	var doc documentedResponse
+	if r, ok := rd.responder.(*responder); ok && r.options != nil {
+		doc = r.describe(status, example)
+	}
+
+	rd.docs.mu.Lock()
+	defer rd.docs.mu.Unlock()
+
+	methods, ok := rd.docs.routes[rd.path]
+	if !ok {
+		methods = make(map[string]map[int]documentedResponse)
+		rd.docs.routes[rd.path] = methods
+	}
+
+	if methods[rd.method] == nil {
+		methods[rd.method] = make(map[int]documentedResponse)
+	}
+
+	methods[rd.method][status] = doc
+
+	return rd
+}
+
+// describe formats the example of the response sent with the status code.
+func (r *responder) describe(status int, example any) documentedResponse {
+	if status >= status400 && binaryContentType(r.contentType) {
+		r = r.partResponder(TextContentType)
+	}
+
+	doc := documentedResponse{mediaType: mediaType(r.contentType)}
+	if !bodyAllowed(status) {
+		return doc
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+
+	if status >= status400 {
+		body, err = r.formatMessage(r.errorInfo(status, nil, example, ""))
+	} else {
+		body, err = r.format(example)
+	}
+
+	if err != nil || len(body) == 0 {
+		return doc
+	}
+
+	doc.example = string(body)
+
+	var v any
+	if isJSON(r.contentType) && json.Unmarshal(body, &v) == nil {
+		doc.example = v
+	}
+
+	return doc
+}
+
+// OpenAPI returns the paths object of an OpenAPI document describing the examples,
+// to be marshaled to JSON or YAML along with the rest of the document.
+func (d *Docs) OpenAPI() map[string]any {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	paths := make(map[string]any, len(d.routes))
+
+	for path, methods := range d.routes {
+		operations := make(map[string]any, len(methods))
+
+		for method, statuses := range methods {
+			responses := make(map[string]any, len(statuses))
+
+			for status, doc := range statuses {
+				r := map[string]any{"description": http.StatusText(status)}
+
+				if doc.mediaType != "" && doc.example != nil {
+					r["content"] = map[string]any{
+						doc.mediaType: map[string]any{"example": doc.example},
+					}
+				}
+
+				responses[strconv.Itoa(status)] = r
+			}
+
+			operations[method] = map[string]any{"responses": responses}
+		}
+
+		paths[path] = operations
+	}
+
+	return paths
+}
@@ -0,0 +1,64 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	docs := NewDocs()
+
+	docs.Route("GET /users/{id}", JSONResponder()).
+		Describe(http.StatusOK, map[string]any{"id": 1, "name": "Ada"}).
+		Describe(http.StatusNotFound, "no such user")
+
+	docs.Route("/health", TextResponder()).
+		Describe(http.StatusOK, "ok").
+		Describe(http.StatusNoContent, nil)
+
+	got, err := json.Marshal(docs.OpenAPI())
+	if err != nil {
+		t.Fatalf("failed to marshal the document: %v", err)
+	}
+
+	want := `{` +
+		`"/health":{"get":{"responses":{` +
+		`"200":{"content":{"text/plain":{"example":"ok"}},"description":"OK"},` +
+		`"204":{"description":"No Content"}}}},` +
+		`"/users/{id}":{"get":{"responses":{` +
+		`"200":{"content":{"application/json":{"example":{"id":1,"name":"Ada"}}},"description":"OK"},` +
+		`"404":{"content":{"application/json":{"example":{"error":"no such user"}}},"description":"Not Found"}}}}` +
+		`}`
+
+	if string(got) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestDocsSideEffects(t *testing.T) {
+	var logs bytes.Buffer
+
+	hooks := 0
+	metrics := &memoryMetrics{}
+	journal := &memoryJournal{}
+
+	r := JSONResponder(
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithMetrics(metrics),
+		WithJournal(journal),
+		WithBeforeSend(func(int, http.Header, []byte) { hooks++ }),
+		WithAfterSend(func(int, int, error) { hooks++ }),
+	)
+
+	NewDocs().Route("GET /users/{id}", r).
+		Describe(http.StatusOK, map[string]any{"id": 1}).
+		Describe(http.StatusInternalServerError, "Internal Server Error")
+
+	if logs.Len() != 0 || len(metrics.metrics) != 0 || len(journal.entries) != 0 || hooks != 0 {
+		t.Errorf("expected no side effects, got logs %q, %d metrics, %d entries and %d hook calls",
+			logs.String(), len(metrics.metrics), len(journal.entries), hooks)
+	}
+}
@@ -0,0 +1,164 @@
+package responder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Redactor is a hook removing personal or secret information from values
+// about to be exposed or logged. It receives the name of the header or
+// query parameter along with its value and returns the value to use.
+type Redactor func(name, value string) string
+
+// RedactedValue replaces the values removed by DefaultRedactor.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveNames lists the lowercased header and query parameter names
+// whose values are redacted by DefaultRedactor.
+var sensitiveNames = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+	"x-auth-token":        true,
+	"x-csrf-token":        true,
+	"access_token":        true,
+	"api_key":             true,
+	"password":            true,
+	"secret":              true,
+	"token":               true,
+}
+
+// DefaultRedactor redacts the credentials usually found in headers and query strings.
+func DefaultRedactor(name, value string) string {
+	if sensitiveNames[strings.ToLower(name)] {
+		return RedactedValue
+	}
+
+	return value
+}
+
+// WithRedactor sets the hook redacting header and query values,
+// replacing DefaultRedactor.
+func WithRedactor(f Redactor) OptionsModifier {
+	return func(o *options) {
+		o.redactor = f
+	}
+}
+
+// Echo is the body sent by SendEcho. It is encoded as JSON and XML as is,
+// as CSV with a row per value and as plain text in the layout of an HTTP request.
+type Echo struct {
+	XMLName xml.Name    `json:"-" xml:"echo"`
+	Method  string      `json:"method" xml:"method"`
+	URL     string      `json:"url" xml:"url"`
+	Proto   string      `json:"proto" xml:"proto"`
+	Host    string      `json:"host" xml:"host"`
+	Headers []EchoValue `json:"headers" xml:"headers>header"`
+	Query   []EchoValue `json:"query" xml:"query>param"`
+}
+
+// EchoValue is a header or query parameter value of the Echo.
+type EchoValue struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value" xml:",chardata"`
+}
+
+// String returns the description of the request in the layout of an HTTP
+// request, followed by the query parameters after a blank line.
+func (e Echo) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s %s\nHost: %s\n", e.Method, e.URL, e.Proto, e.Host)
+
+	for _, h := range e.Headers {
+		fmt.Fprintf(&b, "%s: %s\n", h.Name, h.Value)
+	}
+
+	if len(e.Query) > 0 {
+		b.WriteByte('\n')
+
+		for _, q := range e.Query {
+			fmt.Fprintf(&b, "%s=%s\n", q.Name, q.Value)
+		}
+	}
+
+	return b.String()
+}
+
+// MarshalCSV encodes the description of the request as CSV,
+// with a row per field, header and query parameter value.
+func (e Echo) MarshalCSV() ([]byte, error) {
+	records, err := e.csvRecords(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return csvOptions{}.encode(records)
+}
+
+// csvRecords returns the records of the description of the request.
+func (e Echo) csvRecords(*Locale) ([][]string, error) {
+	records := [][]string{
+		{"field", "name", "value"},
+		{"method", "", e.Method},
+		{"url", "", e.URL},
+		{"proto", "", e.Proto},
+		{"host", "", e.Host},
+	}
+
+	for _, h := range e.Headers {
+		records = append(records, []string{"header", h.Name, h.Value})
+	}
+
+	for _, q := range e.Query {
+		records = append(records, []string{"query", q.Name, q.Value})
+	}
+
+	return records, nil
+}
+
+// newEcho describes the request, redacting its header and query values.
+func newEcho(req *http.Request, redact Redactor) Echo {
+	return Echo{
+		Method:  req.Method,
+		URL:     req.URL.Path,
+		Proto:   req.Proto,
+		Host:    req.Host,
+		Headers: echoValues(redactValues(req.Header, redact)),
+		Query:   echoValues(redactValues(req.URL.Query(), redact)),
+	}
+}
+
+// echoValues returns the values sorted by name, the values of a name being kept in order.
+func echoValues(values map[string][]string) []EchoValue {
+	out := make([]EchoValue, 0, len(values))
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		for _, v := range values[name] {
+			out = append(out, EchoValue{Name: name, Value: v})
+		}
+	}
+
+	return out
+}
+
+func redactValues(values map[string][]string, redact Redactor) map[string][]string {
+	out := make(map[string][]string, len(values))
+
+	for name, vs := range values {
+		redacted := make([]string, len(vs))
+		for i, v := range vs {
+			redacted[i] = redact(name, v)
+		}
+
+		out[name] = redacted
+	}
+
+	return out
+}
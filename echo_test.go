@@ -0,0 +1,120 @@
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSendEcho(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "http://example.com/debug?q=search&token=secret", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+		return req
+	}
+
+	t.Run("describes the request with redacted values", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendEcho(w, newRequest())
+
+		var echo Echo
+		if err := json.Unmarshal(w.Body.Bytes(), &echo); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if echo.Method != http.MethodPost || echo.URL != "/debug" || echo.Host != "example.com" {
+			t.Errorf("unexpected request line %+v", echo)
+		}
+
+		wantHeaders := []EchoValue{{Name: "Authorization", Value: RedactedValue}, {Name: "X-Forwarded-For", Value: "10.0.0.1"}}
+		if !slices.Equal(echo.Headers, wantHeaders) {
+			t.Errorf("expected headers %v, got %v", wantHeaders, echo.Headers)
+		}
+
+		wantQuery := []EchoValue{{Name: "q", Value: "search"}, {Name: "token", Value: RedactedValue}}
+		if !slices.Equal(echo.Query, wantQuery) {
+			t.Errorf("expected query %v, got %v", wantQuery, echo.Query)
+		}
+	})
+
+	t.Run("uses the configured redactor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		redactor := func(name, value string) string {
+			if name == "X-Forwarded-For" {
+				return "hidden"
+			}
+
+			return DefaultRedactor(name, value)
+		}
+
+		JSONResponder(WithRedactor(redactor)).SendEcho(w, newRequest())
+
+		var echo Echo
+		if err := json.Unmarshal(w.Body.Bytes(), &echo); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if v := echo.Headers[1]; v.Name != "X-Forwarded-For" || v.Value != "hidden" {
+			t.Errorf("expected X-Forwarded-For to be redacted, got %v", v)
+		}
+	})
+
+	t.Run("encodes the description as XML", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		XMLResponder().SendEcho(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+
+		want := `<echo><method>POST</method><url>/debug</url><proto>HTTP/1.1</proto><host>example.com</host>` +
+			`<headers><header name="Authorization">[REDACTED]</header><header name="X-Forwarded-For">10.0.0.1</header></headers>` +
+			`<query><param name="q">search</param><param name="token">[REDACTED]</param></query></echo>`
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("expected %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("encodes the description as CSV", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder().SendEcho(w, newRequest())
+
+		want := "field,name,value\nmethod,,POST\nurl,,/debug\nproto,,HTTP/1.1\nhost,,example.com\n" +
+			"header,Authorization,[REDACTED]\nheader,X-Forwarded-For,10.0.0.1\nquery,q,search\nquery,token,[REDACTED]\n"
+		if w.Body.String() != want {
+			t.Errorf("expected %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("describes the request as text", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().SendEcho(w, newRequest())
+
+		want := "POST /debug HTTP/1.1\nHost: example.com\nAuthorization: [REDACTED]\nX-Forwarded-For: 10.0.0.1\n\nq=search\ntoken=[REDACTED]\n"
+		if w.Body.String() != want {
+			t.Errorf("expected %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("escapes the description in HTML", func(t *testing.T) {
+		req := newRequest()
+		req.Header.Set("X-Forwarded-For", "<script>")
+
+		w := httptest.NewRecorder()
+
+		HTMLResponder().SendEcho(w, req)
+
+		if b := w.Body.String(); !strings.HasPrefix(b, "<pre>POST /debug") || !strings.Contains(b, "X-Forwarded-For: &lt;script&gt;") {
+			t.Errorf("expected the escaped description, got %q", b)
+		}
+	})
+}
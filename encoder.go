@@ -0,0 +1,108 @@
+package responder
+
+import (
+	"io"
+	"sync"
+)
+
+// Encoder encodes the data of the responses. Unlike the DataFormatter,
+// it reports the data it fails to encode.
+type Encoder interface {
+	// ContentType returns the content type of the encoded data.
+	ContentType() string
+	// Encode writes the encoding of the value to the writer.
+	Encode(w io.Writer, v any) error
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = make(map[string]Encoder)
+)
+
+// RegisterEncoder registers the encoder of its content type, e.g. to encode
+// JSON with another library. The responders created afterwards with that
+// media type encode their data with it, in place of the built-in encoding.
+func RegisterEncoder(e Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	encoders[mediaType(e.ContentType())] = e
+}
+
+// registeredEncoder returns the encoder registered for the media type of the content type, if any.
+func registeredEncoder(contentType string) Encoder {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	return encoders[mediaType(contentType)]
+}
+
+// WithEncoder sets the encoder of the data, in place of the one of the content type of the responder.
+func WithEncoder(e Encoder) OptionsModifier {
+	return func(o *options) {
+		o.encoder = e
+		o.directEncoder = directEncoder(e)
+	}
+}
+
+// directEncoder returns the direct encoder of the encoder, which encodes all the values,
+// or nil when the encoder formats the data in memory anyway.
+func directEncoder(e Encoder) func(io.Writer, any) (bool, error) {
+	if _, ok := e.(formatterEncoder); ok {
+		return nil
+	}
+
+	return func(w io.Writer, v any) (bool, error) {
+		return true, e.Encode(w, v)
+	}
+}
+
+// FormatterEncoder adapts the data formatter to the Encoder interface.
+// The encoder never fails since the data formatters cannot report their failures.
+func FormatterEncoder(contentType string, f DataFormatter) Encoder {
+	return formatterEncoder{
+		contentType: contentType,
+		format: func(c any) ([]byte, error) {
+			return f(c), nil
+		},
+	}
+}
+
+// formatterEncoder is an Encoder formatting the data in memory.
+type formatterEncoder struct {
+	contentType string
+	format      func(any) ([]byte, error)
+	// localized formats the data with the conventions of the locale, see WithLocale.
+	localized func(*Locale, any) ([]byte, error)
+}
+
+func (e formatterEncoder) ContentType() string {
+	return e.contentType
+}
+
+func (e formatterEncoder) Encode(w io.Writer, v any) error {
+	b, err := e.format(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
+
+// encodeValue encodes the value in memory.
+func encodeValue(e Encoder, v any) ([]byte, error) {
+	if f, ok := e.(formatterEncoder); ok {
+		return f.format(v)
+	}
+
+	b := getBuffer()
+	if err := e.Encode(b, v); err != nil {
+		bufferPool.Put(b)
+
+		return nil, err
+	}
+
+	return releaseBytes(b), nil
+}
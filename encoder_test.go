@@ -0,0 +1,125 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+// upperEncoder encodes the strings in upper case and fails on the other values.
+type upperEncoder struct {
+	contentType string
+}
+
+func (e upperEncoder) ContentType() string {
+	return e.contentType
+}
+
+func (e upperEncoder) Encode(w io.Writer, v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot encode %T", v)
+	}
+
+	_, err := fmt.Fprintf(w, "%X", s)
+
+	return err
+}
+
+func TestWithEncoder(t *testing.T) {
+	t.Run("encodes the data with the encoder", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		TextResponder(WithEncoder(upperEncoder{})).Send200(w, "ok")
+
+		if b := w.Body.String(); b != "6F6B" {
+			t.Errorf("unexpected body %q", b)
+		}
+
+		if cl := w.Header().Get("Content-Length"); cl != "4" {
+			t.Errorf("expected the Content-Length, got %q", cl)
+		}
+	})
+
+	t.Run("reports the encoding failures to the checked responders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := Checked(TextResponder(WithEncoder(upperEncoder{}))).Send200(w, 42)
+
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("encodes the data directly", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		TextResponder(WithEncoder(upperEncoder{}), WithDirectEncoding(true)).Send200(w, "ok")
+
+		if b := w.Body.String(); b != "6F6B" {
+			t.Errorf("unexpected body %q", b)
+		}
+
+		if cl := w.Header().Get("Content-Length"); cl != "" {
+			t.Errorf("expected no Content-Length, got %q", cl)
+		}
+	})
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	const contentType = "application/vnd.upper"
+
+	RegisterEncoder(upperEncoder{contentType: contentType + "; charset=utf-8"})
+	t.Cleanup(func() {
+		encodersMu.Lock()
+		delete(encoders, contentType)
+		encodersMu.Unlock()
+	})
+
+	t.Run("encodes the data of the content type with the registered encoder", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		New(contentType).Send200(w, "ok")
+
+		if b := w.Body.String(); b != "6F6B" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("leaves the other content types untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		TextResponder().Send200(w, "ok")
+
+		if b := w.Body.String(); b != "ok" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("gives way to the encoder of the responder", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		New(contentType, WithDataFormatter(func(any) []byte { return []byte("custom") })).Send200(w, "ok")
+
+		if b := w.Body.String(); b != "custom" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+}
+
+func TestFormatterEncoder(t *testing.T) {
+	e := FormatterEncoder(TextContentType, func(c any) []byte { return fmt.Appendf(nil, "<%v>", c) })
+
+	if ct := e.ContentType(); ct != TextContentType {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	w := httptest.NewRecorder()
+	if err := e.Encode(w, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if b := w.Body.String(); b != "<1>" {
+		t.Errorf("unexpected body %q", b)
+	}
+}
@@ -0,0 +1,74 @@
+package responder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// JOSEContentType is the content type of the JWE and JWS compact serializations.
+const JOSEContentType = "application/jose"
+
+// Encrypter encrypts the response bodies, e.g. as JWE or age payloads.
+type Encrypter interface {
+	// ContentType returns the content type of the encrypted bodies, e.g. JOSEContentType.
+	ContentType() string
+
+	// Encrypt returns the encrypted body.
+	Encrypt([]byte) ([]byte, error)
+}
+
+// WithBodyEncryption encrypts the bodies of the successful responses once formatted,
+// for endpoints delivering secrets to clients holding a key. The responses are sent
+// with the content type of the encrypter. Error responses are sent in clear,
+// and so are the NDJSON streams, which are not buffered. Readers are read
+// in memory to be encrypted. When the encryption fails, a 500 Internal Server Error
+// is sent instead, the clear body never being sent.
+func WithBodyEncryption(e Encrypter) OptionsModifier {
+	return func(o *options) {
+		o.encrypter = e
+	}
+}
+
+// encrypt encrypts the body of successful responses. It returns the encrypted body
+// along with its content type, or the body unchanged when it must be sent in clear.
+func (r *responder) encrypt(code int, body []byte) ([]byte, string, error) {
+	if r.options.encrypter == nil || !Is2xx(code) || len(body) == 0 {
+		return body, r.contentType, nil
+	}
+
+	b, err := r.options.encrypter.Encrypt(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: failed to encrypt response: %w", ErrInvalidContent, err)
+	}
+
+	return b, r.options.encrypter.ContentType(), nil
+}
+
+// sendBuffered reads the body in memory before sending it,
+// so that it can be encrypted.
+func (r *responder) sendBuffered(rw responseWriter, code int, body any) error {
+	if c, ok := body.(io.Closer); ok {
+		defer func() {
+			_ = c.Close()
+		}()
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+
+	switch v := body.(type) {
+	case io.WriterTo:
+		_, err = v.WriteTo(&buf)
+	case io.Reader:
+		_, err = buf.ReadFrom(v)
+	}
+
+	if err != nil {
+		return r.sendFailure(rw, fmt.Errorf("%w: failed to read response: %w", ErrInvalidContent, err))
+	}
+
+	return r.send(rw, code, buf.Bytes(), 0)
+}
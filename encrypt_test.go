@@ -0,0 +1,111 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type reverseEncrypter struct {
+	err error
+}
+
+func (reverseEncrypter) ContentType() string { return JOSEContentType }
+
+func (e reverseEncrypter) Encrypt(b []byte) ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[len(b)-1-i] = c
+	}
+
+	return out, nil
+}
+
+func TestWithBodyEncryption(t *testing.T) {
+	t.Run("encrypts successful responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithBodyEncryption(reverseEncrypter{})).Send200(w, "secret")
+
+		if w.Body.String() != "terces" {
+			t.Errorf("expected the encrypted body, got %q", w.Body.String())
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != JOSEContentType {
+			t.Errorf("expected Content-Type %q, got %q", JOSEContentType, ct)
+		}
+
+		if cl := w.Header().Get("Content-Length"); cl != "6" {
+			t.Errorf("expected Content-Length %q, got %q", "6", cl)
+		}
+	})
+
+	t.Run("encrypts readers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithBodyEncryption(reverseEncrypter{})).Send200(w, strings.NewReader("secret"))
+
+		if w.Body.String() != "terces" {
+			t.Errorf("expected the encrypted body, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("sends errors in clear", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithBodyEncryption(reverseEncrypter{})).Send404(w, nil, "not found")
+
+		if w.Body.String() != "not found" {
+			t.Errorf("expected the clear body, got %q", w.Body.String())
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != TextContentType {
+			t.Errorf("expected Content-Type %q, got %q", TextContentType, ct)
+		}
+	})
+
+	t.Run("never sends the clear body when the encryption fails", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		w := httptest.NewRecorder()
+		responder := TextResponder(
+			WithBodyEncryption(reverseEncrypter{err: errors.New("no key")}),
+			WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		)
+
+		responder.Send200(w, "secret")
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+		}
+
+		if strings.Contains(w.Body.String(), "secret") {
+			t.Errorf("expected the clear body not to be sent, got %q", w.Body.String())
+		}
+
+		if !strings.Contains(logs.String(), "no key") {
+			t.Errorf("expected the failure to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("reports the encryption failure to checked responders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		responder := Checked(TextResponder(WithBodyEncryption(reverseEncrypter{err: errors.New("no key")})))
+
+		if err := responder.Send200(w, "secret"); !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+}
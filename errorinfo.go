@@ -0,0 +1,43 @@
+package responder
+
+// ErrorInfo is the context of an error response given to the ErrorInfoFormatter.
+type ErrorInfo struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Err is the internal error, which must not be exposed to the clients as is.
+	Err error
+	// Message is the message to be sent to the client.
+	// It is a DebugMessage in verbose mode, see WithDebugToken.
+	Message any
+	// RequestID is the ID of the request the responder is bound to, if any,
+	// see WithRequestID.
+	RequestID string
+	// ErrorID is the ID the error was stored under, if any, see WithErrorStore.
+	ErrorID string
+}
+
+// ErrorInfoFormatter formats the error responses from their context,
+// allowing the bodies to carry machine-readable codes, request IDs
+// or details depending on the environment.
+// Its output is passed to the Encoder.
+type ErrorInfoFormatter func(ErrorInfo) any
+
+// WithErrorInfoFormatter sets a formatter receiving the status code, the internal
+// error and the identifiers of the error responses in addition to their message.
+// It takes precedence over the error formatter and the JSON error shape.
+func WithErrorInfoFormatter(f ErrorInfoFormatter) OptionsModifier {
+	return func(o *options) {
+		o.errorInfoFormatter = f
+	}
+}
+
+// errorInfo returns the context of the error response.
+func (r *responder) errorInfo(code int, err error, message any, errorID string) ErrorInfo {
+	return ErrorInfo{
+		Status:    code,
+		Err:       err,
+		Message:   message,
+		RequestID: r.requestID(),
+		ErrorID:   errorID,
+	}
+}
@@ -0,0 +1,79 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithErrorInfoFormatter(t *testing.T) {
+	format := func(e ErrorInfo) any {
+		var cause string
+		if e.Err != nil {
+			cause = e.Err.Error()
+		}
+
+		return map[string]any{
+			"status":     e.Status,
+			"message":    e.Message,
+			"cause":      cause,
+			"request_id": e.RequestID,
+			"error_id":   e.ErrorID,
+		}
+	}
+
+	t.Run("receives the status code, the error and the request ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", "req-1")
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithErrorInfoFormatter(format)).
+			WithRequest(req).
+			Send404(w, errors.New("no row"), "not found")
+
+		expected := `{"cause":"no row","error_id":"","message":"not found","request_id":"req-1","status":404}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("receives the ID of the stored errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithErrorInfoFormatter(format), WithErrorStore(&memoryErrorStore{})).
+			Send500(w, errors.New("boom"), "error")
+
+		id := w.Header().Get(ErrorIDHeader)
+		if id == "" || !strings.Contains(w.Body.String(), `"error_id":"`+id+`"`) {
+			t.Errorf("expected the error ID %q in the body, got %q", id, w.Body.String())
+		}
+	})
+
+	t.Run("takes precedence over the error formatter and the JSON error shape", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(
+			WithErrorInfoFormatter(func(e ErrorInfo) any { return map[string]int{"code": e.Status} }),
+			WithErrorFormatter(func(any) any { return "formatter" }),
+			WithJSONErrorShape(func(int, error, any) any { return "shape" }),
+		).Send400(w, nil, "bad request")
+
+		if expected := `{"code":400}`; w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("applies to every content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithErrorInfoFormatter(func(e ErrorInfo) any { return http.StatusText(e.Status) })).
+			Send409(w, nil, "conflict")
+
+		if w.Body.String() != "Conflict" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package responder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// ErrorCoder is implemented by errors carrying a machine-readable error code.
+type ErrorCoder interface {
+	// ErrorCode returns the machine-readable code of the error.
+	ErrorCode() string
+}
+
+// WithStructuredErrorLog makes the error logs carry the whole error response
+// as a single "response" attribute group: status, code, fingerprint,
+// request ID and client message. Used with a JSON handler, log pipelines
+// can parse errors without regular expressions.
+func WithStructuredErrorLog(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.structuredErrorLog = enabled
+	}
+}
+
+// WithLogAttrs adds the attributes returned by the function to the error logs,
+// e.g. the request ID, the route or the user ID stored in the context by
+// a middleware, so that they can be correlated with the access logs.
+// The function receives the context of the request the responder is bound to,
+// or the background context when it is not bound to any.
+func WithLogAttrs(f func(ctx context.Context) []slog.Attr) OptionsModifier {
+	return func(o *options) {
+		o.logAttrs = f
+	}
+}
+
+// WithLogLevelMapper sets the level the errors are logged at from the status
+// code of their responses, e.g. StatusLogLevel, instead of the error level.
+// The level set on the response with ErrorResponse.WithLogLevel takes precedence.
+func WithLogLevelMapper(f func(status int) slog.Level) OptionsModifier {
+	return func(o *options) {
+		o.logLevelMapper = f
+	}
+}
+
+// StatusLogLevel logs the client errors at the warning level
+// and the server errors at the error level.
+func StatusLogLevel(status int) slog.Level {
+	if Is4xx(status) {
+		return slog.LevelWarn
+	}
+
+	return slog.LevelError
+}
+
+// errorAttr builds the attribute group describing the error response.
+func errorAttr(requestID string, code int, err error, message any) slog.Attr {
+	attrs := []any{
+		slog.Int("status", code),
+	}
+
+	var ec ErrorCoder
+	if errors.As(err, &ec) {
+		attrs = append(attrs, slog.String("code", ec.ErrorCode()))
+	}
+
+	msg := internal.MessageToString(message)
+
+	attrs = append(attrs,
+		slog.String("fingerprint", fingerprint(code, err, msg)),
+		slog.String("message", msg),
+	)
+
+	if requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	return slog.Group("response", attrs...)
+}
+
+// fingerprint identifies errors of the same kind, regardless of
+// the variable parts of their text, to group them in log pipelines.
+func fingerprint(code int, err error, message string) string {
+	root := err
+	for u := errors.Unwrap(root); u != nil; u = errors.Unwrap(root) {
+		root = u
+	}
+
+	sum := sha256.Sum256(fmt.Appendf(nil, "%d|%T|%s", code, root, message))
+
+	return hex.EncodeToString(sum[:8])
+}
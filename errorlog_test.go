@@ -0,0 +1,192 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type codedError struct{}
+
+func (codedError) Error() string     { return "quota exceeded" }
+func (codedError) ErrorCode() string { return "QUOTA_EXCEEDED" }
+
+func TestWithStructuredErrorLog(t *testing.T) {
+	newLogger := func(buf *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewJSONHandler(buf, nil))
+	}
+
+	t.Run("logs the error response as a group", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", "req-1")
+
+		w := httptest.NewRecorder()
+		responder := JSONResponder(WithLogger(newLogger(&logs)), WithStructuredErrorLog(true))
+
+		responder.WithRequest(req).Send403(w, fmt.Errorf("checking quota: %w", codedError{}), "quota exceeded")
+
+		var record struct {
+			Response struct {
+				Status      int    `json:"status"`
+				Code        string `json:"code"`
+				Fingerprint string `json:"fingerprint"`
+				RequestID   string `json:"request_id"`
+				Message     string `json:"message"`
+			} `json:"response"`
+		}
+
+		if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+			t.Fatalf("failed to unmarshal log record: %v (%s)", err, logs.String())
+		}
+
+		r := record.Response
+		if r.Status != http.StatusForbidden || r.Code != "QUOTA_EXCEEDED" || r.RequestID != "req-1" ||
+			r.Message != "quota exceeded" || r.Fingerprint == "" {
+			t.Errorf("unexpected response group %+v", r)
+		}
+	})
+
+	t.Run("does not log the group by default", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithLogger(newLogger(&logs))).Send500(w, errors.New("boom"), "error")
+
+		if bytes.Contains(logs.Bytes(), []byte(`"response"`)) {
+			t.Errorf("expected no response group, got %s", logs.String())
+		}
+	})
+}
+
+type routeKey struct{}
+
+// contextHandler records the contexts of the log records.
+type contextHandler struct {
+	slog.Handler
+	contexts []context.Context
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.contexts = append(h.contexts, ctx)
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestWithLogAttrs(t *testing.T) {
+	attrs := func(ctx context.Context) []slog.Attr {
+		route, _ := ctx.Value(routeKey{}).(string)
+
+		return []slog.Attr{slog.String("route", route)}
+	}
+
+	t.Run("adds the attributes of the request context", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		h := &contextHandler{Handler: slog.NewJSONHandler(&logs, nil)}
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		req = req.WithContext(context.WithValue(req.Context(), routeKey{}, "/users/{id}"))
+
+		JSONResponder(WithLogger(slog.New(h)), WithLogAttrs(attrs)).
+			WithRequest(req).
+			Send500(httptest.NewRecorder(), errors.New("boom"), "error")
+
+		var record struct {
+			Route  string `json:"route"`
+			Status int    `json:"status"`
+		}
+
+		if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+			t.Fatalf("failed to unmarshal log record: %v (%s)", err, logs.String())
+		}
+
+		if record.Route != "/users/{id}" || record.Status != http.StatusInternalServerError {
+			t.Errorf("unexpected log record %+v", record)
+		}
+
+		if len(h.contexts) != 1 || h.contexts[0].Value(routeKey{}) != "/users/{id}" {
+			t.Errorf("expected the record to be logged with the request context")
+		}
+	})
+
+	t.Run("receives the background context without request", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		JSONResponder(WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))), WithLogAttrs(attrs)).
+			Send500(httptest.NewRecorder(), errors.New("boom"), "error")
+
+		if !bytes.Contains(logs.Bytes(), []byte(`"route":""`)) {
+			t.Errorf("unexpected log record %s", logs.String())
+		}
+	})
+}
+
+func TestWithLogLevelMapper(t *testing.T) {
+	newLogger := func(buf *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	level := func(logs *bytes.Buffer) string {
+		var record struct {
+			Level string `json:"level"`
+		}
+
+		_ = json.Unmarshal(logs.Bytes(), &record)
+
+		return record.Level
+	}
+
+	t.Run("maps the status codes to levels", func(t *testing.T) {
+		tests := []struct {
+			send     func(Responder, http.ResponseWriter)
+			expected string
+		}{
+			{func(r Responder, w http.ResponseWriter) { r.Send404(w, errors.New("no row"), "not found") }, "WARN"},
+			{func(r Responder, w http.ResponseWriter) { r.Send503(w, errors.New("down"), "unavailable") }, "ERROR"},
+		}
+
+		for _, tc := range tests {
+			var logs bytes.Buffer
+
+			tc.send(JSONResponder(WithLogger(newLogger(&logs)), WithLogLevelMapper(StatusLogLevel)), httptest.NewRecorder())
+
+			if l := level(&logs); l != tc.expected {
+				t.Errorf("expected level %q, got %q", tc.expected, l)
+			}
+		}
+	})
+
+	t.Run("gives precedence to the level of the response", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		resp := Error(http.StatusNotFound, errors.New("no row"), "not found").(ErrorResponse).WithLogLevel(slog.LevelDebug)
+
+		JSONResponder(WithLogger(newLogger(&logs)), WithLogLevelMapper(StatusLogLevel)).Send(httptest.NewRecorder(), resp)
+
+		if l := level(&logs); l != "DEBUG" {
+			t.Errorf("expected level %q, got %q", "DEBUG", l)
+		}
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	a := fingerprint(500, fmt.Errorf("user 1: %w", codedError{}), "error")
+	b := fingerprint(500, fmt.Errorf("user 2: %w", codedError{}), "error")
+	c := fingerprint(404, fmt.Errorf("user 2: %w", codedError{}), "error")
+
+	if a != b {
+		t.Errorf("expected errors of the same kind to share a fingerprint, got %q and %q", a, b)
+	}
+
+	if a == c {
+		t.Errorf("expected different statuses to have different fingerprints, got %q", a)
+	}
+}
@@ -0,0 +1,120 @@
+package responder
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// ErrorMapper converts an error into the Response sent to the client.
+type ErrorMapper func(error) Response
+
+// WithErrorMapper sets the mapper used by SendError to convert the errors into
+// responses, e.g. the Response method of an ErrorMap. DefaultErrorMapper is used
+// when none is provided.
+func WithErrorMapper(m ErrorMapper) OptionsModifier {
+	return func(o *options) {
+		o.errorMapper = m
+	}
+}
+
+// ErrorMapping describes the response sent for an error.
+type ErrorMapping struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Message is the message sent to the client.
+	// It defaults to the status text when empty.
+	Message string
+	// Level is the level the error is logged at, the error level when nil.
+	Level slog.Leveler
+}
+
+// response creates the error response for the error.
+func (m ErrorMapping) response(err error) Response {
+	msg := m.Message
+	if msg == "" {
+		msg = http.StatusText(m.Status)
+	}
+
+	return ErrorResponse{
+		status:  m.Status,
+		err:     err,
+		message: msg,
+		level:   m.Level,
+	}
+}
+
+// ErrorMap maps the errors to responses, replacing the switch statements
+// choosing the status code of the error responses in the handlers.
+// The rules are evaluated in the order they were added, the first matching one
+// being used. The errors no rule matches are converted with DefaultErrorMapper.
+// An ErrorMap must not be modified once in use.
+type ErrorMap struct {
+	rules []ErrorMapper
+}
+
+// NewErrorMap creates an empty ErrorMap.
+func NewErrorMap() *ErrorMap {
+	return &ErrorMap{}
+}
+
+// Map maps the errors matching the target, as reported by errors.Is.
+func (m *ErrorMap) Map(target error, mapping ErrorMapping) *ErrorMap {
+	return m.MapFunc(func(err error) Response {
+		if !errors.Is(err, target) {
+			return nil
+		}
+
+		return mapping.response(err)
+	})
+}
+
+// MapFunc adds a rule mapping the errors with a function,
+// which returns nil for the errors it does not handle.
+func (m *ErrorMap) MapFunc(f ErrorMapper) *ErrorMap {
+	m.rules = append(m.rules, f)
+
+	return m
+}
+
+// MapAs maps the errors of type T, as reported by errors.As.
+// The function builds the mapping from the matched error.
+func MapAs[T error](m *ErrorMap, f func(T) ErrorMapping) *ErrorMap {
+	return m.MapFunc(func(err error) Response {
+		var target T
+		if !errors.As(err, &target) {
+			return nil
+		}
+
+		return f(target).response(err)
+	})
+}
+
+// Response returns the response of the first rule matching the error.
+func (m *ErrorMap) Response(err error) Response {
+	for _, rule := range m.rules {
+		if resp := rule(err); resp != nil {
+			return resp
+		}
+	}
+
+	return DefaultErrorMapper(err)
+}
+
+// sendMappedError converts the error into a response with the error mapper and sends it.
+func (r *responder) sendMappedError(rw responseWriter, err error) error {
+	if rerr := r.ready(rw); rerr != nil {
+		return rerr
+	}
+
+	mapper := r.options.errorMapper
+	if mapper == nil {
+		mapper = DefaultErrorMapper
+	}
+
+	return r.sendResponse(rw, mapper(err))
+}
+
+func (r *responder) SendError(rw responseWriter, err error) {
+	_ = r.sendMappedError(rw, err)
+}
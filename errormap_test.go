@@ -0,0 +1,121 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var (
+	errNotFound = errors.New("not found")
+	errConflict = errors.New("conflict")
+)
+
+type validationError struct {
+	field string
+}
+
+func (e *validationError) Error() string { return "invalid " + e.field }
+
+func TestErrorMap(t *testing.T) {
+	m := NewErrorMap().
+		Map(errNotFound, ErrorMapping{Status: http.StatusNotFound, Message: "no such user", Level: slog.LevelInfo}).
+		Map(errConflict, ErrorMapping{Status: http.StatusConflict})
+
+	MapAs(m, func(e *validationError) ErrorMapping {
+		return ErrorMapping{Status: http.StatusBadRequest, Message: e.Error(), Level: slog.LevelWarn}
+	})
+
+	testCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+		wantLevel  string
+	}{
+		{
+			name:       "sentinel error",
+			err:        errNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   "no such user",
+			wantLevel:  "INFO",
+		},
+		{
+			name:       "wrapped sentinel error",
+			err:        fmt.Errorf("loading user: %w", errNotFound),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "no such user",
+			wantLevel:  "INFO",
+		},
+		{
+			name:       "default message and level",
+			err:        errConflict,
+			wantStatus: http.StatusConflict,
+			wantBody:   "Conflict",
+			wantLevel:  "ERROR",
+		},
+		{
+			name:       "error type",
+			err:        fmt.Errorf("decoding: %w", &validationError{field: "email"}),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "invalid email",
+			wantLevel:  "WARN",
+		},
+		{
+			name:       "unmapped error",
+			err:        errors.New("database is down"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error",
+			wantLevel:  "ERROR",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+
+			w := httptest.NewRecorder()
+			responder := TextResponder(
+				WithErrorMapper(m.Response),
+				WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			)
+
+			responder.SendError(w, tc.err)
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, w.Code)
+			}
+
+			if w.Body.String() != tc.wantBody {
+				t.Errorf("expected body %q, got %q", tc.wantBody, w.Body.String())
+			}
+
+			if !strings.Contains(logs.String(), "level="+tc.wantLevel) {
+				t.Errorf("expected the error to be logged at %s, got %q", tc.wantLevel, logs.String())
+			}
+		})
+	}
+}
+
+func TestSendError(t *testing.T) {
+	t.Run("uses the default mapper", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().SendError(w, statusError{status: http.StatusGone, msg: "gone"})
+
+		if w.Code != http.StatusGone || w.Body.String() != "gone" {
+			t.Errorf("expected a 410 with the error text, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("is reported by checked responders", func(t *testing.T) {
+		if err := Checked(TextResponder()).SendError(nil, errNotFound); !errors.Is(err, ErrNilWriter) {
+			t.Errorf("expected ErrNilWriter, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package responder
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// defaultErrorPage is the template of the error pages of the unmapped status codes.
+const defaultErrorPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>
+<body><h1>{{.Title}}</h1><p>{{.Message}}</p></body>
+</html>
+`
+
+// ErrorPage is the data the error templates are executed with.
+type ErrorPage struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Title is the text of the status code, e.g. "Not Found".
+	Title string
+	// Message is the message of the response, converted to a string.
+	Message string
+	// RequestID is the ID of the request, if any, see WithRequestID.
+	RequestID string
+	// ErrorID is the ID the error was stored under, if any, see WithErrorStore.
+	ErrorID string
+}
+
+// errorTemplates holds the parsed error templates.
+type errorTemplates struct {
+	pages    map[int]*template.Template
+	fallback *template.Template
+	err      error
+}
+
+// WithErrorTemplates makes the HTML responders render the error responses as pages,
+// executing the html/template of their status code with an ErrorPage.
+// The template mapped to the status code 0, or a minimal page when none is,
+// renders the unmapped status codes. The templates that cannot be parsed
+// or executed are reported as ErrInvalidContent by the checked responders,
+// the other responders sending the message as is.
+// It takes precedence over the error formatter but not the error info formatter.
+func WithErrorTemplates(templates map[int]string) OptionsModifier {
+	return func(o *options) {
+		t := &errorTemplates{pages: make(map[int]*template.Template, len(templates))}
+
+		for status, text := range templates {
+			page, err := template.New(fmt.Sprintf("error%d", status)).Parse(text)
+			if err != nil {
+				t.err = err
+
+				break
+			}
+
+			t.pages[status] = page
+		}
+
+		t.fallback = t.pages[0]
+		if t.fallback == nil {
+			t.fallback = template.Must(template.New("error").Parse(defaultErrorPage))
+		}
+
+		o.errorTemplates = t
+	}
+}
+
+// render renders the error page of the response.
+func (t *errorTemplates) render(info ErrorInfo) ([]byte, error) {
+	if t.err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContent, t.err)
+	}
+
+	page := t.pages[info.Status]
+	if page == nil {
+		page = t.fallback
+	}
+
+	var b bytes.Buffer
+
+	err := page.Execute(&b, ErrorPage{
+		Status:    info.Status,
+		Title:     http.StatusText(info.Status),
+		Message:   internal.MessageToString(info.Message),
+		RequestID: info.RequestID,
+		ErrorID:   info.ErrorID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContent, err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// renderErrorPage renders the error page of the response, falling back
+// to the formatted message when the page cannot be rendered by unchecked responders.
+func (r *responder) renderErrorPage(info ErrorInfo) ([]byte, error) {
+	body, err := r.options.errorTemplates.render(info)
+	if err != nil && !r.checked {
+		return r.format(r.options.errorFormatter(info.Message))
+	}
+
+	return body, err
+}
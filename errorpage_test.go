@@ -0,0 +1,80 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithErrorTemplates(t *testing.T) {
+	templates := map[int]string{
+		http.StatusNotFound: `<html><body><h1>Lost?</h1><p>{{.Message}}</p></body></html>`,
+	}
+
+	t.Run("renders the template of the status code", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithErrorTemplates(templates)).Send404(w, nil, "<no> such page")
+
+		want := `<html><body><h1>Lost?</h1><p>&lt;no&gt; such page</p></body></html>`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %q, got %q", want, b)
+		}
+	})
+
+	t.Run("renders the default page of the unmapped status codes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithErrorTemplates(templates)).Send500(w, errors.New("boom"), "try again later")
+
+		b := w.Body.String()
+		if !strings.Contains(b, "<h1>Internal Server Error</h1>") || !strings.Contains(b, "<p>try again later</p>") {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("renders the template mapped to 0 for the unmapped status codes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithErrorTemplates(map[int]string{0: `{{.Status}}: {{.Message}}`})).Send403(w, nil, "denied")
+
+		if b := w.Body.String(); b != "403: denied" {
+			t.Errorf("expected body %q, got %q", "403: denied", b)
+		}
+	})
+
+	t.Run("does not apply to the other content types", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithErrorTemplates(templates)).Send404(w, nil, "not found")
+
+		if b := w.Body.String(); b != "not found" {
+			t.Errorf("expected body %q, got %q", "not found", b)
+		}
+	})
+
+	t.Run("reports the invalid templates to checked responders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(HTMLResponder(WithErrorTemplates(map[int]string{404: `{{.Message`}))).Send404(w, nil, "not found")
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("falls back to the error formatter when the template fails", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithErrorTemplates(map[int]string{404: `{{.Missing}}`})).Send404(w, nil, "not found")
+
+		if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "not found") {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package responder
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// ErrorIDHeader is the header carrying the ID of the server errors persisted
+// in the ErrorStore, which the clients can quote to the support team.
+const ErrorIDHeader = "X-Error-ID"
+
+// ErrorRecord describes a server error sent by a responder, along with
+// the context of the request it was sent in response to.
+type ErrorRecord struct {
+	// ID identifies the error. It is sent in the X-Error-ID header.
+	ID string
+	// Time is the time the error was sent at.
+	Time time.Time
+	// Status is the status code of the response.
+	Status int
+	// Message is the message sent to the client.
+	Message string
+	// Error is the text of the internal error.
+	Error string
+	// Stack is the stack trace of the panic the error was recovered from, if any.
+	Stack string
+	// Method is the method of the request, if the responder is bound to one.
+	Method string
+	// URL is the URL of the request, its query values being redacted.
+	URL string
+	// RequestID is the ID of the request, see WithRequestID.
+	RequestID string
+	// Header holds the header of the request, its values being redacted.
+	Header http.Header
+}
+
+// ErrorStore persists the server errors, so that a support lookup by ID
+// returns their full context even when the logs have rotated.
+type ErrorStore interface {
+	// Store persists the record. The context is the one of the request
+	// the responder is bound to, if any.
+	Store(context.Context, ErrorRecord) error
+}
+
+// WithErrorStore assigns an ID to the server errors carrying an internal error,
+// sends it in the X-Error-ID header, adds it to the logs as error_id and
+// persists the error in the store. The request URL and header are redacted
+// with the Redactor of the responder. Storage failures are logged if a logger
+// was provided, they do not affect the response.
+func WithErrorStore(s ErrorStore) OptionsModifier {
+	return func(o *options) {
+		o.errorStore = s
+	}
+}
+
+// storeError persists the server error and returns its ID,
+// or an empty string when it is not persisted.
+func (r *responder) storeError(rw responseWriter, code int, err error, message any) string {
+	if r.options.errorStore == nil || err == nil || !Is5xx(code) {
+		return ""
+	}
+
+	id := newErrorID()
+	rw.Header().Set(ErrorIDHeader, id)
+
+	rec := ErrorRecord{
+		ID:      id,
+		Time:    time.Now(),
+		Status:  code,
+		Message: internal.MessageToString(message),
+		Error:   err.Error(),
+	}
+
+	var pe *panicError
+	if errors.As(err, &pe) {
+		rec.Stack = string(pe.stack)
+	}
+
+	ctx := context.Background()
+
+	if req := r.request; req != nil {
+		ctx = req.Context()
+		rec.Method = req.Method
+		rec.URL = r.redactURL(req.URL)
+		rec.RequestID = r.requestID()
+		rec.Header = redactValues(req.Header, r.options.redactor)
+	}
+
+	if serr := r.options.errorStore.Store(ctx, rec); serr != nil && r.options.logger != nil {
+		r.options.logger.Error("failed to store error",
+			"status", code,
+			"error_id", id,
+			"error", serr,
+		)
+	}
+
+	return id
+}
+
+// redactURL returns the URL with its query values redacted.
+func (r *responder) redactURL(u *url.URL) string {
+	c := *u
+	c.RawQuery = url.Values(redactValues(u.Query(), r.options.redactor)).Encode()
+
+	return c.String()
+}
+
+// newErrorID returns a random error ID.
+func newErrorID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,112 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type memoryErrorStore struct {
+	mu      sync.Mutex
+	records []ErrorRecord
+	err     error
+}
+
+func (s *memoryErrorStore) Store(_ context.Context, rec ErrorRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, rec)
+
+	return s.err
+}
+
+func TestWithErrorStore(t *testing.T) {
+	t.Run("persists the server errors with their context", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		store := &memoryErrorStore{}
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		req := httptest.NewRequest(http.MethodGet, "/orders?token=abc&page=2", nil)
+		req.Header.Set("Authorization", "Bearer abc")
+		req.Header.Set("X-Request-ID", "req-1")
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithLogger(logger), WithErrorStore(store)).
+			WithRequest(req).
+			Send500(w, errors.New("connection refused"), "internal error")
+
+		if len(store.records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(store.records))
+		}
+
+		rec := store.records[0]
+
+		if id := w.Header().Get(ErrorIDHeader); id == "" || id != rec.ID {
+			t.Errorf("expected the %s header to carry the ID %q, got %q", ErrorIDHeader, rec.ID, id)
+		}
+
+		if rec.Status != http.StatusInternalServerError || rec.Error != "connection refused" || rec.Message != "internal error" {
+			t.Errorf("unexpected record %+v", rec)
+		}
+
+		if rec.Method != http.MethodGet || rec.URL != "/orders?page=2&token=%5BREDACTED%5D" || rec.RequestID != "req-1" {
+			t.Errorf("unexpected request context %+v", rec)
+		}
+
+		if v := rec.Header.Get("Authorization"); v != RedactedValue {
+			t.Errorf("expected the Authorization header to be redacted, got %q", v)
+		}
+
+		if !strings.Contains(logs.String(), "error_id="+rec.ID) {
+			t.Errorf("expected the ID to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("captures the stack of the panics", func(t *testing.T) {
+		store := &memoryErrorStore{}
+		handler := Recoverer(JSONResponder(WithErrorStore(store)))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if len(store.records) != 1 || !strings.Contains(store.records[0].Stack, "goroutine") {
+			t.Errorf("expected the stack to be stored, got %+v", store.records)
+		}
+	})
+
+	t.Run("ignores the client errors and the errors without cause", func(t *testing.T) {
+		store := &memoryErrorStore{}
+		responder := JSONResponder(WithErrorStore(store))
+
+		w := httptest.NewRecorder()
+		responder.Send404(w, errors.New("no row"), "not found")
+		responder.Send503(w, nil, "maintenance")
+
+		if len(store.records) != 0 || w.Header().Get(ErrorIDHeader) != "" {
+			t.Errorf("expected nothing to be stored, got %+v", store.records)
+		}
+	})
+
+	t.Run("logs the storage failures", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithLogger(logger), WithErrorStore(&memoryErrorStore{err: errors.New("db down")})).
+			Send500(w, errors.New("boom"), "internal error")
+
+		if w.Code != http.StatusInternalServerError || !strings.Contains(logs.String(), "failed to store error") {
+			t.Errorf("expected the failure to be logged, got %q", logs.String())
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package responder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETagFunc computes the ETag of a file served with range support.
+// Implementations reading the content must leave it positioned at its start.
+type ETagFunc func(size int64, modtime time.Time, content io.ReadSeeker) (string, error)
+
+// WeakFileETag is an ETagFunc deriving a weak ETag from the size and the modification time,
+// without reading the content. It is cheap, but resumable downloads restart whenever
+// the modification time changes, e.g. after a deploy.
+func WeakFileETag(size int64, modtime time.Time, _ io.ReadSeeker) (string, error) {
+	return fmt.Sprintf(`W/"%x-%x"`, size, modtime.UnixNano()), nil
+}
+
+// StrongFileETag is an ETagFunc hashing the content with SHA-256.
+// The ETag only changes when the content does, and being strong,
+// it can be used to validate If-Range requests.
+func StrongFileETag(_ int64, _ time.Time, content io.ReadSeeker) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return "", err
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`, nil
+}
+
+// IfRange reports whether the Range header of the request should be honored,
+// given the current ETag and modification time of the representation.
+// It returns true when the request has no If-Range precondition. An entity tag
+// precondition requires a strong match, as mandated by RFC 9110, whereas a date
+// precondition matches when the representation has not been modified since.
+func IfRange(r *http.Request, etag string, modtime time.Time) bool {
+	ir := strings.TrimSpace(r.Header.Get("If-Range"))
+	if ir == "" {
+		return true
+	}
+
+	if strings.HasPrefix(ir, `"`) || strings.HasPrefix(ir, "W/") {
+		return !strings.HasPrefix(ir, "W/") && !strings.HasPrefix(etag, "W/") && ir == etag
+	}
+
+	t, err := http.ParseTime(ir)
+	if err != nil || modtime.IsZero() {
+		return false
+	}
+
+	return !modtime.Truncate(time.Second).After(t)
+}
+
+// NotModified reports whether a 304 Not Modified response should be sent to the
+// conditional GET or HEAD request, given the current ETag and modification time
+// of the representation, either being left empty when unknown. As mandated by
+// RFC 9110, the If-Modified-Since header is ignored when an If-None-Match header is
+// present, which is compared weakly. The caller should set the ETag and Last-Modified
+// headers of the response in both cases, see Send304.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if r.Header.Get("If-None-Match") != "" {
+		return etag != "" && noneMatch(r, etag)
+	}
+
+	t, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+// WithETag makes the responder compute the ETag of the 200 OK responses from
+// their formatted body. When the responder is bound to a GET or HEAD request
+// whose If-None-Match header matches the ETag, a 304 Not Modified response
+// without body is sent instead. The ETag is weak when requested,
+// signaling that the representation is only semantically equivalent,
+// and when the response is compressed, since it is computed from the
+// uncompressed body.
+func WithETag(weak bool) OptionsModifier {
+	return func(o *options) {
+		o.etag = true
+		o.weakETag = weak
+	}
+}
+
+// bodyETag computes the ETag of the body.
+func bodyETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	tag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	if weak {
+		return "W/" + tag
+	}
+
+	return tag
+}
+
+// noneMatch reports whether the If-None-Match header of the request matches the ETag.
+// As mandated by RFC 9110, the comparison is weak.
+func noneMatch(r *http.Request, etag string) bool {
+	inm := r.Header.Get("If-None-Match")
+	if inm == "" {
+		return false
+	}
+
+	for candidate := range strings.SplitSeq(inm, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,265 @@
+package responder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileETags(t *testing.T) {
+	modtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("weak ETag depends on size and modification time", func(t *testing.T) {
+		a, _ := WeakFileETag(10, modtime, nil)
+		b, _ := WeakFileETag(10, modtime.Add(time.Second), nil)
+
+		if !strings.HasPrefix(a, `W/"`) {
+			t.Errorf("expected a weak ETag, got %q", a)
+		}
+
+		if a == b {
+			t.Errorf("expected different ETags for different modification times, got %q", a)
+		}
+	})
+
+	t.Run("strong ETag only depends on the content", func(t *testing.T) {
+		content := strings.NewReader("hello world")
+
+		a, err := StrongFileETag(11, modtime, content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		b, _ := StrongFileETag(11, modtime.Add(time.Hour), strings.NewReader("hello world"))
+		if a != b {
+			t.Errorf("expected identical ETags, got %q and %q", a, b)
+		}
+
+		if strings.HasPrefix(a, "W/") {
+			t.Errorf("expected a strong ETag, got %q", a)
+		}
+
+		rest, _ := io.ReadAll(content)
+		if string(rest) != "hello world" {
+			t.Errorf("expected the content to be rewound, got %q", rest)
+		}
+	})
+}
+
+func TestIfRange(t *testing.T) {
+	modtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	strong := `"abc"`
+
+	testCases := []struct {
+		name    string
+		ifRange string
+		etag    string
+		want    bool
+	}{
+		{name: "no precondition", ifRange: "", etag: strong, want: true},
+		{name: "matching strong ETag", ifRange: strong, etag: strong, want: true},
+		{name: "different ETag", ifRange: `"def"`, etag: strong, want: false},
+		{name: "weak ETag never matches", ifRange: `W/"abc"`, etag: `W/"abc"`, want: false},
+		{name: "unmodified since date", ifRange: modtime.Format(http.TimeFormat), etag: strong, want: true},
+		{name: "modified since date", ifRange: modtime.Add(-time.Hour).Format(http.TimeFormat), etag: strong, want: false},
+		{name: "invalid date", ifRange: "yesterday", etag: strong, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.ifRange != "" {
+				req.Header.Set("If-Range", tc.ifRange)
+			}
+
+			if got := IfRange(req, tc.etag, modtime); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	modtime := time.Date(2024, 1, 2, 3, 4, 5, 500, time.UTC)
+	etag := `"abc"`
+
+	testCases := []struct {
+		name         string
+		method       string
+		ifNoneMatch  string
+		ifModSince   string
+		etag         string
+		lastModified time.Time
+		want         bool
+	}{
+		{name: "no precondition", etag: etag, lastModified: modtime, want: false},
+		{name: "matching ETag", ifNoneMatch: etag, etag: etag, want: true},
+		{name: "weakly matching ETag", ifNoneMatch: `W/"abc"`, etag: etag, want: true},
+		{name: "any ETag", ifNoneMatch: "*", etag: etag, want: true},
+		{name: "different ETag", ifNoneMatch: `"def"`, etag: etag, lastModified: modtime, want: false},
+		{name: "unknown ETag", ifNoneMatch: etag, lastModified: modtime, want: false},
+		{name: "ETag takes precedence over date", ifNoneMatch: `"def"`, ifModSince: modtime.Format(http.TimeFormat),
+			etag: etag, lastModified: modtime, want: false},
+		{name: "unmodified since date", ifModSince: modtime.Format(http.TimeFormat), lastModified: modtime, want: true},
+		{name: "modified since date", ifModSince: modtime.Add(-time.Hour).Format(http.TimeFormat),
+			lastModified: modtime, want: false},
+		{name: "unknown modification time", ifModSince: modtime.Format(http.TimeFormat), want: false},
+		{name: "unsafe method", method: http.MethodPost, ifNoneMatch: etag, etag: etag, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "/", nil)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			if tc.ifModSince != "" {
+				req.Header.Set("If-Modified-Since", tc.ifModSince)
+			}
+
+			if got := NotModified(req, tc.etag, tc.lastModified); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSend304(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set("ETag", `"abc"`)
+
+	JSONResponder().Send304(w)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected status %d, got %d", http.StatusNotModified, w.Code)
+	}
+
+	if w.Body.Len() != 0 || w.Header().Get("Content-Length") != "" {
+		t.Errorf("expected no body nor Content-Length, got %q", w.Body.String())
+	}
+
+	if w.Header().Get("ETag") != `"abc"` {
+		t.Errorf("expected the ETag to be kept, got %q", w.Header().Get("ETag"))
+	}
+}
+
+func TestWithETag(t *testing.T) {
+	t.Run("sets a strong ETag on 200 responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithETag(false)).Send200(w, map[string]string{"id": "1"})
+
+		etag := w.Header().Get("ETag")
+		if !strings.HasPrefix(etag, `"`) {
+			t.Errorf("expected a strong ETag, got %q", etag)
+		}
+	})
+
+	t.Run("sets a weak ETag when requested", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithETag(true)).Send200(w, "data")
+
+		if etag := w.Header().Get("ETag"); !strings.HasPrefix(etag, `W/"`) {
+			t.Errorf("expected a weak ETag, got %q", etag)
+		}
+	})
+
+	t.Run("sets a weak ETag on the compressed responses", func(t *testing.T) {
+		responder := TextResponder(WithETag(false), WithCompression(), WithCompressionThreshold(0))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+
+		responder.WithRequest(r).Send200(w, "data")
+
+		if w.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("expected a gzip response, got %q", w.Header().Get("Content-Encoding"))
+		}
+
+		etag := w.Header().Get("ETag")
+		if !strings.HasPrefix(etag, `W/"`) {
+			t.Errorf("expected a weak ETag, got %q", etag)
+		}
+
+		w = httptest.NewRecorder()
+		r.Header.Set("If-None-Match", etag)
+
+		responder.WithRequest(r).Send200(w, "data")
+
+		if w.Code != http.StatusNotModified || w.Header().Get("ETag") != etag {
+			t.Errorf("expected a 304 with ETag %q, got %d %q", etag, w.Code, w.Header().Get("ETag"))
+		}
+
+		w = httptest.NewRecorder()
+
+		responder.WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).Send200(w, "data")
+
+		if etag := w.Header().Get("ETag"); !strings.HasPrefix(etag, `"`) {
+			t.Errorf("expected a strong ETag without compression, got %q", etag)
+		}
+	})
+
+	t.Run("sends 304 when If-None-Match matches", func(t *testing.T) {
+		responder := JSONResponder(WithETag(false))
+
+		w := httptest.NewRecorder()
+		responder.Send200(w, "data")
+
+		etag := w.Header().Get("ETag")
+
+		for _, inm := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("If-None-Match", inm)
+
+			w = httptest.NewRecorder()
+			responder.WithRequest(req).Send200(w, "data")
+
+			if w.Code != http.StatusNotModified {
+				t.Errorf("expected status %d for If-None-Match %q, got %d", http.StatusNotModified, inm, w.Code)
+			}
+
+			if w.Body.Len() != 0 {
+				t.Errorf("expected an empty body, got %q", w.Body.String())
+			}
+
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("expected ETag %q, got %q", etag, w.Header().Get("ETag"))
+			}
+		}
+	})
+
+	t.Run("sends 200 when If-None-Match does not match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", `"stale"`)
+
+		w := httptest.NewRecorder()
+		JSONResponder(WithETag(false)).WithRequest(req).Send200(w, "data")
+
+		if w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("expected a 200 with a body, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("ignores unsafe methods", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("If-None-Match", "*")
+
+		w := httptest.NewRecorder()
+		JSONResponder(WithETag(false)).WithRequest(req).Send200(w, "data")
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	})
+}
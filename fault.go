@@ -0,0 +1,93 @@
+package responder
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// ErrInjectedFault is the error of the server errors forced by WithFaultInjection.
+var ErrInjectedFault = errors.New("responder: injected fault")
+
+// FaultPolicy describes the faults injected in the responses, each rate
+// being the fraction of the responses affected by the fault, from 0 to 1.
+type FaultPolicy struct {
+	// DelayRate is the fraction of the responses delayed.
+	DelayRate float64
+	// MaxDelay is the maximum delay, the actual delay being random.
+	MaxDelay time.Duration
+	// TruncateRate is the fraction of the responses whose body is cut in half,
+	// the Content-Length still announcing the whole body.
+	TruncateRate float64
+	// ErrorRate is the fraction of the responses that are not errors
+	// replaced with a server error.
+	ErrorRate float64
+	// ErrorStatus is the status code of the server errors, 503 by default.
+	ErrorStatus int
+}
+
+// WithFaultInjection injects faults in the responses as described by the policy,
+// letting teams test the resilience of the clients against the responses
+// this package produces. It is meant for non-production environments only.
+// The faults only apply to the buffered responses, not to the streamed ones,
+// and the injected faults are logged if a logger was provided.
+func WithFaultInjection(p FaultPolicy) OptionsModifier {
+	return func(o *options) {
+		o.fault = &p
+	}
+}
+
+// roll reports whether a response is affected by a fault of the given rate.
+func roll(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate
+}
+
+// injectError replaces the response with a server error when the policy decides so.
+// It reports whether the error was sent.
+func (r *responder) injectError(rw responseWriter, code int) (bool, error) {
+	p := r.options.fault
+	if p == nil || code >= status400 || !roll(p.ErrorRate) {
+		return false, nil
+	}
+
+	status := p.ErrorStatus
+	if !Is5xx(status) {
+		status = status503
+	}
+
+	return true, r.sendLeveledError(rw, status, ErrInjectedFault, http.StatusText(status), nil)
+}
+
+// injectDelay delays the response when the policy decides so.
+func (r *responder) injectDelay(code int) {
+	p := r.options.fault
+	if p == nil || p.MaxDelay <= 0 || !roll(p.DelayRate) {
+		return
+	}
+
+	d := rand.N(p.MaxDelay)
+	r.logFault("delay", code, "delay", d)
+
+	time.Sleep(d)
+}
+
+// injectTruncation cuts the body in half when the policy decides so.
+func (r *responder) injectTruncation(code int, body []byte) []byte {
+	p := r.options.fault
+	if p == nil || len(body) == 0 || !roll(p.TruncateRate) {
+		return body
+	}
+
+	r.logFault("truncation", code, "size", len(body))
+
+	return body[:len(body)/2]
+}
+
+func (r *responder) logFault(fault string, code int, attrs ...any) {
+	if r.options.logger == nil {
+		return
+	}
+
+	r.options.logger.Warn("injected fault", append([]any{"fault", fault, "status", code}, attrs...)...)
+}
@@ -0,0 +1,86 @@
+package responder
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithFaultInjection(t *testing.T) {
+	t.Run("forces server errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithFaultInjection(FaultPolicy{ErrorRate: 1})).Send200(w, "ok")
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+		}
+
+		w = httptest.NewRecorder()
+
+		JSONResponder(WithFaultInjection(FaultPolicy{ErrorRate: 1, ErrorStatus: http.StatusBadGateway})).Send404(w, nil, "missing")
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected the error responses to be kept, got %d", w.Code)
+		}
+	})
+
+	t.Run("uses the given error status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithFaultInjection(FaultPolicy{ErrorRate: 1, ErrorStatus: http.StatusBadGateway})).Send201(w, "created")
+
+		if w.Code != http.StatusBadGateway || w.Body.String() != http.StatusText(http.StatusBadGateway) {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("truncates the bodies", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		w := httptest.NewRecorder()
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+		TextResponder(WithLogger(logger), WithFaultInjection(FaultPolicy{TruncateRate: 1})).Send200(w, "0123456789")
+
+		if w.Body.String() != "01234" || w.Header().Get("Content-Length") != "10" {
+			t.Errorf("expected a truncated body, got %q with Content-Length %q", w.Body.String(), w.Header().Get("Content-Length"))
+		}
+
+		if !strings.Contains(logs.String(), "fault=truncation") {
+			t.Errorf("expected the fault to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("delays the responses", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		start := time.Now()
+
+		TextResponder(WithLogger(logger), WithFaultInjection(FaultPolicy{DelayRate: 1, MaxDelay: 50 * time.Millisecond})).
+			Send200(httptest.NewRecorder(), "ok")
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the delay to be bounded, took %v", elapsed)
+		}
+
+		if !strings.Contains(logs.String(), "fault=delay") {
+			t.Errorf("expected the fault to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("leaves the responses untouched with zero rates", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithFaultInjection(FaultPolicy{MaxDelay: time.Hour})).Send200(w, "ok")
+
+		if w.Code != http.StatusOK || w.Body.String() != "ok" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+}
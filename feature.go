@@ -0,0 +1,51 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFeatureDisabled is the internal error of responses created for disabled features.
+var ErrFeatureDisabled = errors.New("feature disabled")
+
+// Feature describes a feature guarded by a feature flag.
+// It is used as the client message of the responses created by Disabled,
+// so that custom error formatters can render it as they see fit.
+type Feature struct {
+	// Name is the name of the feature flag.
+	Name string
+	// DocURL is an optional link to the documentation of the feature.
+	DocURL string
+	// Hidden makes disabled features respond 404 Not Found instead of
+	// 501 Not Implemented, in order not to disclose their existence.
+	Hidden bool
+}
+
+// String returns the client message naming the flag and linking to its documentation.
+func (f Feature) String() string {
+	if f.DocURL == "" {
+		return fmt.Sprintf("feature %q is disabled", f.Name)
+	}
+
+	return fmt.Sprintf("feature %q is disabled, see %s", f.Name, f.DocURL)
+}
+
+// Disabled creates the error Response to send when the feature is turned off.
+func (f Feature) Disabled() Response {
+	status := status501
+	if f.Hidden {
+		status = status404
+	}
+
+	return ErrorResponse{
+		status:  status,
+		err:     fmt.Errorf("%w: %s", ErrFeatureDisabled, f.Name),
+		message: f,
+	}
+}
+
+// FeatureDisabled creates a 501 Not Implemented Response for the disabled feature.
+// Use Feature to link to the documentation or to respond 404 Not Found instead.
+func FeatureDisabled(feature string) Response {
+	return Feature{Name: feature}.Disabled()
+}
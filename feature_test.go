@@ -0,0 +1,64 @@
+package responder
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFeatureDisabled(t *testing.T) {
+	t.Run("responds 501 naming the flag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, FeatureDisabled("exports"))
+
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected status %d, got %d", http.StatusNotImplemented, w.Code)
+		}
+
+		expected := `feature "exports" is disabled`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("links to the documentation", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		feature := Feature{Name: "exports", DocURL: "https://example.com/docs/exports"}
+
+		JSONResponder().Send(w, feature.Disabled())
+
+		var result jsonError
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		expected := `feature "exports" is disabled, see https://example.com/docs/exports`
+		if result.Error != expected {
+			t.Errorf("expected error %q, got %q", expected, result.Error)
+		}
+	})
+
+	t.Run("hidden features respond 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Feature{Name: "beta", Hidden: true}.Disabled())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("wraps ErrFeatureDisabled", func(t *testing.T) {
+		resp, ok := FeatureDisabled("exports").(ErrorResponse)
+		if !ok {
+			t.Fatal("expected an ErrorResponse")
+		}
+
+		if !errors.Is(resp.err, ErrFeatureDisabled) {
+			t.Errorf("expected the error to wrap ErrFeatureDisabled, got %v", resp.err)
+		}
+	})
+}
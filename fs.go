@@ -0,0 +1,92 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// FSResponder returns a handler serving the files of the file system, e.g. an
+// embed.FS of static assets, with an HTML responder created with the options.
+// The directories are served through their index.html file, without listings.
+// Unlike http.FileServer, the errors are sent and logged by the responder,
+// the 404 Not Found bodies being formatted by its error formatter, and the files
+// carry the headers of the responder, e.g. WithCacheControl and WithFileETag.
+// The range and conditional requests are handled by http.ServeContent.
+func FSResponder(fsys fs.FS, options ...OptionsModifier) http.Handler {
+	r := New(HTMLContentType, options...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rr, _ := r.WithRequest(req).(*responder)
+		_ = rr.serveFS(w, req, fsys)
+	})
+}
+
+// serveFS serves the file of the file system the request targets.
+func (r *responder) serveFS(rw responseWriter, req *http.Request, fsys fs.FS) error {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+
+		return r.sendError(rw, status405, nil, http.StatusText(status405))
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+req.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	f, info, err := openFile(fsys, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return r.sendError(rw, status404, err, http.StatusText(status404))
+		case errors.Is(err, fs.ErrPermission):
+			return r.sendError(rw, status403, err, http.StatusText(status403))
+		default:
+			return r.sendError(rw, status500, err, http.StatusText(status500))
+		}
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		b, err := io.ReadAll(f)
+		if err != nil {
+			return r.sendError(rw, status500, err, http.StatusText(status500))
+		}
+
+		content = bytes.NewReader(b)
+	}
+
+	return r.serveContent(rw, req, info.Name(), content, info.ModTime())
+}
+
+// openFile opens the file with the name, or the index.html file of the directory.
+func openFile(fsys fs.FS, name string) (fs.File, fs.FileInfo, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+
+		return nil, nil, err
+	}
+
+	if !info.IsDir() {
+		return f, info, nil
+	}
+
+	_ = f.Close()
+
+	return openFile(fsys, path.Join(name, "index.html"))
+}
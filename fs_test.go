@@ -0,0 +1,94 @@
+package responder
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFSResponder(t *testing.T) {
+	modtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"index.html":      {Data: []byte("<h1>home</h1>"), ModTime: modtime},
+		"css/site.css":    {Data: []byte("body{}"), ModTime: modtime},
+		"docs/index.html": {Data: []byte("<h1>docs</h1>"), ModTime: modtime},
+		"empty/.keep":     {Data: []byte{}},
+	}
+
+	serve := func(h http.Handler, method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+
+		return w
+	}
+
+	t.Run("serves the files", func(t *testing.T) {
+		w := serve(FSResponder(fsys), http.MethodGet, "/css/site.css")
+
+		if w.Code != http.StatusOK || w.Body.String() != "body{}" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != "text/css; charset=utf-8" {
+			t.Errorf("unexpected Content-Type %q", ct)
+		}
+
+		if cd := w.Header().Get("Content-Disposition"); cd != "" {
+			t.Errorf("expected no Content-Disposition, got %q", cd)
+		}
+	})
+
+	t.Run("serves the index of the directories", func(t *testing.T) {
+		for target, body := range map[string]string{"/": "<h1>home</h1>", "/docs/": "<h1>docs</h1>", "/docs": "<h1>docs</h1>"} {
+			if w := serve(FSResponder(fsys), http.MethodGet, target); w.Body.String() != body {
+				t.Errorf("expected body %q for %s, got %q", body, target, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("sends the errors with the responder", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		h := FSResponder(fsys,
+			WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			WithErrorFormatter(func(message any) any { return "<p>" + message.(string) + "</p>" }),
+		)
+
+		for _, target := range []string{"/missing.js", "/empty/", "/../index.html/x"} {
+			w := serve(h, http.MethodGet, target)
+
+			if w.Code != http.StatusNotFound || w.Body.String() != "<p>Not Found</p>" {
+				t.Errorf("unexpected response %d %q for %s", w.Code, w.Body.String(), target)
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != HTMLContentType {
+				t.Errorf("unexpected Content-Type %q", ct)
+			}
+		}
+
+		if !strings.Contains(logs.String(), "status=404") {
+			t.Errorf("expected the errors to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("rejects the unsafe methods", func(t *testing.T) {
+		w := serve(FSResponder(fsys), http.MethodPost, "/index.html")
+
+		if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, HEAD" {
+			t.Errorf("unexpected response %d with Allow %q", w.Code, w.Header().Get("Allow"))
+		}
+	})
+
+	t.Run("applies the cache policy", func(t *testing.T) {
+		w := serve(FSResponder(fsys, WithCacheControl(CacheControl{Public: true, MaxAge: time.Hour})), http.MethodGet, "/css/site.css")
+
+		if cc := w.Header().Get("Cache-Control"); cc != "public, max-age=3600" {
+			t.Errorf("unexpected Cache-Control %q", cc)
+		}
+	})
+}
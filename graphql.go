@@ -0,0 +1,110 @@
+package responder
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// GraphQLResponse is the body of the GraphQL responses.
+type GraphQLResponse struct {
+	// Data is the result of the execution, omitted when nil.
+	Data any `json:"data,omitempty"`
+	// Errors are the errors raised during the request, omitted when empty.
+	Errors []GraphQLError `json:"errors,omitempty"`
+	// Extensions are the implementation-specific entries of the response.
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLError is an error of a GraphQL response.
+type GraphQLError struct {
+	// Message is the description of the error intended to the developers.
+	Message string `json:"message"`
+	// Path is the path of the response field which experienced the error,
+	// made of field names and list indices.
+	Path []any `json:"path,omitempty"`
+	// Extensions are the additional entries of the error, e.g. a code.
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLResponder creates a new JSON responder sending the GraphQL response shape.
+// The data are sent as the data entry of the responses, unless they already are
+// a GraphQLResponse, and the errors are shaped by GraphQLErrorShape.
+// The raw JSON data must be a json.RawMessage rather than a string or a byte slice.
+func GraphQLResponder(options ...OptionsModifier) Responder {
+	o := []OptionsModifier{graphQLDefaults}
+	o = append(o, options...)
+
+	return New(JSONContentType, o...)
+}
+
+// graphQLDefaults sets the formatters of the GraphQL responders.
+func graphQLDefaults(o *options) {
+	o.jsonErrorShape = GraphQLErrorShape
+	o.encoder = formatterEncoder{contentType: JSONContentType, format: o.formatGraphQL}
+	o.directEncoder = nil
+}
+
+// formatGraphQL is the data formatter of the GraphQL responders.
+func (o *options) formatGraphQL(c any) ([]byte, error) {
+	if _, ok := c.(GraphQLResponse); !ok {
+		c = GraphQLResponse{Data: c}
+	}
+
+	return o.formatJSON(c)
+}
+
+// GraphQLErrorShape is a JSONErrorShape sending the error responses as GraphQL
+// responses with errors, e.g. { "errors": [{ "message": "...", "extensions": { "code": "NOT_FOUND" } }] },
+// the code being derived from the status code. The GraphQLError messages are sent
+// as is, and the validation messages as an error per field, with a field extension.
+func GraphQLErrorShape(status int, _ error, message any) any {
+	code := strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+
+	var detail *debugDetail
+	if m, ok := message.(DebugMessage); ok {
+		detail = &debugDetail{Causes: m.Causes, Stack: m.Stack}
+		message = m.Message
+	}
+
+	var errs []GraphQLError
+
+	switch m := message.(type) {
+	case GraphQLError:
+		errs = []GraphQLError{m}
+	case []GraphQLError:
+		errs = slices.Clone(m)
+	case ValidationMessage:
+		for _, f := range m.Fields {
+			errs = append(errs, GraphQLError{
+				Message:    f.Message,
+				Extensions: map[string]any{"code": code, "field": f.Field},
+			})
+		}
+	}
+
+	if len(errs) == 0 {
+		errs = []GraphQLError{{
+			Message:    internal.MessageToString(message),
+			Extensions: map[string]any{"code": code},
+		}}
+	}
+
+	if detail != nil {
+		errs[0].Extensions = withExtension(errs[0].Extensions, "detail", detail)
+	}
+
+	return GraphQLResponse{Errors: errs}
+}
+
+// withExtension returns a copy of the extensions with the additional entry.
+func withExtension(extensions map[string]any, key string, value any) map[string]any {
+	c := make(map[string]any, len(extensions)+1)
+	maps.Copy(c, extensions)
+	c[key] = value
+
+	return c
+}
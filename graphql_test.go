@@ -0,0 +1,79 @@
+package responder
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGraphQLResponder(t *testing.T) {
+	t.Run("sends the data entry", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		GraphQLResponder().Send200(w, map[string]any{"user": map[string]string{"name": "Jane"}})
+
+		if b := w.Body.String(); b != `{"data":{"user":{"name":"Jane"}}}` {
+			t.Errorf("unexpected body %s", b)
+		}
+	})
+
+	t.Run("sends the responses as is", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		GraphQLResponder().Send200(w, GraphQLResponse{
+			Data:   json.RawMessage(`{"user":null}`),
+			Errors: []GraphQLError{{Message: "user not found", Path: []any{"user"}}},
+		})
+
+		want := `{"data":{"user":null},"errors":[{"message":"user not found","path":["user"]}]}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+
+	t.Run("sends the errors entry", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		GraphQLResponder().Send400(w, errors.New("parse error"), "Syntax Error: Unexpected Name")
+
+		want := `{"errors":[{"message":"Syntax Error: Unexpected Name","extensions":{"code":"BAD_REQUEST"}}]}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+
+	t.Run("sends the GraphQL errors as is", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs := []GraphQLError{{Message: "a", Path: []any{"users", 1, "email"}}, {Message: "b"}}
+
+		GraphQLResponder().Send500(w, nil, errs)
+
+		want := `{"errors":[{"message":"a","path":["users",1,"email"]},{"message":"b"}]}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+
+	t.Run("sends an error per invalid field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		GraphQLResponder().SendValidation(w, []FieldError{{Field: "email", Message: "is required"}})
+
+		want := `{"errors":[{"message":"is required","extensions":{"code":"UNPROCESSABLE_ENTITY","field":"email"}}]}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+
+	t.Run("shapes the errors of JSON responders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONErrorShape(GraphQLErrorShape)).Send404(w, nil, "not found")
+
+		want := `{"errors":[{"message":"not found","extensions":{"code":"NOT_FOUND"}}]}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+}
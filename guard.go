@@ -0,0 +1,98 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrAlreadyWritten is returned by the checked responders when
+// the response is already written to the guarded writer, see Guard.
+var ErrAlreadyWritten = errors.New("responder: response already written")
+
+// GuardedWriter is a response writer keeping track of the response being written.
+type GuardedWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// Guard wraps the response writer so that the responders detect the responses
+// already written, whether by a previous send or by the handler itself, and
+// suppress the superfluous ones, logging them, instead of triggering the
+// "superfluous response.WriteHeader" warnings of net/http. The superfluous
+// WriteHeader calls of the handlers are dropped as well.
+// The writers already guarded are returned as is.
+func Guard(w http.ResponseWriter) *GuardedWriter {
+	if g, ok := w.(*GuardedWriter); ok {
+		return g
+	}
+
+	return &GuardedWriter{ResponseWriter: w}
+}
+
+// GuardWrites is a middleware guarding the response writers of the handlers, see Guard.
+func GuardWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(Guard(w), req)
+	})
+}
+
+// WriteHeader sends the header of the response, unless it is already sent.
+// The informational headers can be sent several times before the response.
+func (w *GuardedWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+
+	if code < status200 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the body of the response, sending a 200 OK header first when no header is sent.
+func (w *GuardedWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = status200
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Written reports whether the response is written.
+func (w *GuardedWriter) Written() bool {
+	return w.status != 0
+}
+
+// Status returns the status code of the response, 0 when it is not written.
+func (w *GuardedWriter) Status() int {
+	return w.status
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (w *GuardedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Written reports whether the response is already written to the writer,
+// which is only known when it is guarded, see Guard, or when it or one of
+// the writers it wraps implements a Written() bool method.
+func Written(w http.ResponseWriter) bool {
+	for w != nil {
+		if t, ok := w.(interface{ Written() bool }); ok {
+			return t.Written()
+		}
+
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return false
+		}
+
+		w = u.Unwrap()
+	}
+
+	return false
+}
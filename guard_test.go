@@ -0,0 +1,115 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGuard(t *testing.T) {
+	t.Run("suppresses the second response", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		rec := httptest.NewRecorder()
+		w := Guard(rec)
+		responder := JSONResponder(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+		responder.Send201(w, map[string]int{"id": 1})
+		responder.Send500(w, errors.New("late"), "Internal Server Error")
+
+		if rec.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d", rec.Code)
+		}
+
+		if b := rec.Body.String(); b != `{"id":1}` {
+			t.Errorf("unexpected body %q", b)
+		}
+
+		if !strings.Contains(logs.String(), "superfluous response suppressed") {
+			t.Errorf("expected the superfluous response to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("reports the response written by the handler", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w := Guard(rec)
+
+		w.WriteHeader(http.StatusAccepted)
+
+		err := Checked(JSONResponder()).Send200(w, "ok")
+		if !errors.Is(err, ErrAlreadyWritten) {
+			t.Errorf("expected ErrAlreadyWritten, got %v", err)
+		}
+
+		if rec.Code != http.StatusAccepted || rec.Body.Len() != 0 {
+			t.Errorf("expected the response to be untouched, got %d %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("drops the superfluous headers", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		w := Guard(rec)
+
+		w.WriteHeader(http.StatusNotFound)
+		w.WriteHeader(http.StatusOK)
+
+		if w.Status() != http.StatusNotFound || rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("tracks the implicit header of the writes", func(t *testing.T) {
+		w := Guard(httptest.NewRecorder())
+
+		if Written(w) {
+			t.Error("expected the response not to be written")
+		}
+
+		_, _ = w.Write([]byte("ok"))
+
+		if !Written(w) || w.Status() != http.StatusOK {
+			t.Errorf("expected the response to be written with status 200, got %d", w.Status())
+		}
+	})
+
+	t.Run("returns the guarded writers as is", func(t *testing.T) {
+		w := Guard(httptest.NewRecorder())
+
+		if Guard(w) != w {
+			t.Error("expected the guarded writer to be returned")
+		}
+	})
+
+	t.Run("finds the guarded writer behind the wrappers", func(t *testing.T) {
+		w := Guard(httptest.NewRecorder())
+		w.WriteHeader(http.StatusOK)
+
+		if !Written(deadlineResponseWriter{ResponseWriter: w}) {
+			t.Error("expected the wrapped writer to be reported as written")
+		}
+
+		if Written(httptest.NewRecorder()) {
+			t.Error("expected the unguarded writer not to be reported as written")
+		}
+	})
+}
+
+func TestGuardWrites(t *testing.T) {
+	responder := TextResponder()
+
+	h := GuardWrites(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		responder.Send200(w, "first")
+		responder.Send200(w, "second")
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if b := w.Body.String(); b != "first" {
+		t.Errorf("unexpected body %q", b)
+	}
+}
@@ -0,0 +1,189 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// HALContentType is the content type for HAL responses.
+const HALContentType = "application/hal+json"
+
+// errHALObject is returned when the data of a HAL resource is not a JSON object.
+var errHALObject = errors.New("responder: HAL resource data must encode as a JSON object")
+
+// HALResponder creates a new responder sending the data as HAL documents.
+// The Resource values carry the _links and _embedded properties of the document,
+// the other values being sent as by the JSON responders.
+func HALResponder(options ...OptionsModifier) Responder {
+	return New(HALContentType, options...)
+}
+
+// Link is a HAL link object.
+type Link struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Resource is a HAL resource, the properties of its data being sent
+// alongside its links and embedded resources.
+// Its methods return copies, leaving the original untouched so that
+// resources can be safely reused.
+type Resource struct {
+	data     any
+	links    []halRelation
+	embedded []halRelation
+}
+
+// halRelation is a relation of a resource, kept in insertion order.
+type halRelation struct {
+	rel   string
+	value any
+}
+
+// NewResource creates a HAL resource holding the data, which must encode as
+// a JSON object, e.g. a struct or a map, or be nil.
+func NewResource(data any) Resource {
+	return Resource{data: data}
+}
+
+// WithLink returns a copy of the resource linking to the href with the relation.
+// Calling it several times with the same relation sends an array of links.
+func (r Resource) WithLink(rel string, link Link) Resource {
+	r.links = append(slices.Clip(r.links), halRelation{rel: rel, value: link})
+
+	return r
+}
+
+// WithSelf returns a copy of the resource with the self link.
+func (r Resource) WithSelf(href string) Resource {
+	return r.WithLink("self", Link{Href: href})
+}
+
+// WithNext returns a copy of the resource with the next link.
+func (r Resource) WithNext(href string) Resource {
+	return r.WithLink("next", Link{Href: href})
+}
+
+// WithPrev returns a copy of the resource with the prev link.
+func (r Resource) WithPrev(href string) Resource {
+	return r.WithLink("prev", Link{Href: href})
+}
+
+// WithEmbedded returns a copy of the resource embedding the resources with the relation.
+// A single resource is sent as an object and several as an array. Calling it
+// several times with the same relation adds the resources to the array.
+func (r Resource) WithEmbedded(rel string, resources ...Resource) Resource {
+	for _, e := range resources {
+		r.embedded = append(slices.Clip(r.embedded), halRelation{rel: rel, value: e})
+	}
+
+	return r
+}
+
+// MarshalJSON encodes the resource as a HAL document.
+func (r Resource) MarshalJSON() ([]byte, error) {
+	body := []byte("{}")
+
+	if r.data != nil {
+		b, err := json.Marshal(r.data)
+		if err != nil {
+			return nil, err
+		}
+
+		if b = bytes.TrimSpace(b); len(b) < 2 || b[0] != '{' {
+			return nil, errHALObject
+		}
+
+		body = b
+	}
+
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for _, p := range []struct {
+		name      string
+		relations []halRelation
+	}{
+		{name: "_links", relations: r.links},
+		{name: "_embedded", relations: r.embedded},
+	} {
+		if len(p.relations) == 0 {
+			continue
+		}
+
+		v, err := encodeRelations(p.relations)
+		if err != nil {
+			return nil, err
+		}
+
+		b.WriteString(`"` + p.name + `":`)
+		b.Write(v)
+		b.WriteByte(',')
+	}
+
+	if inner := bytes.TrimSpace(body[1 : len(body)-1]); len(inner) > 0 {
+		b.Write(inner)
+	} else if b.Len() > 1 {
+		b.Truncate(b.Len() - 1)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// encodeRelations encodes the relations as an object, the relations
+// appearing several times being sent as arrays.
+func encodeRelations(relations []halRelation) ([]byte, error) {
+	var (
+		order  []string
+		values = make(map[string][]any)
+	)
+
+	for _, r := range relations {
+		if _, ok := values[r.rel]; !ok {
+			order = append(order, r.rel)
+		}
+
+		values[r.rel] = append(values[r.rel], r.value)
+	}
+
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for i, rel := range order {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		k, err := json.Marshal(rel)
+		if err != nil {
+			return nil, err
+		}
+
+		var v any = values[rel]
+		if len(values[rel]) == 1 {
+			v = values[rel][0]
+		}
+
+		e, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(e)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
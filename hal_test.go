@@ -0,0 +1,86 @@
+package responder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHALResponder(t *testing.T) {
+	type order struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+	}
+
+	t.Run("sends the links and embedded resources with the data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		resource := NewResource(map[string]int{"total": 2}).
+			WithSelf("/orders?page=2").
+			WithNext("/orders?page=3").
+			WithPrev("/orders?page=1").
+			WithEmbedded("orders",
+				NewResource(order{ID: 1, Status: "shipped"}).WithSelf("/orders/1"),
+				NewResource(order{ID: 2, Status: "pending"}).WithSelf("/orders/2"),
+			)
+
+		HALResponder().Send200(w, resource)
+
+		want := `{"_links":{"self":{"href":"/orders?page=2"},"next":{"href":"/orders?page=3"},` +
+			`"prev":{"href":"/orders?page=1"}},"_embedded":{"orders":[` +
+			`{"_links":{"self":{"href":"/orders/1"}},"id":1,"status":"shipped"},` +
+			`{"_links":{"self":{"href":"/orders/2"}},"id":2,"status":"pending"}]},"total":2}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != HALContentType {
+			t.Errorf("expected Content-Type %q, got %q", HALContentType, ct)
+		}
+	})
+
+	t.Run("sends the links of a relation as an array", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		resource := NewResource(nil).
+			WithLink("item", Link{Href: "/a"}).
+			WithLink("item", Link{Href: "/b{?q}", Templated: true})
+
+		HALResponder().Send200(w, resource)
+
+		want := `{"_links":{"item":[{"href":"/a"},{"href":"/b{?q}","templated":true}]}}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+
+	t.Run("sends an empty object for empty resources", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HALResponder().Send200(w, NewResource(struct{}{}))
+
+		if b := w.Body.String(); b != "{}" {
+			t.Errorf("expected body {}, got %s", b)
+		}
+	})
+
+	t.Run("leaves the original resource untouched", func(t *testing.T) {
+		base := NewResource(nil).WithSelf("/a")
+		_ = base.WithNext("/b")
+
+		w := httptest.NewRecorder()
+
+		HALResponder().Send200(w, base)
+
+		if b := w.Body.String(); b != `{"_links":{"self":{"href":"/a"}}}` {
+			t.Errorf("unexpected body %s", b)
+		}
+	})
+
+	t.Run("reports the data that are not objects", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(HALResponder()).Send200(w, NewResource([]int{1}).WithSelf("/"))
+		if !errors.Is(err, ErrInvalidContent) || !errors.Is(err, errHALObject) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+)
+
+// HandlerFunc adapts a handler returning the Response to send into an http.Handler.
+// The responder is bound to the request before sending the response.
+// A panic in the handler is recovered and sent as the PanicResponse of the
+// recovered value, except http.ErrAbortHandler which is propagated so that
+// the server aborts the response.
+func (r *responder) HandlerFunc(f func(*http.Request) Response) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		bound := r.WithRequest(req)
+
+		defer func() {
+			if v := recover(); v != nil {
+				if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(v)
+				}
+
+				bound.Send(w, recoveredResponse(v, debug.Stack()))
+			}
+		}()
+
+		bound.Send(w, f(req))
+	})
+}
@@ -0,0 +1,73 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandlerFunc(t *testing.T) {
+	t.Run("sends the returned response", func(t *testing.T) {
+		h := TextResponder().HandlerFunc(func(*http.Request) Response {
+			return Success(http.StatusCreated, "created").WithHeader("Location", "/users/1")
+		})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+		if w.Code != http.StatusCreated || w.Body.String() != "created" {
+			t.Errorf("expected a 201 with the body, got %d %q", w.Code, w.Body.String())
+		}
+
+		if loc := w.Header().Get("Location"); loc != "/users/1" {
+			t.Errorf("expected Location %q, got %q", "/users/1", loc)
+		}
+	})
+
+	t.Run("binds the responder to the request", func(t *testing.T) {
+		h := TextResponder(WithETag(false)).HandlerFunc(func(*http.Request) Response {
+			return Success(http.StatusOK, "data")
+		})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotModified {
+			t.Errorf("expected status %d, got %d", http.StatusNotModified, w.Code)
+		}
+	})
+
+	t.Run("recovers panics", func(t *testing.T) {
+		h := TextResponder().HandlerFunc(func(*http.Request) Response {
+			panic(statusError{status: http.StatusConflict, msg: "already exists"})
+		})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+
+		if w.Code != http.StatusConflict || w.Body.String() != "already exists" {
+			t.Errorf("expected a 409 with the error text, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("propagates aborted handlers", func(t *testing.T) {
+		h := TextResponder().HandlerFunc(func(*http.Request) Response {
+			panic(http.ErrAbortHandler)
+		})
+
+		defer func() {
+			if v := recover(); !errors.Is(v.(error), http.ErrAbortHandler) {
+				t.Errorf("expected http.ErrAbortHandler to be propagated, got %v", v)
+			}
+		}()
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -0,0 +1,46 @@
+package responder
+
+import "net/http"
+
+// BeforeSendHook is called before the header of a response is written, with its
+// status code, its header and the body about to be written, once encrypted and
+// compressed. The header may still be modified, the body must not.
+type BeforeSendHook func(status int, header http.Header, body []byte)
+
+// AfterSendHook is called once a response is sent, with its status code,
+// the number of bytes of the body written and the write error, if any.
+type AfterSendHook func(status, bytesWritten int, err error)
+
+// WithBeforeSend adds a hook called before each response is sent, e.g. to audit
+// or capture the responses. The hooks are called in the order they were added.
+// They are not called for the redirects, the streams and the bodies encoded
+// directly, see WithDirectEncoding.
+func WithBeforeSend(f BeforeSendHook) OptionsModifier {
+	return func(o *options) {
+		o.beforeSend = append(o.beforeSend, f)
+	}
+}
+
+// WithAfterSend adds a hook called after each response is sent, e.g. to collect
+// metrics. The hooks are called in the order they were added, for all the
+// responses, the redirects, the files, the streams and the bodies encoded
+// directly included.
+func WithAfterSend(f AfterSendHook) OptionsModifier {
+	return func(o *options) {
+		o.afterSend = append(o.afterSend, f)
+	}
+}
+
+// beforeSend calls the before-send hooks.
+func (r *responder) beforeSend(rw responseWriter, code int, body []byte) {
+	for _, f := range r.options.beforeSend {
+		f(code, rw.Header(), body)
+	}
+}
+
+// afterSend calls the after-send hooks.
+func (r *responder) afterSend(code, n int, err error) {
+	for _, f := range r.options.afterSend {
+		f(code, n, err)
+	}
+}
@@ -0,0 +1,118 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithBeforeSend(t *testing.T) {
+	t.Run("receives the status, the header and the body", func(t *testing.T) {
+		var (
+			status int
+			ct     string
+			body   string
+		)
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithBeforeSend(func(code int, h http.Header, b []byte) {
+			status, ct, body = code, h.Get("Content-Type"), string(b)
+			h.Set("X-Audit", "1")
+		})).Send201(w, map[string]int{"id": 1})
+
+		if status != http.StatusCreated || ct != JSONContentType || body != `{"id":1}` {
+			t.Errorf("unexpected hook arguments %d %q %q", status, ct, body)
+		}
+
+		if w.Header().Get("X-Audit") != "1" {
+			t.Error("expected the hook to be able to modify the header")
+		}
+	})
+
+	t.Run("calls the hooks in order", func(t *testing.T) {
+		var calls []int
+
+		TextResponder(
+			WithBeforeSend(func(int, http.Header, []byte) { calls = append(calls, 1) }),
+			WithBeforeSend(func(int, http.Header, []byte) { calls = append(calls, 2) }),
+		).Send404(httptest.NewRecorder(), nil, "not found")
+
+		if len(calls) != 2 || calls[0] != 1 || calls[1] != 2 {
+			t.Errorf("unexpected calls %v", calls)
+		}
+	})
+}
+
+func TestWithAfterSend(t *testing.T) {
+	t.Run("receives the number of bytes written", func(t *testing.T) {
+		var (
+			status int
+			n      int
+			err    error
+		)
+
+		TextResponder(WithAfterSend(func(code, written int, werr error) {
+			status, n, err = code, written, werr
+		})).Send200(httptest.NewRecorder(), "hello")
+
+		if status != http.StatusOK || n != 5 || err != nil {
+			t.Errorf("unexpected hook arguments %d %d %v", status, n, err)
+		}
+	})
+
+	t.Run("receives the write error", func(t *testing.T) {
+		var err error
+
+		TextResponder(WithAfterSend(func(_, _ int, werr error) { err = werr })).
+			Send200(&failingWriter{ResponseRecorder: httptest.NewRecorder()}, "hello")
+
+		if err == nil {
+			t.Error("expected the write error")
+		}
+	})
+
+	t.Run("is called for all the responses", func(t *testing.T) {
+		var statuses []int
+
+		responder := JSONResponder(WithDirectEncoding(true), WithAfterSend(func(code, _ int, _ error) {
+			statuses = append(statuses, code)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		responder.Redirect302(httptest.NewRecorder(), req, "/home")
+		responder.SendFile(httptest.NewRecorder(), req, "hello.txt", strings.NewReader("hello"), time.Time{})
+		responder.Send201(httptest.NewRecorder(), strings.NewReader("created"))
+		responder.SendSeq(httptest.NewRecorder(), slices.Values([]any{1}))
+		responder.Send202(httptest.NewRecorder(), []int{1})
+
+		expected := []int{http.StatusFound, http.StatusOK, http.StatusCreated, http.StatusOK, http.StatusAccepted}
+		if !slices.Equal(statuses, expected) {
+			t.Errorf("expected the hooks to be called with %v, got %v", expected, statuses)
+		}
+	})
+
+	t.Run("receives the direct encoding failures", func(t *testing.T) {
+		var err error
+
+		XMLResponder(WithDirectEncoding(true), WithAfterSend(func(_, _ int, werr error) { err = werr })).
+			Send200(httptest.NewRecorder(), []any{directItem{Name: "a"}, func() {}})
+
+		if err == nil {
+			t.Error("expected the encoding error")
+		}
+	})
+
+	t.Run("is called for bodiless responses", func(t *testing.T) {
+		status := 0
+
+		TextResponder(WithAfterSend(func(code, _ int, _ error) { status = code })).Send204(httptest.NewRecorder())
+
+		if status != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, status)
+		}
+	})
+}
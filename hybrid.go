@@ -0,0 +1,77 @@
+package responder
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// acceptCacheSize is the number of distinct Accept headers
+// a Hybrid keeps parsed.
+const acceptCacheSize = 256
+
+// Hybrid selects a responder per request in applications mixing server-rendered
+// pages and API endpoints on the same routes. Requests issued by scripts
+// (XMLHttpRequest, fetch) get the API responder, typically a JSONResponder,
+// whereas navigations get the page responder, typically an HTMLResponder.
+// Other clients get the API responder when their Accept header prefers JSON to HTML.
+type Hybrid struct {
+	api    Responder
+	page   Responder
+	accept *internal.AcceptCache
+}
+
+// NewHybrid creates a new Hybrid selecting between the given responders.
+func NewHybrid(api, page Responder) *Hybrid {
+	return &Hybrid{
+		api:    api,
+		page:   page,
+		accept: internal.NewAcceptCache(acceptCacheSize),
+	}
+}
+
+// For returns the API responder when the request was issued by a script
+// or prefers JSON, and the page responder otherwise. The request headers
+// the selection depends on are added to the Vary header of the response,
+// so that caches do not serve a page to a script or the other way around.
+func (h *Hybrid) For(w http.ResponseWriter, r *http.Request) Responder {
+	addVary(w.Header(), "Accept", "X-Requested-With", "Sec-Fetch-Mode", "Sec-Fetch-Dest")
+
+	if IsScriptRequest(r) || h.prefersJSON(r) {
+		return h.api
+	}
+
+	return h.page
+}
+
+// prefersJSON reports whether the Accept header of the request
+// gives JSON a higher quality than HTML.
+func (h *Hybrid) prefersJSON(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+
+	ranges := h.accept.Get(accept)
+
+	return internal.Quality(ranges, "application/json") > internal.Quality(ranges, "text/html")
+}
+
+// IsScriptRequest reports whether the request was issued by a script rather than
+// by a navigation. It relies on the X-Requested-With header set by most JavaScript
+// libraries and on the Fetch Metadata headers sent by modern browsers.
+func IsScriptRequest(r *http.Request) bool {
+	if strings.EqualFold(r.Header.Get("X-Requested-With"), "XMLHttpRequest") {
+		return true
+	}
+
+	switch r.Header.Get("Sec-Fetch-Mode") {
+	case "cors", "same-origin", "no-cors":
+		dest := r.Header.Get("Sec-Fetch-Dest")
+
+		return dest == "" || dest == "empty"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestHybrid(t *testing.T) {
+	hybrid := NewHybrid(JSONResponder(), HTMLResponder())
+
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{name: "no hints", want: HTMLContentType},
+		{name: "XMLHttpRequest", headers: map[string]string{"X-Requested-With": "XMLHttpRequest"}, want: JSONContentType},
+		{
+			name:    "navigation",
+			headers: map[string]string{"Sec-Fetch-Mode": "navigate", "Sec-Fetch-Dest": "document"},
+			want:    HTMLContentType,
+		},
+		{
+			name:    "fetch",
+			headers: map[string]string{"Sec-Fetch-Mode": "cors", "Sec-Fetch-Dest": "empty"},
+			want:    JSONContentType,
+		},
+		{
+			name:    "browser navigation Accept header",
+			headers: map[string]string{"Accept": "text/html,application/xhtml+xml,*/*;q=0.8"},
+			want:    HTMLContentType,
+		},
+		{
+			name:    "API client Accept header",
+			headers: map[string]string{"Accept": "application/json"},
+			want:    JSONContentType,
+		},
+		{
+			name:    "wildcard Accept header",
+			headers: map[string]string{"Accept": "*/*"},
+			want:    HTMLContentType,
+		},
+		{
+			name:    "subresource",
+			headers: map[string]string{"Sec-Fetch-Mode": "no-cors", "Sec-Fetch-Dest": "image"},
+			want:    HTMLContentType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			hybrid.For(w, req).Send404(w, errors.New("not found"), "page not found")
+
+			if ct := w.Header().Get("Content-Type"); ct != tc.want {
+				t.Errorf("expected Content-Type %q, got %q", tc.want, ct)
+			}
+
+			if v := w.Header().Values("Vary"); !slices.Equal(v, []string{"Accept", "X-Requested-With", "Sec-Fetch-Mode", "Sec-Fetch-Dest"}) {
+				t.Errorf("expected the selection headers in Vary, got %q", v)
+			}
+		})
+	}
+}
+
+func BenchmarkHybrid(b *testing.B) {
+	hybrid := NewHybrid(JSONResponder(), HTMLResponder())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	w := httptest.NewRecorder()
+
+	for b.Loop() {
+		hybrid.For(w, req)
+	}
+}
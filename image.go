@@ -0,0 +1,95 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"time"
+)
+
+// errImageFormat is returned when an image cannot be encoded in the requested format.
+var errImageFormat = errors.New("responder: unsupported image format")
+
+// imageOptions holds the configuration of the image encoding.
+type imageOptions struct {
+	jpegQuality    int
+	pngCompression png.CompressionLevel
+}
+
+// WithJPEGQuality sets the quality of the images encoded as JPEG by SendImage,
+// ranging from 1 to 100, higher being better. It defaults to jpeg.DefaultQuality.
+func WithJPEGQuality(quality int) OptionsModifier {
+	return func(o *options) {
+		o.image.jpegQuality = quality
+	}
+}
+
+// WithPNGCompression sets the compression level of the images encoded as PNG by SendImage.
+func WithPNGCompression(level png.CompressionLevel) OptionsModifier {
+	return func(o *options) {
+		o.image.pngCompression = level
+	}
+}
+
+// encode encodes the image in the format, returning its content type.
+func (o imageOptions) encode(img image.Image, format string) ([]byte, string, error) {
+	var (
+		b   bytes.Buffer
+		err error
+		ct  string
+	)
+
+	switch strings.ToLower(format) {
+	case "", "png":
+		ct = "image/png"
+		err = (&png.Encoder{CompressionLevel: o.pngCompression}).Encode(&b, img)
+	case "jpeg", "jpg":
+		quality := o.jpegQuality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+
+		ct = "image/jpeg"
+		err = jpeg.Encode(&b, img, &jpeg.Options{Quality: quality})
+	case "gif":
+		ct = "image/gif"
+		err = gif.Encode(&b, img, nil)
+	default:
+		err = fmt.Errorf("%w: %q", errImageFormat, format)
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrInvalidContent, err)
+	}
+
+	return b.Bytes(), ct, nil
+}
+
+// sendImage encodes the image in the format and sends it with the content type of the format.
+func (r *responder) sendImage(rw responseWriter, img image.Image, format string) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	if img == nil {
+		return r.sendFailure(rw, fmt.Errorf("%w: nil image", ErrInvalidContent))
+	}
+
+	body, ct, err := r.options.image.encode(img, format)
+	if err != nil {
+		return r.sendFailure(rw, err)
+	}
+
+	return r.withContentType(ct).send(rw, status200, body, time.Since(start))
+}
+
+func (r *responder) SendImage(rw responseWriter, img image.Image, format string) {
+	_ = r.sendImage(rw, img, format)
+}
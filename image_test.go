@@ -0,0 +1,97 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for x := range 16 {
+		for y := range 16 {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+
+	for _, tc := range []struct {
+		format      string
+		contentType string
+	}{
+		{format: "", contentType: "image/png"},
+		{format: "png", contentType: "image/png"},
+		{format: "JPEG", contentType: "image/jpeg"},
+		{format: "jpg", contentType: "image/jpeg"},
+		{format: "gif", contentType: "image/gif"},
+	} {
+		t.Run("encodes the image as "+tc.contentType, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			TextResponder().SendImage(w, img, tc.format)
+
+			if ct := w.Header().Get("Content-Type"); ct != tc.contentType {
+				t.Errorf("expected Content-Type %q, got %q", tc.contentType, ct)
+			}
+
+			if ct := http.DetectContentType(w.Body.Bytes()); ct != tc.contentType {
+				t.Errorf("expected the body to be %s, got %s", tc.contentType, ct)
+			}
+		})
+	}
+
+	t.Run("applies the quality options", func(t *testing.T) {
+		low, high := httptest.NewRecorder(), httptest.NewRecorder()
+
+		TextResponder(WithJPEGQuality(10)).SendImage(low, img, "jpeg")
+		TextResponder(WithJPEGQuality(100)).SendImage(high, img, "jpeg")
+
+		if low.Body.Len() >= high.Body.Len() {
+			t.Errorf("expected the low quality image to be smaller, got %d and %d bytes", low.Body.Len(), high.Body.Len())
+		}
+
+		if _, err := jpeg.Decode(bytes.NewReader(low.Body.Bytes())); err != nil {
+			t.Errorf("expected a valid JPEG image, got %v", err)
+		}
+
+		w := httptest.NewRecorder()
+
+		TextResponder(WithPNGCompression(png.NoCompression)).SendImage(w, img, "png")
+
+		if _, err := png.Decode(bytes.NewReader(w.Body.Bytes())); err != nil {
+			t.Errorf("expected a valid PNG image, got %v", err)
+		}
+	})
+
+	t.Run("reports the unsupported formats", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(TextResponder()).SendImage(w, img, "webp")
+		if !errors.Is(err, ErrInvalidContent) || !errors.Is(err, errImageFormat) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+
+		JSONResponder().SendImage(w, img, "webp")
+
+		if w.Code != http.StatusInternalServerError || w.Header().Get("Content-Type") != JSONContentType {
+			t.Errorf("expected a JSON 500 response, got %d %q", w.Code, w.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("reports the nil images", func(t *testing.T) {
+		if err := Checked(TextResponder()).SendImage(httptest.NewRecorder(), nil, "png"); !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package responder
+
+import (
+	"bytes"
+	"html"
+	"strings"
+)
+
+// Incident describes an ongoing incident advertised on the HTML server error pages.
+type Incident struct {
+	// ID identifies the incident for the support team.
+	ID string
+	// Message is the user-facing description of the outage.
+	Message string
+	// StatusPageURL links to the status page of the service.
+	StatusPageURL string
+}
+
+// WithIncident sets a function reporting the ongoing incident, if any.
+// When it returns a non-nil Incident, a banner describing it is injected into
+// the 5xx error pages sent by HTML responders. The function is called on every
+// server error so that the incident can be declared and resolved at runtime.
+func WithIncident(f func() *Incident) OptionsModifier {
+	return func(o *options) {
+		o.incident = f
+	}
+}
+
+// banner renders the incident as an HTML fragment.
+func (i *Incident) banner() string {
+	var b strings.Builder
+
+	b.WriteString(`<div class="incident-banner" role="alert">`)
+	b.WriteString(html.EscapeString(i.Message))
+
+	if i.ID != "" {
+		b.WriteString(` <span class="incident-id">Incident ID: `)
+		b.WriteString(html.EscapeString(i.ID))
+		b.WriteString(`</span>`)
+	}
+
+	if i.StatusPageURL != "" {
+		b.WriteString(` <a href="`)
+		b.WriteString(html.EscapeString(i.StatusPageURL))
+		b.WriteString(`">Status page</a>`)
+	}
+
+	b.WriteString(`</div>`)
+
+	return b.String()
+}
+
+// injectBanner inserts the banner right after the opening body tag,
+// or at the beginning of the document when there is none.
+func injectBanner(body []byte, banner string) []byte {
+	at := 0
+
+	if i := bytes.Index(bytes.ToLower(body), []byte("<body")); i >= 0 {
+		if j := bytes.IndexByte(body[i:], '>'); j >= 0 {
+			at = i + j + 1
+		}
+	}
+
+	out := make([]byte, 0, len(body)+len(banner))
+	out = append(out, body[:at]...)
+	out = append(out, banner...)
+	out = append(out, body[at:]...)
+
+	return out
+}
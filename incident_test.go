@@ -0,0 +1,77 @@
+package responder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWithIncident(t *testing.T) {
+	var current *Incident
+
+	incident := func() *Incident { return current }
+
+	t.Run("does not inject a banner without incident", func(t *testing.T) {
+		current = nil
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithIncident(incident)).Send500(w, errors.New("boom"), "<p>Server error</p>")
+
+		if w.Body.String() != "<p>Server error</p>" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("injects the banner after the body tag", func(t *testing.T) {
+		current = &Incident{ID: "INC-42", Message: "Degraded <performance>", StatusPageURL: "https://status.example.com"}
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithIncident(incident)).Send500(w, errors.New("boom"), `<html><body class="x"><p>Unavailable</p></body></html>`)
+
+		body := w.Body.String()
+		if !strings.HasPrefix(body, `<html><body class="x"><div class="incident-banner"`) {
+			t.Errorf("expected the banner after the body tag, got %q", body)
+		}
+
+		for _, want := range []string{"Degraded &lt;performance&gt;", "INC-42", `href="https://status.example.com"`} {
+			if !strings.Contains(body, want) {
+				t.Errorf("expected body to contain %q, got %q", want, body)
+			}
+		}
+
+		if w.Header().Get("Content-Length") != strconv.Itoa(len(body)) {
+			t.Errorf("expected Content-Length to match the body, got %q", w.Header().Get("Content-Length"))
+		}
+	})
+
+	t.Run("prepends the banner to fragments", func(t *testing.T) {
+		current = &Incident{Message: "Outage"}
+		w := httptest.NewRecorder()
+
+		HTMLResponder(WithIncident(incident)).Send500(w, errors.New("boom"), "<p>Server error</p>")
+
+		if !strings.HasPrefix(w.Body.String(), `<div class="incident-banner" role="alert">Outage</div><p>`) {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("leaves client errors and other content types alone", func(t *testing.T) {
+		current = &Incident{Message: "Outage"}
+
+		w := httptest.NewRecorder()
+		HTMLResponder(WithIncident(incident)).Send404(w, errors.New("missing"), "<p>Not found</p>")
+
+		if strings.Contains(w.Body.String(), "incident-banner") {
+			t.Errorf("expected no banner on 404, got %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		TextResponder(WithIncident(incident)).Send500(w, errors.New("boom"), "Server error")
+
+		if strings.Contains(w.Body.String(), "incident-banner") {
+			t.Errorf("expected no banner on text responses, got %q", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"container/list"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MediaRange is a media range of an Accept header along with its quality value.
+type MediaRange struct {
+	Type    string
+	Subtype string
+	Q       float64
+}
+
+// specificity ranks the media range, exact types being more specific than wildcards.
+func (m MediaRange) specificity() int {
+	switch {
+	case m.Type == "*":
+		return 0
+	case m.Subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// matches reports whether the media range covers the media type.
+func (m MediaRange) matches(typ, subtype string) bool {
+	return (m.Type == "*" || m.Type == typ) && (m.Subtype == "*" || m.Subtype == subtype)
+}
+
+// ParseAccept parses the value of an Accept header. Invalid ranges are ignored
+// and the returned ranges are sorted by decreasing specificity.
+func ParseAccept(header string) []MediaRange {
+	var ranges []MediaRange
+
+	for part := range strings.SplitSeq(header, ",") {
+		params := strings.Split(part, ";")
+
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(params[0])), "/")
+		if !ok || typ == "" || subtype == "" {
+			continue
+		}
+
+		m := MediaRange{Type: typ, Subtype: subtype, Q: 1}
+
+		for _, p := range params[1:] {
+			k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if strings.EqualFold(k, "q") {
+				if q, err := strconv.ParseFloat(v, 64); err == nil && q >= 0 && q <= 1 {
+					m.Q = q
+				}
+			}
+		}
+
+		ranges = append(ranges, m)
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].specificity() > ranges[j].specificity()
+	})
+
+	return ranges
+}
+
+// Quality returns the quality value the ranges assign to the media type,
+// the most specific matching range taking precedence. It returns 0 when
+// no range matches, and 1 when there are no ranges at all, since a missing
+// Accept header means that any media type is acceptable.
+func Quality(ranges []MediaRange, mediaType string) float64 {
+	if len(ranges) == 0 {
+		return 1
+	}
+
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+
+	for _, m := range ranges {
+		if m.matches(typ, subtype) {
+			return m.Q
+		}
+	}
+
+	return 0
+}
+
+// AcceptCache is a fixed-size LRU cache of parsed Accept headers keyed
+// by their raw value. Clients mostly send the very same headers, so caching
+// avoids parsing them on every request. It is safe for concurrent use.
+type AcceptCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type acceptEntry struct {
+	header string
+	ranges []MediaRange
+}
+
+// NewAcceptCache creates an AcceptCache holding up to size headers.
+func NewAcceptCache(size int) *AcceptCache {
+	return &AcceptCache{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the parsed header, parsing and caching it on a miss.
+// The returned ranges are shared and must not be modified.
+func (c *AcceptCache) Get(header string) []MediaRange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[header]; ok {
+		c.order.MoveToFront(e)
+
+		entry, _ := e.Value.(*acceptEntry)
+
+		return entry.ranges
+	}
+
+	ranges := ParseAccept(header)
+	c.entries[header] = c.order.PushFront(&acceptEntry{header: header, ranges: ranges})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		entry, _ := oldest.Value.(*acceptEntry)
+		delete(c.entries, entry.header)
+	}
+
+	return ranges
+}
+
+// Len returns the number of cached headers.
+func (c *AcceptCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
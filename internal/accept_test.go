@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"strconv"
+	"testing"
+)
+
+const browserAccept = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+
+func TestParseAccept(t *testing.T) {
+	ranges := ParseAccept("*/*;q=0.1, text/*;q=0.5, application/json, invalid")
+
+	if len(ranges) != 3 {
+		t.Fatalf("expected 3 ranges, got %v", ranges)
+	}
+
+	testCases := []struct {
+		mediaType string
+		want      float64
+	}{
+		{"application/json", 1},
+		{"text/html", 0.5},
+		{"image/png", 0.1},
+	}
+
+	for _, tc := range testCases {
+		if q := Quality(ranges, tc.mediaType); q != tc.want {
+			t.Errorf("expected quality %v for %s, got %v", tc.want, tc.mediaType, q)
+		}
+	}
+
+	if q := Quality(nil, "text/html"); q != 1 {
+		t.Errorf("expected quality 1 without Accept header, got %v", q)
+	}
+
+	if q := Quality(ParseAccept("text/html"), "application/json"); q != 0 {
+		t.Errorf("expected quality 0 for unacceptable types, got %v", q)
+	}
+}
+
+func TestAcceptCache(t *testing.T) {
+	c := NewAcceptCache(2)
+
+	c.Get("text/html")
+	c.Get("application/json")
+	c.Get("text/html")
+	c.Get("text/csv")
+
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 cached headers, got %d", c.Len())
+	}
+
+	if _, ok := c.entries["application/json"]; ok {
+		t.Error("expected the least recently used header to be evicted")
+	}
+
+	if _, ok := c.entries["text/html"]; !ok {
+		t.Error("expected the recently used header to be kept")
+	}
+}
+
+func BenchmarkParseAccept(b *testing.B) {
+	for b.Loop() {
+		ParseAccept(browserAccept)
+	}
+}
+
+func BenchmarkAcceptCache(b *testing.B) {
+	b.Run("hit", func(b *testing.B) {
+		c := NewAcceptCache(64)
+
+		for b.Loop() {
+			c.Get(browserAccept)
+		}
+	})
+
+	b.Run("miss", func(b *testing.B) {
+		c := NewAcceptCache(64)
+		headers := make([]string, 128)
+
+		for i := range headers {
+			headers[i] = browserAccept + ",text/x-" + strconv.Itoa(i)
+		}
+
+		i := 0
+		for b.Loop() {
+			c.Get(headers[i%len(headers)])
+			i++
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"hash"
+	"io"
+	"net/http"
+)
+
+// ContentDigestHeader is the header carrying the digest of the content (RFC 9530).
+const ContentDigestHeader = "Content-Digest"
+
+// DigestWriter computes the SHA-256 digest of the data written through it,
+// for bodies streamed without knowing their content upfront.
+type DigestWriter struct {
+	w io.Writer
+	h hash.Hash
+	n int64
+}
+
+// NewDigestWriter creates a DigestWriter writing to w.
+func NewDigestWriter(w io.Writer) *DigestWriter {
+	return &DigestWriter{w: w, h: sha256.New()}
+}
+
+// Write writes the data to the underlying writer and hashes what was written.
+func (d *DigestWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	d.h.Write(p[:n])
+	d.n += int64(n)
+
+	return n, err
+}
+
+// ContentDigest returns the digest of the data written so far,
+// formatted as the value of a Content-Digest field.
+func (d *DigestWriter) ContentDigest() string {
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(d.h.Sum(nil)) + ":"
+}
+
+// Written returns the number of bytes written so far.
+func (d *DigestWriter) Written() int64 {
+	return d.n
+}
+
+// DeclareDigestTrailer announces the Content-Digest trailer.
+// It must be called before the header is written.
+func DeclareDigestTrailer(h http.Header) {
+	h.Add("Trailer", ContentDigestHeader)
+}
+
+// SetDigestTrailer sets the Content-Digest trailer once the body has been written.
+func SetDigestTrailer(h http.Header, d *DigestWriter) {
+	h.Set(ContentDigestHeader, d.ContentDigest())
+}
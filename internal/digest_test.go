@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestDigestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	d := NewDigestWriter(&buf)
+
+	_, _ = d.Write([]byte("hello "))
+	_, _ = d.Write([]byte("world"))
+
+	if buf.String() != "hello world" {
+		t.Errorf("expected the data to be written through, got %q", buf.String())
+	}
+
+	// echo -n "hello world" | openssl dgst -sha256 -binary | base64
+	expected := "sha-256=:uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=:"
+	if d.ContentDigest() != expected {
+		t.Errorf("expected digest %q, got %q", expected, d.ContentDigest())
+	}
+
+	h := make(http.Header)
+	DeclareDigestTrailer(h)
+	SetDigestTrailer(h, d)
+
+	if h.Get("Trailer") != ContentDigestHeader || h.Get(ContentDigestHeader) != expected {
+		t.Errorf("unexpected headers %v", h)
+	}
+}
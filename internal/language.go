@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LanguageRange is a language range of an Accept-Language header along with its quality value.
+type LanguageRange struct {
+	Tag string
+	Q   float64
+}
+
+// ParseAcceptLanguage parses the value of an Accept-Language header. Invalid and
+// rejected (q=0) ranges are ignored and the returned ranges are sorted
+// by decreasing quality, the tags being lowercased.
+func ParseAcceptLanguage(header string) []LanguageRange {
+	var ranges []LanguageRange
+
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+
+		l := LanguageRange{Tag: tag, Q: 1}
+
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.EqualFold(k, "q") {
+			if q, err := strconv.ParseFloat(v, 64); err == nil && q >= 0 && q <= 1 {
+				l.Q = q
+			}
+		}
+
+		if l.Q > 0 {
+			ranges = append(ranges, l)
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].Q > ranges[j].Q
+	})
+
+	return ranges
+}
+
+// MatchLanguage returns the index of the available tag best matching the ranges,
+// or -1 when none matches. A range matches the tags it equals or is a prefix of,
+// e.g. "fr" matches "fr-CH", and a tag matches the ranges of its primary language,
+// e.g. "fr-FR" matches "fr-CH" when nothing better is available.
+func MatchLanguage(ranges []LanguageRange, available []string) int {
+	for _, r := range ranges {
+		if r.Tag == "*" && len(available) > 0 {
+			return 0
+		}
+
+		for i, tag := range available {
+			tag = strings.ToLower(tag)
+			if tag == r.Tag || strings.HasPrefix(tag, r.Tag+"-") {
+				return i
+			}
+		}
+
+		primary, _, _ := strings.Cut(r.Tag, "-")
+
+		for i, tag := range available {
+			p, _, _ := strings.Cut(strings.ToLower(tag), "-")
+			if p == primary {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
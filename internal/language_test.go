@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0, *;q=0.5, ")
+	want := []LanguageRange{
+		{Tag: "fr-ch", Q: 1},
+		{Tag: "fr", Q: 0.9},
+		{Tag: "en", Q: 0.8},
+		{Tag: "*", Q: 0.5},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	available := []string{"en-US", "fr-FR", "de-DE"}
+
+	testCases := []struct {
+		header string
+		want   int
+	}{
+		{header: "fr-FR", want: 1},
+		{header: "fr", want: 1},
+		{header: "fr-CH", want: 1},
+		{header: "es, de;q=0.5", want: 2},
+		{header: "es", want: -1},
+		{header: "es, *;q=0.1", want: 0},
+		{header: "", want: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			if got := MatchLanguage(ParseAcceptLanguage(tc.header), available); got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package internal
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// RenderMarkdown converts a subset of Markdown to HTML: ATX headings, paragraphs,
+// fenced code blocks, block quotes, unordered and ordered lists, thematic breaks,
+// and inline code, emphasis, strong emphasis, links and images. The links and
+// images are inline ones, without title, their destination containing no space
+// and its parentheses being balanced or escaped as in CommonMark.
+// The raw HTML of the source is escaped and the links with an unsafe scheme,
+// e.g. javascript:, are rendered as text, the output being safe to send as is.
+func RenderMarkdown(src string) string {
+	var b strings.Builder
+
+	renderBlocks(&b, strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n"))
+
+	return b.String()
+}
+
+// renderBlocks renders the block elements of the lines.
+func renderBlocks(b *strings.Builder, lines []string) {
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimLeft(line, " ")
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+
+			i = renderFence(b, lines, i, strings.TrimSpace(trimmed[3:]))
+		case headingLevel(trimmed) > 0:
+			flush()
+
+			level := headingLevel(trimmed)
+			text := strings.TrimRight(strings.TrimSpace(trimmed[level:]), "#")
+			tag := "h" + strconv.Itoa(level)
+			b.WriteString("<" + tag + ">" + renderInline(strings.TrimSpace(text)) + "</" + tag + ">\n")
+		case isThematicBreak(trimmed):
+			flush()
+			b.WriteString("<hr>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimLeft(lines[i], " "), ">"); i++ {
+				l := strings.TrimPrefix(strings.TrimLeft(lines[i], " "), ">")
+				quote = append(quote, strings.TrimPrefix(l, " "))
+			}
+
+			i--
+
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quote)
+			b.WriteString("</blockquote>\n")
+		case listItem(trimmed) != "":
+			flush()
+
+			i = renderList(b, lines, i)
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	flush()
+}
+
+// renderFence renders the fenced code block starting at the line,
+// returning the index of its closing line.
+func renderFence(b *strings.Builder, lines []string, start int, info string) int {
+	b.WriteString("<pre><code")
+
+	if lang, _, _ := strings.Cut(info, " "); lang != "" {
+		b.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+	}
+
+	b.WriteString(">")
+
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+			break
+		}
+
+		b.WriteString(html.EscapeString(lines[i]) + "\n")
+	}
+
+	b.WriteString("</code></pre>\n")
+
+	return i
+}
+
+// renderList renders the list starting at the line, returning the index of its last line.
+func renderList(b *strings.Builder, lines []string, start int) int {
+	tag := "ul"
+	if listItem(strings.TrimLeft(lines[start], " ")) == "ol" {
+		tag = "ol"
+	}
+
+	b.WriteString("<" + tag + ">\n")
+
+	i := start
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if listItem(trimmed) == "" {
+			break
+		}
+
+		_, text, _ := strings.Cut(trimmed, " ")
+		b.WriteString("<li>" + renderInline(strings.TrimSpace(text)) + "</li>\n")
+	}
+
+	b.WriteString("</" + tag + ">\n")
+
+	return i - 1
+}
+
+// headingLevel returns the level of the ATX heading, 0 if the line is not one.
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0
+	}
+
+	return level
+}
+
+// isThematicBreak reports whether the line is made of three or more -, * or _.
+func isThematicBreak(line string) bool {
+	s := strings.ReplaceAll(line, " ", "")
+	if len(s) < 3 {
+		return false
+	}
+
+	return strings.Count(s, s[:1]) == len(s) && strings.Contains("-*_", s[:1])
+}
+
+// listItem returns the tag of the list the line is an item of, if any.
+func listItem(line string) string {
+	if len(line) > 1 && strings.Contains("-*+", line[:1]) && line[1] == ' ' {
+		return "ul"
+	}
+
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+
+	if digits > 0 && digits+1 < len(line) && line[digits] == '.' && line[digits+1] == ' ' {
+		return "ol"
+	}
+
+	return ""
+}
+
+// renderInline renders the inline elements of the text, escaping the rest of it.
+func renderInline(text string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_[]()#+-.!>", text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+				i += end + 2
+			} else {
+				b.WriteString("`")
+				i++
+			}
+		case c == '*' || c == '_':
+			n := i + renderEmphasis(&b, text[i:])
+			if n == i {
+				b.WriteByte(c)
+				n++
+			}
+
+			i = n
+		case c == '[':
+			n := i + renderLink(&b, text[i:])
+			if n == i {
+				b.WriteString("[")
+				n++
+			}
+
+			i = n
+		case c == '!' && strings.HasPrefix(text[i+1:], "["):
+			n := i + renderImage(&b, text[i:])
+			if n == i {
+				b.WriteString("![")
+				n += 2
+			}
+
+			i = n
+		case c == '\n':
+			b.WriteString("\n")
+			i++
+		default:
+			b.WriteString(html.EscapeString(text[i : i+1]))
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// renderEmphasis renders the emphasis the text starts with, returning
+// the number of bytes consumed, 0 if the text does not start with one.
+func renderEmphasis(b *strings.Builder, text string) int {
+	for _, d := range []struct{ delim, tag string }{
+		{text[:1] + text[:1], "strong"},
+		{text[:1], "em"},
+	} {
+		if !strings.HasPrefix(text, d.delim) || len(text) <= len(d.delim) || text[len(d.delim)] == ' ' {
+			continue
+		}
+
+		end := strings.Index(text[len(d.delim):], d.delim)
+		if end <= 0 {
+			continue
+		}
+
+		b.WriteString("<" + d.tag + ">" + renderInline(text[len(d.delim):len(d.delim)+end]) + "</" + d.tag + ">")
+
+		return end + 2*len(d.delim)
+	}
+
+	return 0
+}
+
+// renderLink renders the link the text starts with, returning
+// the number of bytes consumed, 0 if the text does not start with one.
+func renderLink(b *strings.Builder, text string) int {
+	label, url, n := parseLink(text)
+	if n == 0 {
+		return 0
+	}
+
+	if !safeURL(url) {
+		b.WriteString(renderInline(label))
+	} else {
+		b.WriteString(`<a href="` + html.EscapeString(url) + `">` + renderInline(label) + "</a>")
+	}
+
+	return n
+}
+
+// renderImage renders the image the text starts with, returning
+// the number of bytes consumed, 0 if the text does not start with one.
+// The images with an unsafe source are rendered as their description.
+func renderImage(b *strings.Builder, text string) int {
+	alt, src, n := parseLink(text[1:])
+	if n == 0 {
+		return 0
+	}
+
+	if !safeURL(src) {
+		b.WriteString(renderInline(alt))
+	} else {
+		b.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(unescape(alt)) + `">`)
+	}
+
+	return n + 1
+}
+
+// parseLink parses the [label](destination) the text starts with, returning its label,
+// its unescaped destination and its length, 0 if the text does not start with one.
+// The brackets of the label and the parentheses of the destination are balanced.
+func parseLink(text string) (string, string, int) {
+	end := closingBracket(text)
+	if end < 0 || !strings.HasPrefix(text[end+1:], "(") {
+		return "", "", 0
+	}
+
+	label, rest := text[1:end], text[end+2:]
+	depth := 0
+
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return label, unescape(rest[:i]), len(label) + i + 4
+			}
+
+			depth--
+		case ' ', '\n':
+			return "", "", 0
+		}
+	}
+
+	return "", "", 0
+}
+
+// closingBracket returns the index of the bracket closing the one the text starts with,
+// -1 if it is not closed on the same line.
+func closingBracket(text string) int {
+	depth := 0
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		case '\n':
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// unescape removes the backslashes escaping the ASCII punctuation characters.
+func unescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(asciiPunctuation, s[i+1]) >= 0 {
+			i++
+		}
+
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// asciiPunctuation lists the ASCII punctuation characters, which can be escaped with a backslash.
+const asciiPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// safeURL reports whether the URL is relative or uses a scheme that cannot run scripts.
+func safeURL(url string) bool {
+	scheme, _, ok := strings.Cut(url, ":")
+	if !ok || strings.ContainsAny(scheme, "/?#") {
+		return true
+	}
+
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,42 @@
+package internal
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "heading", src: "## Changelog ##", want: "<h2>Changelog</h2>\n"},
+		{name: "paragraphs", src: "one\ntwo\n\nthree", want: "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{name: "emphasis", src: "**bold** and *em* and _em_", want: "<p><strong>bold</strong> and <em>em</em> and <em>em</em></p>\n"},
+		{name: "code", src: "run `go test <pkg>`", want: "<p>run <code>go test &lt;pkg&gt;</code></p>\n"},
+		{name: "fence", src: "```go\nif a < b {}\n```", want: "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n"},
+		{name: "unordered list", src: "- a\n* b", want: "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{name: "ordered list", src: "1. a\n2. b", want: "<ol>\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{name: "block quote", src: "> quoted\n> text", want: "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+		{name: "thematic break", src: "a\n\n---\n\nb", want: "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{name: "link", src: "[docs](https://example.com/?a=1&b=2)", want: "<p><a href=\"https://example.com/?a=1&amp;b=2\">docs</a></p>\n"},
+		{name: "relative link", src: "[next](/v2)", want: "<p><a href=\"/v2\">next</a></p>\n"},
+		{name: "unsafe link", src: "[click](javascript:alert(1))", want: "<p>click</p>\n"},
+		{name: "balanced parentheses", src: "[Go](https://en.wikipedia.org/wiki/Go_(programming_language))", want: "<p><a href=\"https://en.wikipedia.org/wiki/Go_(programming_language)\">Go</a></p>\n"},
+		{name: "escaped parenthesis", src: `[a](/b\)c)`, want: "<p><a href=\"/b)c\">a</a></p>\n"},
+		{name: "unbalanced parentheses", src: "[a](/b(c)", want: "<p>[a](/b(c)</p>\n"},
+		{name: "image", src: "![a \"logo\"](/logo.png)", want: "<p><img src=\"/logo.png\" alt=\"a &#34;logo&#34;\"></p>\n"},
+		{name: "unsafe image", src: "![logo](javascript:alert(1))", want: "<p>logo</p>\n"},
+		{name: "image link", src: "[![logo](/logo.png)](/)", want: "<p><a href=\"/\"><img src=\"/logo.png\" alt=\"logo\"></a></p>\n"},
+		{name: "exclamation mark", src: "Hello![", want: "<p>Hello![</p>\n"},
+		{name: "raw html", src: "<script>alert(1)</script>", want: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{name: "escapes", src: `\*not em\*`, want: "<p>*not em*</p>\n"},
+		{name: "unclosed delimiters", src: "a * b and `c", want: "<p>a * b and `c</p>\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RenderMarkdown(tc.src); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidRange is returned for the Range headers that cannot be parsed.
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrUnsatisfiableRange is returned when none of the ranges overlaps the content.
+	ErrUnsatisfiableRange = errors.New("unsatisfiable range")
+)
+
+// ByteRange is a range of bytes of a content.
+type ByteRange struct {
+	Start  int64
+	Length int64
+}
+
+// ContentRange returns the value of the Content-Range header of the range
+// of a content of the given size.
+func (r ByteRange) ContentRange(size int64) string {
+	return "bytes " + strconv.FormatInt(r.Start, 10) + "-" + strconv.FormatInt(r.Start+r.Length-1, 10) +
+		"/" + strconv.FormatInt(size, 10)
+}
+
+// ParseRange parses the value of a Range header for a content of the given size,
+// returning the satisfiable byte ranges, clamped to the content.
+func ParseRange(header string, size int64) ([]ByteRange, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return nil, ErrInvalidRange
+	}
+
+	var (
+		ranges []ByteRange
+		parsed int
+	)
+
+	for part := range strings.SplitSeq(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		first, last, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, ErrInvalidRange
+		}
+
+		parsed++
+
+		var r ByteRange
+
+		if first == "" {
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < 0 {
+				return nil, ErrInvalidRange
+			}
+
+			if n == 0 || size == 0 {
+				continue
+			}
+
+			n = min(n, size)
+			r = ByteRange{Start: size - n, Length: n}
+		} else {
+			start, err := strconv.ParseInt(first, 10, 64)
+			if err != nil || start < 0 {
+				return nil, ErrInvalidRange
+			}
+
+			end := size - 1
+
+			if last != "" {
+				end, err = strconv.ParseInt(last, 10, 64)
+				if err != nil || end < start {
+					return nil, ErrInvalidRange
+				}
+
+				end = min(end, size-1)
+			}
+
+			if start >= size {
+				continue
+			}
+
+			r = ByteRange{Start: start, Length: end - start + 1}
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	if parsed == 0 {
+		return nil, ErrInvalidRange
+	}
+
+	if len(ranges) == 0 {
+		return nil, ErrUnsatisfiableRange
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		header string
+		want   []ByteRange
+		err    error
+	}{
+		{header: "bytes=0-99", want: []ByteRange{{Start: 0, Length: 100}}},
+		{header: "bytes=100-", want: []ByteRange{{Start: 100, Length: 900}}},
+		{header: "bytes=-100", want: []ByteRange{{Start: 900, Length: 100}}},
+		{header: "bytes=-2000", want: []ByteRange{{Start: 0, Length: 1000}}},
+		{header: "bytes=900-2000", want: []ByteRange{{Start: 900, Length: 100}}},
+		{header: "bytes=0-0, 10-19", want: []ByteRange{{Start: 0, Length: 1}, {Start: 10, Length: 10}}},
+		{header: "bytes=2000-, 0-9", want: []ByteRange{{Start: 0, Length: 10}}},
+		{header: "bytes=1000-", err: ErrUnsatisfiableRange},
+		{header: "bytes=-0", err: ErrUnsatisfiableRange},
+		{header: "items=0-9", err: ErrInvalidRange},
+		{header: "bytes=9-0", err: ErrInvalidRange},
+		{header: "bytes=a-b", err: ErrInvalidRange},
+		{header: "bytes=10", err: ErrInvalidRange},
+		{header: "bytes=", err: ErrInvalidRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			got, err := ParseRange(tc.header, 1000)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestByteRangeContentRange(t *testing.T) {
+	if got := (ByteRange{Start: 10, Length: 10}).ContentRange(1000); got != "bytes 10-19/1000" {
+		t.Errorf("unexpected Content-Range %q", got)
+	}
+}
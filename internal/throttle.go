@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// maxThrottledChunk is the maximum size of the chunks written by a ThrottledWriter.
+const maxThrottledChunk = 32 * 1024
+
+// Limiter is a token bucket limiting a throughput in bytes per second.
+// The bucket holds up to a second worth of bytes and starts full.
+// It is safe for concurrent use.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a Limiter allowing the given number of bytes per second.
+func NewLimiter(bytesPerSec int) *Limiter {
+	return &Limiter{
+		rate:   float64(bytesPerSec),
+		burst:  bytesPerSec,
+		tokens: float64(bytesPerSec),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until n bytes may be written or the context is done.
+// The bytes are reserved even when the context is done.
+func (l *Limiter) Wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(float64(l.burst), l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// ThrottledWriter writes to the underlying writer in chunks,
+// waiting for all its limiters before writing each of them.
+type ThrottledWriter struct {
+	ctx      context.Context
+	w        io.Writer
+	limiters []*Limiter
+	chunk    int
+}
+
+// NewThrottledWriter creates a ThrottledWriter writing to w.
+// The writes are interrupted when the context is done.
+func NewThrottledWriter(ctx context.Context, w io.Writer, limiters ...*Limiter) *ThrottledWriter {
+	chunk := maxThrottledChunk
+	for _, l := range limiters {
+		chunk = max(1, min(chunk, l.burst))
+	}
+
+	return &ThrottledWriter{ctx: ctx, w: w, limiters: limiters, chunk: chunk}
+}
+
+// Write writes the data once the limiters allow it.
+func (t *ThrottledWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		n := min(len(p), t.chunk)
+
+		for _, l := range t.limiters {
+			if err := l.Wait(t.ctx, n); err != nil {
+				return written, err
+			}
+		}
+
+		m, err := t.w.Write(p[:n])
+		written += m
+
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
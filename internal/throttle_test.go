@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestThrottledWriter(t *testing.T) {
+	t.Run("limits the throughput", func(t *testing.T) {
+		var b bytes.Buffer
+
+		w := NewThrottledWriter(context.Background(), &b, NewLimiter(2000))
+		start := time.Now()
+
+		n, err := w.Write(make([]byte, 2500))
+		if err != nil || n != 2500 || b.Len() != 2500 {
+			t.Fatalf("unexpected write %d %v", n, err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+			t.Errorf("expected the write to be throttled, took %v", elapsed)
+		}
+	})
+
+	t.Run("shares the global limiters", func(t *testing.T) {
+		var b bytes.Buffer
+
+		global := NewLimiter(2000)
+		start := time.Now()
+
+		for range 2 {
+			if _, err := NewThrottledWriter(context.Background(), &b, NewLimiter(10000), global).Write(make([]byte, 1250)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+			t.Errorf("expected the writes to be throttled, took %v", elapsed)
+		}
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		var b bytes.Buffer
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		n, err := NewThrottledWriter(ctx, &b, NewLimiter(100)).Write(make([]byte, 150))
+		if !errors.Is(err, context.Canceled) || n != 100 {
+			t.Errorf("expected 100 bytes and context.Canceled, got %d %v", n, err)
+		}
+	})
+}
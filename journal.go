@@ -0,0 +1,101 @@
+package responder
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// JournalEntry describes a response sent by a responder.
+type JournalEntry struct {
+	// Time is the time the response was sent at.
+	Time time.Time
+	// RequestID is the ID of the request the responder is bound to, see WithRequestID.
+	RequestID string
+	// Status is the status code of the response.
+	Status int
+	// Header holds the journaled headers of the response.
+	Header http.Header
+	// BodyDigest is the SHA-256 digest of the body as it was written,
+	// formatted as the value of a Content-Digest field.
+	// It is empty for the redirects.
+	BodyDigest string
+	// BytesWritten is the number of bytes of the body written to the client.
+	BytesWritten int64
+	// Watermark is the watermark embedded in the body, see WithWatermark.
+	Watermark string
+	// WriteError is the error that occurred while writing the body, if any.
+	WriteError error
+}
+
+// Journal persists the responses sent by a responder,
+// e.g. to audit the delivery of the responses of a financial API.
+type Journal interface {
+	// Record persists the entry. The context is the one of the request
+	// the responder is bound to, if any.
+	Record(context.Context, JournalEntry) error
+}
+
+// WithJournal records every response sent by the responder in the journal,
+// along with the given subset of its headers. Recording failures are logged
+// if a logger was provided, they do not affect the response.
+func WithJournal(j Journal, headers ...string) OptionsModifier {
+	return func(o *options) {
+		o.journal = j
+		o.journalHeaders = headers
+	}
+}
+
+// journalWriter returns the writer the body must be written to,
+// along with the digest writer hashing it for the journal when it is enabled.
+func (r *responder) journalWriter(w io.Writer) (io.Writer, *internal.DigestWriter) {
+	if r.options.journal == nil {
+		return w, nil
+	}
+
+	d := internal.NewDigestWriter(w)
+
+	return d, d
+}
+
+// record records the response in the journal.
+func (r *responder) record(rw responseWriter, code int, digest *internal.DigestWriter, werr error, watermark string) {
+	if r.options.journal == nil {
+		return
+	}
+
+	ctx := context.Background()
+	entry := JournalEntry{
+		Time:       time.Now(),
+		Status:     code,
+		Header:     make(http.Header, len(r.options.journalHeaders)),
+		Watermark:  watermark,
+		WriteError: werr,
+	}
+
+	if r.request != nil {
+		ctx = r.request.Context()
+		entry.RequestID = r.requestID()
+	}
+
+	for _, h := range r.options.journalHeaders {
+		if v := rw.Header().Values(h); len(v) > 0 {
+			entry.Header[http.CanonicalHeaderKey(h)] = append([]string(nil), v...)
+		}
+	}
+
+	if digest != nil {
+		entry.BodyDigest = digest.ContentDigest()
+		entry.BytesWritten = digest.Written()
+	}
+
+	if err := r.options.journal.Record(ctx, entry); err != nil && r.options.logger != nil {
+		r.options.logger.Error("failed to record response in journal",
+			"status", code,
+			"error", err,
+		)
+	}
+}
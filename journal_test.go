@@ -0,0 +1,115 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type memoryJournal struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+	err     error
+}
+
+func (j *memoryJournal) Record(_ context.Context, e JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = append(j.entries, e)
+
+	return j.err
+}
+
+func TestWithJournal(t *testing.T) {
+	t.Run("records the sent responses", func(t *testing.T) {
+		j := &memoryJournal{}
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set("X-Request-ID", "req-1")
+
+		w := httptest.NewRecorder()
+		w.Header().Set("X-Secret", "not journaled")
+
+		JSONResponder(WithJournal(j, "Content-Type", "Location")).
+			WithRequest(req).
+			Send(w, Success(http.StatusCreated, map[string]string{"id": "p1"}).WithHeader("Location", "/payments/p1"))
+
+		if len(j.entries) != 1 {
+			t.Fatalf("expected 1 entry, got %d", len(j.entries))
+		}
+
+		e := j.entries[0]
+
+		if e.Status != http.StatusCreated || e.RequestID != "req-1" || e.Time.IsZero() || e.WriteError != nil {
+			t.Errorf("unexpected entry %+v", e)
+		}
+
+		if e.Header.Get("Location") != "/payments/p1" || e.Header.Get("Content-Type") != JSONContentType {
+			t.Errorf("expected the journaled headers, got %v", e.Header)
+		}
+
+		if e.Header.Get("X-Secret") != "" {
+			t.Errorf("expected the other headers not to be journaled, got %v", e.Header)
+		}
+
+		if want := "sha-256=:" + digestOf(`{"id":"p1"}`) + ":"; e.BodyDigest != want {
+			t.Errorf("expected digest %q, got %q", want, e.BodyDigest)
+		}
+	})
+
+	t.Run("records streams, redirects and write failures", func(t *testing.T) {
+		j := &memoryJournal{}
+		responder := TextResponder(WithJournal(j))
+
+		responder.Send200(httptest.NewRecorder(), strings.NewReader("streamed"))
+		responder.Redirect302(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), "/elsewhere")
+		responder.Send500(failingWriter{httptest.NewRecorder()}, nil, "error")
+
+		if len(j.entries) != 3 {
+			t.Fatalf("expected 3 entries, got %d", len(j.entries))
+		}
+
+		if want := "sha-256=:" + digestOf("streamed") + ":"; j.entries[0].BodyDigest != want {
+			t.Errorf("expected digest %q, got %q", want, j.entries[0].BodyDigest)
+		}
+
+		if j.entries[1].Status != http.StatusFound || j.entries[1].BodyDigest != "" {
+			t.Errorf("unexpected redirect entry %+v", j.entries[1])
+		}
+
+		if j.entries[2].WriteError == nil {
+			t.Error("expected the write failure to be recorded")
+		}
+	})
+
+	t.Run("logs the recording failures", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		j := &memoryJournal{err: errors.New("store unavailable")}
+		w := httptest.NewRecorder()
+
+		TextResponder(WithJournal(j), WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))).Send200(w, "data")
+
+		if w.Body.String() != "data" {
+			t.Errorf("expected the response to be sent, got %q", w.Body.String())
+		}
+
+		if !strings.Contains(logs.String(), "store unavailable") {
+			t.Errorf("expected the failure to be logged, got %q", logs.String())
+		}
+	})
+}
+
+func digestOf(body string) string {
+	sum := sha256.Sum256([]byte(body))
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
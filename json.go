@@ -0,0 +1,85 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// WithJSONIndent pretty-prints the bodies of the JSON responses, each element
+// beginning on a new line starting with the prefix followed by copies of
+// the indent, as json.MarshalIndent does, e.g. WithJSONIndent("", "  ") in
+// development. It applies once the body is formatted, whatever the formatter,
+// the bodies that are not valid JSON being sent untouched.
+func WithJSONIndent(prefix, indent string) OptionsModifier {
+	return func(o *options) {
+		o.jsonIndent = &jsonIndent{prefix: prefix, indent: indent}
+	}
+}
+
+// jsonIndent holds the indentation of the JSON bodies.
+type jsonIndent struct {
+	prefix string
+	indent string
+}
+
+// apply indents the body, or returns it untouched when it is not valid JSON.
+func (i *jsonIndent) apply(body []byte) []byte {
+	b := getBuffer()
+	if err := json.Indent(b, body, i.prefix, i.indent); err != nil {
+		bufferPool.Put(b)
+
+		return body
+	}
+
+	return releaseBytes(b)
+}
+
+// WithJSONEscapeHTML sets whether the characters <, > and & are escaped in
+// the JSON strings, as json.Encoder.SetEscapeHTML does. They are by default,
+// disabling it keeps the URLs and the HTML snippets readable in the responses.
+// It applies to the values marshaled by the JSON responders and to the streams.
+func WithJSONEscapeHTML(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.jsonEscapeHTML = enabled
+	}
+}
+
+// formatJSON is the default data formatter of the JSON responders.
+func (o *options) formatJSON(c any) ([]byte, error) {
+	return formatValue(c, o.marshalJSON)
+}
+
+// marshalJSON marshals the value with the configured encoder settings.
+func (o *options) marshalJSON(v any) ([]byte, error) {
+	e, _ := jsonEncoderPool.Get().(*jsonEncoder)
+
+	return e.encode(v, o.jsonEscapeHTML)
+}
+
+// encodeJSON encodes the value to the writer as formatJSON does,
+// unless it has a dedicated encoding.
+func (o *options) encodeJSON(w io.Writer, c any) (bool, error) {
+	if !marshaled(c) {
+		return false, nil
+	}
+
+	enc := json.NewEncoder(newlineTrimmer{w})
+	enc.SetEscapeHTML(o.jsonEscapeHTML)
+
+	return true, enc.Encode(c)
+}
+
+// newlineTrimmer drops the newline json.Encoder writes after each value,
+// which it writes along with the value.
+type newlineTrimmer struct {
+	w io.Writer
+}
+
+func (t newlineTrimmer) Write(p []byte) (int, error) {
+	if _, err := t.w.Write(bytes.TrimSuffix(p, []byte("\n"))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,97 @@
+package responder
+
+import (
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithJSONIndent(t *testing.T) {
+	t.Run("pretty-prints the JSON bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONIndent("", "  ")).Send200(w, map[string]any{"id": 1, "tags": []string{"a"}})
+
+		expected := "{\n  \"id\": 1,\n  \"tags\": [\n    \"a\"\n  ]\n}"
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+
+		if v := w.Header().Get("Content-Length"); v != strconv.Itoa(len(expected)) {
+			t.Errorf("expected the Content-Length of the indented body, got %q", v)
+		}
+	})
+
+	t.Run("pretty-prints the error bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONIndent(">", "\t")).Send404(w, nil, "missing")
+
+		if expected := "{\n>\t\"error\": \"missing\"\n>}"; w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("leaves invalid JSON and other content types untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONIndent("", "  ")).Send200(w, "not json")
+
+		if w.Body.String() != "not json" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+
+		TextResponder(WithJSONIndent("", "  ")).Send200(w, `{"id":1}`)
+
+		if w.Body.String() != `{"id":1}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
+
+func TestWithJSONEscapeHTML(t *testing.T) {
+	data := map[string]string{"url": "/search?q=a&page=2", "html": "<b>bold</b>"}
+
+	t.Run("escapes HTML by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send200(w, data)
+
+		expected := `{"html":"\u003cb\u003ebold\u003c/b\u003e","url":"/search?q=a\u0026page=2"}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("leaves HTML unescaped when disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONEscapeHTML(false)).Send200(w, data)
+
+		expected := `{"html":"<b>bold</b>","url":"/search?q=a&page=2"}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+
+		JSONResponder(WithJSONEscapeHTML(false)).Send400(w, nil, "a & b")
+
+		if w.Body.String() != `{"error":"a & b"}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("applies to streams", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONEscapeHTML(false)).SendSeq(w, slices.Values([]any{"a&b"}))
+
+		if w.Body.String() != "\"a&b\"\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONPatchContentType is the content type for JSON Patch documents (RFC 6902).
+const JSONPatchContentType = "application/json-patch+json"
+
+// PatchOperation is an operation of a JSON Patch document (RFC 6902).
+type PatchOperation struct {
+	// Op is the operation: add, remove, replace, move, copy or test.
+	Op string
+	// Path is the JSON Pointer (RFC 6901) of the target location.
+	Path string
+	// From is the JSON Pointer of the source location of the move and copy operations.
+	From string
+	// Value is the value of the add, replace and test operations.
+	Value any
+}
+
+// MarshalJSON encodes the operation, the value being encoded, even when nil,
+// for the operations requiring it.
+func (p PatchOperation) MarshalJSON() ([]byte, error) {
+	op := struct {
+		Op    string          `json:"op"`
+		From  string          `json:"from,omitempty"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}{Op: p.Op, Path: p.Path}
+
+	switch p.Op {
+	case "add", "replace", "test":
+		value, err := json.Marshal(p.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		op.Value = value
+	case "move", "copy":
+		op.From = p.From
+	}
+
+	return json.Marshal(op)
+}
+
+// JSONPatch creates a 200 OK Response sending the operations as a JSON Patch
+// document, e.g. the operations computed by DiffJSON, for bandwidth-sensitive
+// synchronization APIs sending the changes of a representation rather than
+// the whole of it.
+func JSONPatch(ops []PatchOperation) Response {
+	if ops == nil {
+		ops = []PatchOperation{}
+	}
+
+	return SuccessResponse{
+		status:      http.StatusOK,
+		body:        ops,
+		contentType: JSONPatchContentType,
+	}
+}
+
+// DiffJSON returns the operations turning the JSON representation of
+// the previous value into the one of the current value. The objects are
+// compared member by member and the arrays of the same length element by
+// element, the arrays whose length changed being replaced as a whole.
+func DiffJSON(previous, current any) ([]PatchOperation, error) {
+	a, err := decodeJSONTree(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := decodeJSONTree(current)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffJSON(nil, "", a, b), nil
+}
+
+// decodeJSONTree returns the generic JSON representation of the value.
+func decodeJSONTree(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func diffJSON(ops []PatchOperation, path string, a, b any) []PatchOperation {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(av)) {
+			if _, ok := bv[k]; !ok {
+				ops = append(ops, PatchOperation{Op: "remove", Path: path + "/" + escapePointer(k)})
+			}
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(bv)) {
+			p := path + "/" + escapePointer(k)
+
+			if v, ok := av[k]; ok {
+				ops = diffJSON(ops, p, v, bv[k])
+			} else {
+				ops = append(ops, PatchOperation{Op: "add", Path: p, Value: bv[k]})
+			}
+		}
+
+		return ops
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			break
+		}
+
+		for i := range av {
+			ops = diffJSON(ops, path+"/"+strconv.Itoa(i), av[i], bv[i])
+		}
+
+		return ops
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return ops
+	}
+
+	return append(ops, PatchOperation{Op: "replace", Path: path, Value: b})
+}
+
+// escapePointer escapes the reference token of a JSON Pointer.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,90 @@
+package responder
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	type user struct {
+		Name  string            `json:"name"`
+		Email *string           `json:"email"`
+		Tags  []string          `json:"tags"`
+		Meta  map[string]string `json:"meta,omitempty"`
+	}
+
+	email := "ann@example.com"
+	tests := []struct {
+		name     string
+		previous any
+		current  any
+		expected string
+	}{
+		{
+			name:     "identical values",
+			previous: user{Name: "Ann", Tags: []string{"a"}},
+			current:  user{Name: "Ann", Tags: []string{"a"}},
+			expected: `[]`,
+		},
+		{
+			name:     "changed members",
+			previous: user{Name: "Ann", Email: &email, Tags: []string{"a", "b"}, Meta: map[string]string{"a/b": "1", "c~d": "2"}},
+			current:  user{Name: "Bob", Tags: []string{"a", "c"}, Meta: map[string]string{"c~d": "3"}},
+			expected: `[{"op":"replace","path":"/email","value":null},` +
+				`{"op":"remove","path":"/meta/a~1b"},` +
+				`{"op":"replace","path":"/meta/c~0d","value":"3"},` +
+				`{"op":"replace","path":"/name","value":"Bob"},` +
+				`{"op":"replace","path":"/tags/1","value":"c"}]`,
+		},
+		{
+			name:     "added members and resized arrays",
+			previous: map[string]any{"items": []int{1}},
+			current:  map[string]any{"items": []int{1, 2}, "total": 2},
+			expected: `[{"op":"replace","path":"/items","value":[1,2]},{"op":"add","path":"/total","value":2}]`,
+		},
+		{
+			name:     "replaced document",
+			previous: []int{1},
+			current:  "new",
+			expected: `[{"op":"replace","path":"","value":"new"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := DiffJSON(tt.previous, tt.current)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			JSONResponder().Send(w, JSONPatch(ops))
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("expected patch %s, got %s", tt.expected, w.Body.String())
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != JSONPatchContentType {
+				t.Errorf("expected Content-Type %q, got %q", JSONPatchContentType, ct)
+			}
+		})
+	}
+
+	t.Run("reports the values that cannot be encoded", func(t *testing.T) {
+		if _, err := DiffJSON(make(chan int), 1); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestPatchOperation(t *testing.T) {
+	data, _ := json.Marshal([]PatchOperation{
+		{Op: "move", From: "/a", Path: "/b"},
+		{Op: "test", Path: "/c", Value: false},
+	})
+
+	if string(data) != `[{"op":"move","from":"/a","path":"/b"},{"op":"test","path":"/c","value":false}]` {
+		t.Errorf("unexpected operations %s", data)
+	}
+}
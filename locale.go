@@ -0,0 +1,162 @@
+package responder
+
+import (
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// Locale holds the regional conventions used to format numbers and dates
+// in the textual exports, e.g. CSV files opened in spreadsheets.
+type Locale struct {
+	// Tag is the BCP 47 language tag of the locale, e.g. "fr-FR".
+	Tag string
+	// DecimalSeparator separates the integer part of the numbers from their fraction.
+	DecimalSeparator string
+	// GroupSeparator separates the groups of thousands.
+	GroupSeparator string
+	// DateLayout is the layout of the dates, as accepted by time.Time.Format.
+	DateLayout string
+}
+
+// WithLocale formats the floating-point numbers and the dates of the CSV and XLSX
+// exports with the conventions of the locale, the XLSX numbers being stored as such
+// and only their text cells being affected. When other locales are available, the
+// locale is negotiated against the Accept-Language header of the request the responder
+// is bound to with WithRequest, see NegotiateLocale, the first locale being the fallback.
+func WithLocale(fallback Locale, available ...Locale) OptionsModifier {
+	return func(o *options) {
+		o.locales = append([]Locale{fallback}, available...)
+	}
+}
+
+// Common locales.
+var (
+	LocaleEnUS = Locale{Tag: "en-US", DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "01/02/2006"}
+	LocaleEnGB = Locale{Tag: "en-GB", DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "02/01/2006"}
+	LocaleFrFR = Locale{Tag: "fr-FR", DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "02/01/2006"}
+	LocaleDeDE = Locale{Tag: "de-DE", DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "02.01.2006"}
+)
+
+// FormatNumber formats the number with the given number of decimals.
+func (l Locale) FormatNumber(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+
+		b.WriteRune(c)
+	}
+
+	if fraction != "" {
+		b.WriteString(l.DecimalSeparator)
+		b.WriteString(fraction)
+	}
+
+	return b.String()
+}
+
+// FormatTime formats the date with the layout of the locale.
+func (l Locale) FormatTime(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// NegotiateLocale returns the locale among the available ones best matching
+// the Accept-Language header of the request, or the fallback when none matches.
+func NegotiateLocale(r *http.Request, fallback Locale, available ...Locale) Locale {
+	tags := make([]string, len(available))
+	for i, l := range available {
+		tags[i] = l.Tag
+	}
+
+	i := internal.MatchLanguage(internal.ParseAcceptLanguage(r.Header.Get("Accept-Language")), tags)
+	if i < 0 {
+		return fallback
+	}
+
+	return available[i]
+}
+
+// locale returns the locale of the exports, negotiated with the request the responder
+// is bound to, or nil when no locale is set.
+func (r *responder) locale() *Locale {
+	locales := r.options.locales
+	if len(locales) == 0 {
+		return nil
+	}
+
+	l := locales[0]
+	if len(locales) > 1 && r.request != nil {
+		l = NegotiateLocale(r.request, l, locales...)
+	}
+
+	return &l
+}
+
+// encoder returns the encoder of the data, formatting them with
+// the conventions of the locale when the encoder supports it.
+func (r *responder) encoder() Encoder {
+	f, ok := r.options.encoder.(formatterEncoder)
+	if !ok || f.localized == nil {
+		return r.options.encoder
+	}
+
+	l := r.locale()
+	if l == nil {
+		return f
+	}
+
+	f.format = func(c any) ([]byte, error) {
+		return f.localized(l, c)
+	}
+
+	return f
+}
+
+// varyLocale adds Accept-Language to the Vary header of the responses
+// whose format depends on the negotiated locale.
+func (r *responder) varyLocale(h http.Header) {
+	if len(r.options.locales) < 2 || r.request == nil {
+		return
+	}
+
+	if f, ok := r.options.encoder.(formatterEncoder); ok && f.localized != nil {
+		addVary(h, "Accept-Language")
+	}
+}
+
+// formatLocaleValue returns the text of the float or date value formatted
+// with the conventions of the locale, reporting whether the value is one.
+func formatLocaleValue(l *Locale, v reflect.Value) (string, bool) {
+	if l == nil {
+		return "", false
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return l.FormatTime(t), true
+	}
+
+	if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+		return l.FormatNumber(v.Float(), -1), true
+	}
+
+	return "", false
+}
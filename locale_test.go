@@ -0,0 +1,128 @@
+package responder
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocale(t *testing.T) {
+	t.Run("formats numbers", func(t *testing.T) {
+		testCases := []struct {
+			locale   Locale
+			value    float64
+			decimals int
+			want     string
+		}{
+			{locale: LocaleEnUS, value: 1234567.891, decimals: 2, want: "1,234,567.89"},
+			{locale: LocaleDeDE, value: 1234567.891, decimals: 2, want: "1.234.567,89"},
+			{locale: LocaleFrFR, value: -1234.5, decimals: 1, want: "-1 234,5"},
+			{locale: LocaleEnUS, value: 999, decimals: 0, want: "999"},
+			{locale: LocaleEnUS, value: -0.001, decimals: 2, want: "0.00"},
+			{locale: LocaleEnUS, value: math.Inf(1), decimals: 2, want: "+Inf"},
+		}
+
+		for _, tc := range testCases {
+			if got := tc.locale.FormatNumber(tc.value, tc.decimals); got != tc.want {
+				t.Errorf("%s: expected %q, got %q", tc.locale.Tag, tc.want, got)
+			}
+		}
+	})
+
+	t.Run("formats dates", func(t *testing.T) {
+		d := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+		for l, want := range map[Locale]string{LocaleEnUS: "03/14/2026", LocaleEnGB: "14/03/2026", LocaleDeDE: "14.03.2026"} {
+			if got := l.FormatTime(d); got != want {
+				t.Errorf("%s: expected %q, got %q", l.Tag, want, got)
+			}
+		}
+	})
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	testCases := []struct {
+		header string
+		want   string
+	}{
+		{header: "de-AT, en;q=0.5", want: "de-DE"},
+		{header: "en-GB", want: "en-GB"},
+		{header: "ja", want: "en-US"},
+		{header: "", want: "en-US"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/export.csv", nil)
+			req.Header.Set("Accept-Language", tc.header)
+
+			if got := NegotiateLocale(req, LocaleEnUS, LocaleEnGB, LocaleFrFR, LocaleDeDE); got.Tag != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got.Tag)
+			}
+		})
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	type row struct {
+		Amount float64   `csv:"amount"`
+		Date   time.Time `csv:"date"`
+		Count  int       `csv:"count"`
+	}
+
+	rows := []row{{Amount: 1234.5, Date: time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC), Count: 1234}}
+
+	t.Run("formats the CSV fields with the locale", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		CSVResponder(WithLocale(LocaleFrFR), WithCSVDelimiter(';')).Send200(w, rows)
+
+		if want := "amount;date;count\n1\u202f234,5;14/03/2026;1234\n"; w.Body.String() != want {
+			t.Errorf("expected %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("negotiates the locale with the request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/export.csv", nil)
+		req.Header.Set("Accept-Language", "de-AT")
+
+		w := httptest.NewRecorder()
+
+		CSVResponder(WithLocale(LocaleEnUS, LocaleDeDE), WithCSVDelimiter(';')).WithRequest(req).Send200(w, rows)
+
+		if want := "amount;date;count\n1.234,5;14.03.2026;1234\n"; w.Body.String() != want {
+			t.Errorf("expected %q, got %q", want, w.Body.String())
+		}
+
+		if v := w.Header().Get("Vary"); v != "Accept-Language" {
+			t.Errorf("expected Vary Accept-Language, got %q", v)
+		}
+	})
+
+	t.Run("formats the XLSX text cells with the locale", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		XLSXResponder(WithLocale(LocaleDeDE)).Send200(w, rows)
+
+		sheet := readSheet(t, w.Body.Bytes())
+
+		for _, want := range []string{`<c r="A2"><v>1234.5</v></c>`, `<t xml:space="preserve">14.03.2026</t>`} {
+			if !strings.Contains(sheet, want) {
+				t.Errorf("expected the sheet to contain %q, got %q", want, sheet)
+			}
+		}
+	})
+
+	t.Run("leaves the other responders untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithLocale(LocaleFrFR, LocaleDeDE)).WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).Send200(w, rows)
+
+		if !strings.Contains(w.Body.String(), `"Amount":1234.5`) || w.Header().Get("Vary") != "" {
+			t.Errorf("expected the JSON response to be left untouched, got %q and Vary %q", w.Body.String(), w.Header().Get("Vary"))
+		}
+	})
+}
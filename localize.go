@@ -0,0 +1,99 @@
+package responder
+
+import "github.com/mickaelvieira/responder/internal"
+
+// Localizer translates the message key into the language, returning
+// an empty string when no translation is available.
+type Localizer func(lang, key string) string
+
+// WithLocalizer translates the client messages of the error responses, i.e. the string
+// messages and the messages of the SyntaxMessage and ValidationMessage values,
+// into the language of the Accept-Language header of the request the responder
+// is bound to with WithRequest. The header is negotiated against the available
+// languages, the first one being the default, or its preferred language is used
+// when none is given. The messages without translation are sent as is.
+// The error responses carry the Content-Language of the translations
+// when at least one message is translated.
+func WithLocalizer(l Localizer, languages ...string) OptionsModifier {
+	return func(o *options) {
+		o.localizer = l
+		o.languages = languages
+	}
+}
+
+// language returns the language of the request, or an empty string when unknown.
+func (r *responder) language() string {
+	var ranges []internal.LanguageRange
+	if r.request != nil {
+		ranges = internal.ParseAcceptLanguage(r.request.Header.Get("Accept-Language"))
+	}
+
+	if len(r.options.languages) > 0 {
+		if i := internal.MatchLanguage(ranges, r.options.languages); i >= 0 {
+			return r.options.languages[i]
+		}
+
+		return r.options.languages[0]
+	}
+
+	for _, rg := range ranges {
+		if rg.Tag != "*" {
+			return rg.Tag
+		}
+	}
+
+	return ""
+}
+
+// localize translates the message, setting the Content-Language
+// of the response when at least one of its keys is translated.
+func (r *responder) localize(rw responseWriter, message any) any {
+	if r.options.localizer == nil {
+		return message
+	}
+
+	if r.request != nil {
+		addVary(rw.Header(), "Accept-Language")
+	}
+
+	lang := r.language()
+	if lang == "" {
+		return message
+	}
+
+	translated := false
+
+	translate := func(key string) string {
+		if s := r.options.localizer(lang, key); s != "" {
+			translated = true
+
+			return s
+		}
+
+		return key
+	}
+
+	switch m := message.(type) {
+	case string:
+		message = translate(m)
+	case SyntaxMessage:
+		m.Message = translate(m.Message)
+		message = m
+	case ValidationMessage:
+		fields := make([]FieldError, len(m.Fields))
+		for i, f := range m.Fields {
+			fields[i] = FieldError{Field: f.Field, Message: translate(f.Message)}
+		}
+
+		m.Message, m.Fields = translate(m.Message), fields
+		message = m
+	default:
+		return message
+	}
+
+	if translated && rw.Header().Get("Content-Language") == "" {
+		rw.Header().Set("Content-Language", lang)
+	}
+
+	return message
+}
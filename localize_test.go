@@ -0,0 +1,106 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithLocalizer(t *testing.T) {
+	translations := map[string]map[string]string{
+		"fr": {
+			"not_found":         "introuvable",
+			"validation failed": "validation échouée",
+			"required":          "obligatoire",
+		},
+		"de": {
+			"not_found": "nicht gefunden",
+		},
+	}
+
+	localizer := func(lang, key string) string {
+		return translations[lang][key]
+	}
+
+	newRequest := func(header string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set("Accept-Language", header)
+		}
+
+		return req
+	}
+
+	t.Run("translates the messages in the negotiated language", func(t *testing.T) {
+		tests := []struct {
+			header   string
+			expected string
+			language string
+		}{
+			{"fr-CH, de;q=0.8", "introuvable", "fr"},
+			{"de", "nicht gefunden", "de"},
+			{"es", "not_found", ""},
+			{"", "not_found", ""},
+		}
+
+		for _, tc := range tests {
+			w := httptest.NewRecorder()
+
+			TextResponder(WithLocalizer(localizer, "en", "fr", "de")).
+				WithRequest(newRequest(tc.header)).
+				Send404(w, errors.New("no row"), "not_found")
+
+			if w.Body.String() != tc.expected {
+				t.Errorf("expected body %q for %q, got %q", tc.expected, tc.header, w.Body.String())
+			}
+
+			if v := w.Header().Get("Content-Language"); v != tc.language {
+				t.Errorf("expected Content-Language %q for %q, got %q", tc.language, tc.header, v)
+			}
+
+			if v := w.Header().Get("Vary"); v != "Accept-Language" {
+				t.Errorf("expected Vary %q, got %q", "Accept-Language", v)
+			}
+		}
+	})
+
+	t.Run("uses the preferred language without available languages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithLocalizer(localizer)).
+			WithRequest(newRequest("de, fr;q=0.9")).
+			Send404(w, nil, "not_found")
+
+		if w.Body.String() != "nicht gefunden" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("translates the validation messages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithLocalizer(localizer, "en", "fr")).
+			WithRequest(newRequest("fr")).
+			SendValidation(w, []FieldError{{Field: "email", Message: "required"}})
+
+		expected := `{"error":"validation échouée","errors":[{"field":"email","message":"obligatoire"}]}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("leaves the messages untouched without request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithLocalizer(localizer)).Send404(w, nil, "not_found")
+
+		if w.Body.String() != "not_found" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+
+		if v := w.Header().Get("Content-Language"); v != "" {
+			t.Errorf("expected no Content-Language, got %q", v)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package responder
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// MarkdownRenderer converts Markdown to HTML. The renderers are expected
+// to sanitize their output, which is sent as is.
+type MarkdownRenderer interface {
+	// Render returns the HTML of the Markdown source.
+	Render(src []byte) ([]byte, error)
+}
+
+// MarkdownRendererFunc is an adapter allowing functions to be used as MarkdownRenderer.
+type MarkdownRendererFunc func(src []byte) ([]byte, error)
+
+// Render calls f(src).
+func (f MarkdownRendererFunc) Render(src []byte) ([]byte, error) {
+	return f(src)
+}
+
+// DefaultMarkdownRenderer renders the headings, paragraphs, lists, block quotes,
+// fenced code blocks, thematic breaks, emphasis, code spans, inline links and images
+// of the source, a subset of CommonMark. The raw HTML is escaped and the links and
+// images with a scheme other than http, https or mailto are rendered as text.
+var DefaultMarkdownRenderer MarkdownRenderer = MarkdownRendererFunc(func(src []byte) ([]byte, error) {
+	return []byte(internal.RenderMarkdown(string(src))), nil
+})
+
+// MarkdownPage is the data the Markdown layouts are executed with.
+type MarkdownPage struct {
+	// Title is the text of the first heading of the document.
+	Title string
+	// Content is the rendered document.
+	Content template.HTML
+}
+
+// markdownOptions holds the configuration of the Markdown responders.
+type markdownOptions struct {
+	renderer MarkdownRenderer
+	layout   *template.Template
+	err      error
+}
+
+// MarkdownResponder creates a new responder sending the Markdown documents, e.g.
+// docs or changelog files, as HTML pages rendered by DefaultMarkdownRenderer
+// unless another renderer is set with WithMarkdownRenderer.
+// Strings, byte slices and fmt.Stringer values are rendered as Markdown,
+// template.HTML values are sent as is and the other values are formatted
+// by the default data formatter before being rendered.
+func MarkdownResponder(options ...OptionsModifier) Responder {
+	o := []OptionsModifier{markdownDefaults}
+	o = append(o, options...)
+
+	return New(HTMLContentType, o...)
+}
+
+// markdownDefaults sets the formatters of the Markdown responders.
+func markdownDefaults(o *options) {
+	o.markdown.renderer = DefaultMarkdownRenderer
+	o.errorFormatter = markdownErrorFormatter
+	o.encoder = formatterEncoder{contentType: HTMLContentType, format: o.formatMarkdown}
+}
+
+// WithMarkdownRenderer sets the renderer of the Markdown responders.
+func WithMarkdownRenderer(renderer MarkdownRenderer) OptionsModifier {
+	return func(o *options) {
+		o.markdown.renderer = renderer
+	}
+}
+
+// WithMarkdownLayout wraps the pages of the Markdown responders in the
+// html/template layout, executed with a MarkdownPage. A layout that
+// cannot be parsed makes the responses fail to be formatted.
+func WithMarkdownLayout(layout string) OptionsModifier {
+	return func(o *options) {
+		o.markdown.layout, o.markdown.err = template.New("layout").Parse(layout)
+	}
+}
+
+// formatMarkdown is the data formatter of the Markdown responders.
+func (o *options) formatMarkdown(c any) ([]byte, error) {
+	if o.markdown.err != nil {
+		return nil, o.markdown.err
+	}
+
+	content, ok := c.(template.HTML)
+	if !ok {
+		src, err := formatText(c)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := o.markdown.renderer.Render(src)
+		if err != nil {
+			return nil, err
+		}
+
+		content = template.HTML(b)
+	}
+
+	if o.markdown.layout == nil {
+		return []byte(content), nil
+	}
+
+	var b bytes.Buffer
+
+	err := o.markdown.layout.Execute(&b, MarkdownPage{Title: markdownTitle(string(content)), Content: content})
+	if err != nil {
+		return nil, fmt.Errorf("markdown layout: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// markdownErrorFormatter is the default error formatter of the Markdown responders,
+// sending the validation errors as the HTML responders do.
+func markdownErrorFormatter(message any) any {
+	if _, ok := validationMessage(message); ok {
+		return template.HTML(htmlErrorFormatter(message).(string))
+	}
+
+	return stringFormatter(message)
+}
+
+// markdownTitle returns the text of the first heading of the HTML.
+func markdownTitle(content string) string {
+	_, rest, ok := strings.Cut(content, "<h")
+	if !ok || rest == "" || rest[0] < '1' || rest[0] > '6' {
+		return ""
+	}
+
+	_, rest, _ = strings.Cut(rest, ">")
+	title, _, _ := strings.Cut(rest, "</h")
+
+	return textContent(title)
+}
+
+// textContent returns the text of the HTML fragment.
+func textContent(s string) string {
+	var b strings.Builder
+
+	inTag := false
+	for _, c := range s {
+		switch {
+		case c == '<':
+			inTag = true
+		case c == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(c)
+		}
+	}
+
+	return html.UnescapeString(b.String())
+}
@@ -0,0 +1,104 @@
+package responder
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownResponder(t *testing.T) {
+	t.Run("renders the documents as HTML", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		MarkdownResponder().Send200(w, "# Changelog\n\n- fixed <things>")
+
+		want := "<h1>Changelog</h1>\n<ul>\n<li>fixed &lt;things&gt;</li>\n</ul>\n"
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %q, got %q", want, b)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != HTMLContentType {
+			t.Errorf("expected Content-Type %q, got %q", HTMLContentType, ct)
+		}
+	})
+
+	t.Run("sends template.HTML values as is", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		MarkdownResponder().Send200(w, template.HTML("<b>*raw*</b>"))
+
+		if b := w.Body.String(); b != "<b>*raw*</b>" {
+			t.Errorf("expected body %q, got %q", "<b>*raw*</b>", b)
+		}
+	})
+
+	t.Run("uses the custom renderer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		renderer := MarkdownRendererFunc(func(src []byte) ([]byte, error) {
+			return []byte("<article>" + strings.ToUpper(string(src)) + "</article>"), nil
+		})
+
+		MarkdownResponder(WithMarkdownRenderer(renderer)).Send200(w, "docs")
+
+		if b := w.Body.String(); b != "<article>DOCS</article>" {
+			t.Errorf("expected body %q, got %q", "<article>DOCS</article>", b)
+		}
+	})
+
+	t.Run("wraps the pages in the layout", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		layout := `<title>{{.Title}}</title><main>{{.Content}}</main>`
+
+		MarkdownResponder(WithMarkdownLayout(layout)).Send200(w, "# Q&A\n\ntext")
+
+		want := "<title>Q&amp;A</title><main><h1>Q&amp;A</h1>\n<p>text</p>\n</main>"
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %q, got %q", want, b)
+		}
+	})
+
+	t.Run("reports the invalid layouts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(MarkdownResponder(WithMarkdownLayout("{{.Content"))).Send200(w, "# Title")
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+
+	t.Run("reports the renderer failures", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		renderer := MarkdownRendererFunc(func([]byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		})
+
+		err := Checked(MarkdownResponder(WithMarkdownRenderer(renderer))).Send200(w, "# Title")
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+
+	t.Run("renders the error messages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		MarkdownResponder().Send404(w, nil, "page <b>not</b> found")
+
+		if b := w.Body.String(); b != "<p>page &lt;b&gt;not&lt;/b&gt; found</p>\n" {
+			t.Errorf("unexpected body %q", b)
+		}
+	})
+
+	t.Run("sends the validation errors as lists", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		MarkdownResponder().SendValidation(w, []FieldError{{Field: "email", Message: "is required"}})
+
+		want := `<ul class="errors"><li data-field="email">is required</li></ul>`
+		if w.Code != http.StatusUnprocessableEntity || w.Body.String() != want {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+}
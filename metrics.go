@@ -0,0 +1,69 @@
+package responder
+
+import (
+	"context"
+	"time"
+)
+
+// ResponseMetrics describes a response sent by a responder.
+type ResponseMetrics struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// ContentType is the media type of the response, without its parameters.
+	ContentType string
+	// BodySize is the number of bytes of the body written to the client.
+	BodySize int
+	// Serialization is the time spent formatting the data or the error message,
+	// or writing the body of the streams and the direct encodings. It is zero
+	// for the redirects and the files.
+	Serialization time.Duration
+	// Duration is the time spent sending the response, from the formatting
+	// of the data or the error message to the write of the body.
+	Duration time.Duration
+	// TraceID is the ID of the trace of the request when tracing is enabled,
+	// e.g. to be attached to the histograms as an exemplar, see WithTracing.
+	TraceID string
+}
+
+// MetricsRecorder records the metrics of the responses, e.g. in Prometheus
+// or OpenTelemetry instruments. It must be safe for concurrent use.
+type MetricsRecorder interface {
+	// RecordResponse records the metrics of a response. The context is the one
+	// of the request the responder is bound to, e.g. to attach the trace ID
+	// of the active span as an exemplar, or the background context.
+	RecordResponse(ctx context.Context, m ResponseMetrics)
+}
+
+// WithMetrics reports the metrics of the responses to the recorder.
+// The responder being the single choke point of the responses, it spares
+// instrumenting the handlers one by one. All the responses are recorded,
+// the redirects, the files, the streams and the direct encodings included.
+// Recorders for Prometheus and OpenTelemetry are provided by the
+// github.com/mickaelvieira/responder/prometheus and
+// github.com/mickaelvieira/responder/otel modules.
+func WithMetrics(rec MetricsRecorder) OptionsModifier {
+	return func(o *options) {
+		o.metrics = rec
+	}
+}
+
+// recordMetrics reports the metrics of the response to the recorder, if any.
+func (r *responder) recordMetrics(rw responseWriter, code, n int, serialization, duration time.Duration) {
+	if r.options.metrics == nil {
+		return
+	}
+
+	m := ResponseMetrics{
+		Status:        code,
+		ContentType:   mediaType(rw.Header().Get("Content-Type")),
+		BodySize:      n,
+		Serialization: serialization,
+		Duration:      duration,
+	}
+
+	if span := r.span(); span != nil {
+		m.TraceID = span.TraceID()
+	}
+
+	r.options.metrics.RecordResponse(r.context(), m)
+}
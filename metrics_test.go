@@ -0,0 +1,108 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type metricsKey struct{}
+
+type memoryMetrics struct {
+	mu       sync.Mutex
+	metrics  []ResponseMetrics
+	contexts []context.Context
+}
+
+func (m *memoryMetrics) RecordResponse(ctx context.Context, rm ResponseMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.metrics = append(m.metrics, rm)
+	m.contexts = append(m.contexts, ctx)
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Run("records the status, content type and body size", func(t *testing.T) {
+		rec := &memoryMetrics{}
+		responder := JSONResponder(WithMetrics(rec))
+
+		responder.Send200(httptest.NewRecorder(), map[string]int{"id": 1})
+		responder.Send500(httptest.NewRecorder(), errors.New("boom"), "error")
+		responder.Send204(httptest.NewRecorder())
+
+		if len(rec.metrics) != 3 {
+			t.Fatalf("expected 3 records, got %d", len(rec.metrics))
+		}
+
+		expected := []ResponseMetrics{
+			{Status: http.StatusOK, ContentType: "application/json", BodySize: len(`{"id":1}`)},
+			{Status: http.StatusInternalServerError, ContentType: "application/json", BodySize: len(`{"error":"error"}`)},
+			{Status: http.StatusNoContent, ContentType: "application/json"},
+		}
+
+		for i, m := range rec.metrics {
+			if m.Serialization < 0 || m.Duration < m.Serialization {
+				t.Errorf("unexpected durations %v and %v", m.Serialization, m.Duration)
+			}
+
+			m.Serialization, m.Duration = 0, 0
+			if m != expected[i] {
+				t.Errorf("expected %+v, got %+v", expected[i], m)
+			}
+		}
+	})
+
+	t.Run("records the streams and the direct encodings", func(t *testing.T) {
+		rec := &memoryMetrics{}
+		responder := JSONResponder(WithMetrics(rec), WithDirectEncoding(true))
+
+		responder.Send200(httptest.NewRecorder(), strings.NewReader("report"))
+		responder.SendSeq(httptest.NewRecorder(), slices.Values([]any{1, 2}))
+		responder.Send200(httptest.NewRecorder(), []int{1, 2})
+
+		expected := []ResponseMetrics{
+			{Status: http.StatusOK, ContentType: "application/json", BodySize: len("report")},
+			{Status: http.StatusOK, ContentType: NDJSONContentType, BodySize: len("1\n2\n")},
+			{Status: http.StatusOK, ContentType: "application/json", BodySize: len("[1,2]")},
+		}
+
+		if len(rec.metrics) != len(expected) {
+			t.Fatalf("expected %d records, got %d", len(expected), len(rec.metrics))
+		}
+
+		for i, m := range rec.metrics {
+			m.Serialization, m.Duration = 0, 0
+			if m != expected[i] {
+				t.Errorf("expected %+v, got %+v", expected[i], m)
+			}
+		}
+	})
+
+	t.Run("records the final status code", func(t *testing.T) {
+		rec := &memoryMetrics{}
+
+		JSONResponder(WithMetrics(rec), WithEmptyBodyAs204(true)).Send200(httptest.NewRecorder(), nil)
+
+		if len(rec.metrics) != 1 || rec.metrics[0].Status != http.StatusNoContent {
+			t.Errorf("unexpected records %+v", rec.metrics)
+		}
+	})
+
+	t.Run("passes the request context", func(t *testing.T) {
+		rec := &memoryMetrics{}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), metricsKey{}, "trace-1"))
+
+		TextResponder(WithMetrics(rec)).WithRequest(req).Send200(httptest.NewRecorder(), "ok")
+
+		if len(rec.contexts) != 1 || rec.contexts[0].Value(metricsKey{}) != "trace-1" {
+			t.Error("expected the request context to be passed to the recorder")
+		}
+	})
+}
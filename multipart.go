@@ -0,0 +1,180 @@
+package responder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"time"
+)
+
+// Part is a part of a multipart response.
+type Part struct {
+	// ContentType is the content type of the part, the one of the responder when empty.
+	// The body is formatted by the data formatter of the responder when the content
+	// types match, and by the default data formatter of the content type otherwise.
+	ContentType string
+	// Name is the name of the form field of the multipart/form-data responses.
+	Name string
+	// Filename is the name the part is saved under, sent in its Content-Disposition header.
+	Filename string
+	// Header holds the additional headers of the part, e.g. Content-ID.
+	Header http.Header
+	// Body is the data of the part. The readers are copied as is.
+	Body any
+}
+
+// MultipartResponse is a multipart/mixed response, or multipart/form-data
+// when sent with that content type, see WithContentType.
+// The parts are formatted before anything is written, the boundary
+// being set in the Content-Type header.
+type MultipartResponse struct {
+	status       int
+	parts        []Part
+	header       http.Header
+	contentType  string
+	cacheControl *CacheControl
+}
+
+// Multipart creates a new multipart/mixed Response with the given status code and parts.
+func Multipart(status int, parts ...Part) MultipartResponse {
+	return MultipartResponse{status: status, parts: parts}
+}
+
+// Status returns the HTTP status code of the multipart response.
+func (r MultipartResponse) Status() int {
+	return r.status
+}
+
+// WithPart returns a copy of the multipart response with the additional part.
+func (r MultipartResponse) WithPart(part Part) MultipartResponse {
+	r.parts = append(r.parts[:len(r.parts):len(r.parts)], part)
+
+	return r
+}
+
+// WithHeader returns a copy of the multipart response carrying the additional header.
+func (r MultipartResponse) WithHeader(key, value string) ResponseBuilder {
+	r.header = withHeader(r.header, key, value)
+
+	return r
+}
+
+// WithCookie returns a copy of the multipart response setting the cookie.
+func (r MultipartResponse) WithCookie(cookie *http.Cookie) ResponseBuilder {
+	r.header = withCookie(r.header, cookie)
+
+	return r
+}
+
+// WithContentType returns a copy of the multipart response sent with the multipart
+// content type, e.g. multipart/form-data, the boundary being added to it.
+func (r MultipartResponse) WithContentType(contentType string) ResponseBuilder {
+	r.contentType = contentType
+
+	return r
+}
+
+// WithCacheControl returns a copy of the multipart response sent with the caching policy.
+func (r MultipartResponse) WithCacheControl(c CacheControl) ResponseBuilder {
+	r.cacheControl = &c
+
+	return r
+}
+
+// sendMultipart formats the parts of the response and sends them.
+func (r *responder) sendMultipart(rw responseWriter, resp MultipartResponse) error {
+	start := time.Now()
+
+	subtype := "multipart/mixed"
+	if resp.contentType != "" {
+		subtype = mediaType(resp.contentType)
+	}
+
+	var b bytes.Buffer
+
+	mw := multipart.NewWriter(&b)
+
+	for _, p := range resp.parts {
+		if err := r.writePart(mw, subtype, p); err != nil {
+			return r.sendFailure(rw, err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return r.sendFailure(rw, err)
+	}
+
+	applyHeader(rw, resp.header)
+
+	ct := mime.FormatMediaType(subtype, map[string]string{"boundary": mw.Boundary()})
+
+	return r.withContentType(ct).withCacheControl(resp.cacheControl).send(rw, resp.status, b.Bytes(), time.Since(start))
+}
+
+// writePart formats the part and writes it.
+func (r *responder) writePart(mw *multipart.Writer, subtype string, p Part) error {
+	pr := r
+	if p.ContentType == "" {
+		p.ContentType = r.contentType
+	} else if p.ContentType != r.contentType {
+		pr = r.partResponder(p.ContentType)
+	}
+
+	h := make(textproto.MIMEHeader, len(p.Header)+2)
+	for k, v := range p.Header {
+		h[textproto.CanonicalMIMEHeaderKey(k)] = v
+	}
+
+	h.Set("Content-Type", p.ContentType)
+
+	switch {
+	case subtype == "multipart/form-data":
+		params := map[string]string{"name": p.Name}
+		if p.Filename != "" {
+			params["filename"] = p.Filename
+		}
+
+		h.Set("Content-Disposition", mime.FormatMediaType("form-data", params))
+	case p.Filename != "":
+		h.Set("Content-Disposition", contentDisposition("attachment", p.Filename))
+	}
+
+	w, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	if rd, ok := p.Body.(io.Reader); ok {
+		if _, err := io.Copy(w, rd); err != nil {
+			return fmt.Errorf("multipart: %w", err)
+		}
+
+		return nil
+	}
+
+	body, err := pr.format(p.Body)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(body)
+
+	return err
+}
+
+// partResponder returns a copy of the responder formatting the data
+// with the default data formatters of the content type.
+func (r *responder) partResponder(contentType string) *responder {
+	o := *r.options
+	o.setFormatters(contentType)
+
+	c := *r
+	c.contentType = contentType
+	c.options = &o
+
+	return &c
+}
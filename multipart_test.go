@@ -0,0 +1,125 @@
+package responder
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// readParts reads the parts of the multipart response.
+func readParts(t *testing.T, w *httptest.ResponseRecorder) (string, []*multipart.Part, []string) {
+	t.Helper()
+
+	mt, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("unexpected Content-Type %q: %v", w.Header().Get("Content-Type"), err)
+	}
+
+	var (
+		parts  []*multipart.Part
+		bodies []string
+	)
+
+	mr := multipart.NewReader(w.Body, params["boundary"])
+
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		b, _ := io.ReadAll(p)
+		parts = append(parts, p)
+		bodies = append(bodies, string(b))
+	}
+
+	return mt, parts, bodies
+}
+
+func TestMultipartResponse(t *testing.T) {
+	t.Run("sends the parts formatted with their content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		resp := Multipart(http.StatusOK,
+			Part{Body: map[string]string{"name": "report"}},
+			Part{ContentType: "text/csv", Body: [][]string{{"a", "b"}}},
+		).WithPart(Part{
+			ContentType: "application/pdf",
+			Filename:    "report.pdf",
+			Header:      http.Header{"Content-Id": {"<report>"}},
+			Body:        strings.NewReader("%PDF-1.7"),
+		})
+
+		JSONResponder().Send(w, resp)
+
+		mt, parts, bodies := readParts(t, w)
+		if mt != "multipart/mixed" {
+			t.Errorf("expected multipart/mixed, got %q", mt)
+		}
+
+		want := []string{`{"name":"report"}`, "a,b\n", "%PDF-1.7"}
+		if strings.Join(bodies, "|") != strings.Join(want, "|") {
+			t.Fatalf("expected bodies %q, got %q", want, bodies)
+		}
+
+		if ct := parts[0].Header.Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("expected the first part to be JSON, got %q", ct)
+		}
+
+		if fn := parts[2].FileName(); fn != "report.pdf" {
+			t.Errorf("expected filename %q, got %q", "report.pdf", fn)
+		}
+
+		if id := parts[2].Header.Get("Content-Id"); id != "<report>" {
+			t.Errorf("expected Content-ID %q, got %q", "<report>", id)
+		}
+	})
+
+	t.Run("sends form data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		resp := Multipart(http.StatusOK,
+			Part{Name: "title", ContentType: TextContentType, Body: "Report"},
+			Part{Name: "file", Filename: "a.txt", ContentType: TextContentType, Body: []byte("content")},
+		).WithContentType("multipart/form-data").WithHeader("X-Batch", "1")
+
+		JSONResponder().Send(w, resp)
+
+		mt, parts, bodies := readParts(t, w)
+		if mt != "multipart/form-data" {
+			t.Errorf("expected multipart/form-data, got %q", mt)
+		}
+
+		if parts[0].FormName() != "title" || parts[1].FormName() != "file" || parts[1].FileName() != "a.txt" {
+			t.Errorf("unexpected parts %v", parts)
+		}
+
+		if bodies[1] != "content" {
+			t.Errorf("unexpected body %q", bodies[1])
+		}
+
+		if v := w.Header().Get("X-Batch"); v != "1" {
+			t.Errorf("expected X-Batch %q, got %q", "1", v)
+		}
+	})
+
+	t.Run("reports the parts that cannot be formatted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(JSONResponder()).Send(w, Multipart(http.StatusOK, Part{Body: make(chan int)}))
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package responder
+
+import (
+	"image"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// Noop returns a Responder that never writes anything.
+// It is useful in tests and as a placeholder for optional dependencies.
+func Noop() Responder {
+	return noop{}
+}
+
+type noop struct{}
+
+func (noop) Send200(responseWriter, any)                                              {}
+func (noop) Send201(responseWriter, any)                                              {}
+func (noop) Send202(responseWriter, any)                                              {}
+func (noop) Send204(responseWriter)                                                   {}
+func (noop) Send205(responseWriter)                                                   {}
+func (noop) Send206(responseWriter, *http.Request, io.ReadSeeker, int64)              {}
+func (noop) Send304(responseWriter)                                                   {}
+func (noop) Redirect301(responseWriter, *http.Request, string)                        {}
+func (noop) Redirect302(responseWriter, *http.Request, string)                        {}
+func (noop) Redirect303(responseWriter, *http.Request, string)                        {}
+func (noop) Redirect307(responseWriter, *http.Request, string)                        {}
+func (noop) Redirect308(responseWriter, *http.Request, string)                        {}
+func (noop) Redirect(responseWriter, *http.Request, string, int)                      {}
+func (noop) Send400(responseWriter, error, any)                                       {}
+func (noop) Send401(responseWriter, error, any)                                       {}
+func (noop) Send403(responseWriter, error, any)                                       {}
+func (noop) Send404(responseWriter, error, any)                                       {}
+func (noop) Send405(responseWriter, error, any)                                       {}
+func (noop) Send406(responseWriter, error, any)                                       {}
+func (noop) Send409(responseWriter, error, any)                                       {}
+func (noop) Send410(responseWriter, error, any)                                       {}
+func (noop) Send415(responseWriter, error, any)                                       {}
+func (noop) Send422(responseWriter, error, any)                                       {}
+func (noop) Send429(responseWriter, error, any, time.Duration)                        {}
+func (noop) Send500(responseWriter, error, any)                                       {}
+func (noop) Send501(responseWriter, error, any)                                       {}
+func (noop) Send502(responseWriter, error, any)                                       {}
+func (noop) Send503(responseWriter, error, any)                                       {}
+func (noop) Send504(responseWriter, error, any)                                       {}
+func (noop) SendError(responseWriter, error)                                          {}
+func (noop) SendValidation(responseWriter, []FieldError)                              {}
+func (noop) SendSuccessStatus(responseWriter, int, any)                               {}
+func (noop) SendErrorStatus(responseWriter, int, error, any)                          {}
+func (noop) SendQueued(responseWriter, int, time.Duration)                            {}
+func (noop) SendPage(responseWriter, Paginated)                                       {}
+func (noop) SendSitemap(responseWriter, []SitemapURL)                                 {}
+func (noop) SendEcho(responseWriter, *http.Request)                                   {}
+func (noop) SendFile(responseWriter, *http.Request, string, io.ReadSeeker, time.Time) {}
+func (noop) SendAttachment(responseWriter, string, any)                               {}
+func (noop) SendBlob(responseWriter, []byte, string)                                  {}
+func (noop) SendImage(responseWriter, image.Image, string)                            {}
+func (noop) SendStream(responseWriter, <-chan any)                                    {}
+func (noop) SendSeq(responseWriter, iter.Seq[any])                                    {}
+func (noop) Send(responseWriter, Response)                                            {}
+
+func (n noop) WithRequest(*http.Request) Responder {
+	return n
+}
+
+func (noop) HandlerFunc(f func(*http.Request) Response) http.Handler {
+	return http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		f(req)
+	})
+}
+
+func (noop) SelfTest() error {
+	return nil
+}
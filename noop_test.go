@@ -0,0 +1,85 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNoop(t *testing.T) {
+	responder := Noop()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	responder.Send200(w, "data")
+	responder.Send204(w)
+	responder.Redirect302(w, req, "/elsewhere")
+	responder.Send500(w, errors.New("boom"), "server error")
+	responder.Send(w, Success(http.StatusOK, "data"))
+
+	if w.Body.Len() != 0 || len(w.Header()) != 0 {
+		t.Errorf("expected nothing to be written, got headers %v and body %q", w.Header(), w.Body.String())
+	}
+}
+
+func TestDefensiveSends(t *testing.T) {
+	t.Run("nil writer is a no-op", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		responder := TextResponder(WithLogger(logger))
+
+		responder.Send200(nil, "data")
+		responder.Send204(nil)
+		responder.Send400(nil, errors.New("bad"), "bad request")
+		responder.Redirect301(nil, httptest.NewRequest(http.MethodGet, "/", nil), "/")
+		responder.Send(nil, Success(http.StatusOK, "data"))
+
+		if !strings.Contains(logs.String(), "nil writer") {
+			t.Errorf("expected the nil writer to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("nil responder is a no-op", func(t *testing.T) {
+		var r *responder
+
+		w := httptest.NewRecorder()
+
+		r.Send200(w, "data")
+		r.Send500(w, errors.New("boom"), "server error")
+		r.Redirect302(w, httptest.NewRequest(http.MethodGet, "/", nil), "/")
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("zero-value responder is a no-op", func(t *testing.T) {
+		r := &responder{}
+		w := httptest.NewRecorder()
+
+		r.Send200(w, "data")
+		r.Send(w, Error(http.StatusBadRequest, errors.New("bad"), "bad request"))
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("nil response is logged", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		w := httptest.NewRecorder()
+
+		TextResponder(WithLogger(logger)).Send(w, nil)
+
+		if !strings.Contains(logs.String(), "nil response") {
+			t.Errorf("expected the nil response to be logged, got %q", logs.String())
+		}
+	})
+}
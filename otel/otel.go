@@ -0,0 +1,80 @@
+// Package otel provides a responder.MetricsRecorder reporting the metrics
+// of the responses to OpenTelemetry instruments, see responder.WithMetrics.
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/mickaelvieira/responder"
+)
+
+// Recorder records the number of responses, the size of their body, the time spent
+// sending them and the time spent formatting them, with the status code and the
+// content type as attributes.
+// The measurements are recorded with the context of the request the responder
+// is bound to, so that the SDK attaches the sampled span of the request to the
+// histograms as an exemplar, linking the slow or failing responses to their trace.
+type Recorder struct {
+	responses     metric.Int64Counter
+	bodySize      metric.Int64Histogram
+	duration      metric.Float64Histogram
+	serialization metric.Float64Histogram
+}
+
+// NewRecorder creates a new Recorder whose instruments are created with the meter.
+func NewRecorder(meter metric.Meter) (*Recorder, error) {
+	responses, err := meter.Int64Counter("responder.responses",
+		metric.WithDescription("Number of responses sent by the responders."),
+		metric.WithUnit("{response}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bodySize, err := meter.Int64Histogram("responder.response.body.size",
+		metric.WithDescription("Size of the body of the responses sent by the responders."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("responder.response.duration",
+		metric.WithDescription("Time spent sending the responses by the responders."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	serialization, err := meter.Float64Histogram("responder.serialization.duration",
+		metric.WithDescription("Time spent formatting the body of the responses sent by the responders."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		responses:     responses,
+		bodySize:      bodySize,
+		duration:      duration,
+		serialization: serialization,
+	}, nil
+}
+
+// RecordResponse records the metrics of the response.
+func (r *Recorder) RecordResponse(ctx context.Context, m responder.ResponseMetrics) {
+	attrs := metric.WithAttributes(
+		attribute.Int("http.response.status_code", m.Status),
+		attribute.String("http.response.content_type", m.ContentType),
+	)
+
+	r.responses.Add(ctx, 1, attrs)
+	r.bodySize.Record(ctx, int64(m.BodySize), attrs)
+	r.duration.Record(ctx, m.Duration.Seconds(), attrs)
+	r.serialization.Record(ctx, m.Serialization.Seconds(), attrs)
+}
@@ -0,0 +1,144 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mickaelvieira/responder"
+)
+
+func TestRecorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	rec, err := NewRecorder(provider.Meter("responder"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	r := responder.JSONResponder(responder.WithMetrics(rec))
+	r.Send200(httptest.NewRecorder(), map[string]int{"id": 1})
+	r.Send200(httptest.NewRecorder(), map[string]int{"id": 2})
+	r.Send404(httptest.NewRecorder(), nil, "not found")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect the metrics: %v", err)
+	}
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	t.Run("counts the responses", func(t *testing.T) {
+		sum, ok := metrics["responder.responses"].(metricdata.Sum[int64])
+		if !ok {
+			t.Fatalf("expected a counter, got %T", metrics["responder.responses"])
+		}
+
+		counts := make(map[int64]int64)
+		for _, dp := range sum.DataPoints {
+			status, _ := dp.Attributes.Value(attribute.Key("http.response.status_code"))
+			counts[status.AsInt64()] = dp.Value
+
+			if ct, _ := dp.Attributes.Value(attribute.Key("http.response.content_type")); ct.AsString() != "application/json" {
+				t.Errorf("expected the content type application/json, got %q", ct.AsString())
+			}
+		}
+
+		if counts[200] != 2 || counts[404] != 1 {
+			t.Errorf("expected 2 200s and 1 404, got %v", counts)
+		}
+	})
+
+	t.Run("records the size, duration and serialization histograms", func(t *testing.T) {
+		size, ok := metrics["responder.response.body.size"].(metricdata.Histogram[int64])
+		if !ok || len(size.DataPoints) != 2 {
+			t.Fatalf("expected 2 size histograms, got %v", metrics["responder.response.body.size"])
+		}
+
+		var total int64
+		for _, dp := range size.DataPoints {
+			total += dp.Sum
+		}
+
+		if want := int64(len(`{"id":1}`) + len(`{"id":2}`) + len(`{"error":"not found"}`)); total != want {
+			t.Errorf("expected %d bytes, got %d", want, total)
+		}
+
+		if _, ok := metrics["responder.response.duration"].(metricdata.Histogram[float64]); !ok {
+			t.Errorf("expected a duration histogram, got %T", metrics["responder.response.duration"])
+		}
+
+		if _, ok := metrics["responder.serialization.duration"].(metricdata.Histogram[float64]); !ok {
+			t.Errorf("expected a serialization histogram, got %T", metrics["responder.serialization.duration"])
+		}
+	})
+}
+
+func TestRecorderExemplars(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	rec, err := NewRecorder(provider.Meter("responder"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	responder.JSONResponder(responder.WithMetrics(rec)).WithRequest(req).Send500(httptest.NewRecorder(), nil, "boom")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect the metrics: %v", err)
+	}
+
+	histograms := 0
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			var exemplars [][]byte
+
+			switch h := m.Data.(type) {
+			case metricdata.Histogram[int64]:
+				for _, e := range h.DataPoints[0].Exemplars {
+					exemplars = append(exemplars, e.TraceID)
+				}
+			case metricdata.Histogram[float64]:
+				for _, e := range h.DataPoints[0].Exemplars {
+					exemplars = append(exemplars, e.TraceID)
+				}
+			default:
+				continue
+			}
+
+			histograms++
+
+			if len(exemplars) != 1 || trace.TraceID(exemplars[0]) != traceID {
+				t.Errorf("expected the trace as exemplar of %s, got %x", m.Name, exemplars)
+			}
+		}
+	}
+
+	if histograms != 3 {
+		t.Errorf("expected 3 histograms, got %d", histograms)
+	}
+}
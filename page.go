@@ -0,0 +1,95 @@
+package responder
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Paginated is implemented by the pages of results sent by SendPage.
+type Paginated interface {
+	// Pagination returns the number of the page, starting at 1,
+	// the number of items per page and the total number of items.
+	Pagination() (page, perPage, total int)
+}
+
+// Page is a page of the results of a list endpoint.
+type Page[T any] struct {
+	// Items are the items of the page.
+	Items []T `json:"items" xml:"items>item"`
+	// Total is the total number of items.
+	Total int `json:"total" xml:"total"`
+	// Page is the number of the page, starting at 1.
+	Page int `json:"page" xml:"page"`
+	// PerPage is the maximum number of items per page.
+	PerPage int `json:"per_page" xml:"per_page"`
+}
+
+// Pagination returns the number of the page, the number of items per page and the total number of items.
+func (p Page[T]) Pagination() (page, perPage, total int) {
+	return p.Page, p.PerPage, p.Total
+}
+
+// sendPage sends the page along with the Link header of the pagination.
+func (r *responder) sendPage(rw responseWriter, p Paginated) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	page, perPage, total := p.Pagination()
+
+	var base *url.URL
+	if r.request != nil {
+		base = r.request.URL
+	}
+
+	rw.Header().Add("Link", pageLinks(base, page, perPage, total))
+
+	return r.sendData(rw, status200, p)
+}
+
+// pageLinks returns the value of the RFC 8288 Link header of the page, linking to
+// the first, previous, next and last pages. The links are the URL with the page
+// and per_page query parameters replaced, or query-only references without URL.
+func pageLinks(u *url.URL, page, perPage, total int) string {
+	last := 1
+	if perPage > 0 && total > 0 {
+		last = (total + perPage - 1) / perPage
+	}
+
+	page = max(page, 1)
+
+	link := func(n int, rel string) string {
+		var q url.Values
+
+		path := ""
+		if u != nil {
+			q = u.Query()
+			path = u.EscapedPath()
+		} else {
+			q = make(url.Values)
+		}
+
+		q.Set("page", strconv.Itoa(n))
+
+		if perPage > 0 {
+			q.Set("per_page", strconv.Itoa(perPage))
+		}
+
+		return "<" + path + "?" + q.Encode() + `>; rel="` + rel + `"`
+	}
+
+	links := []string{link(1, "first")}
+
+	if page > 1 {
+		links = append(links, link(min(page-1, last), "prev"))
+	}
+
+	if page < last {
+		links = append(links, link(page+1, "next"))
+	}
+
+	links = append(links, link(last, "last"))
+
+	return strings.Join(links, ", ")
+}
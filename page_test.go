@@ -0,0 +1,62 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendPage(t *testing.T) {
+	t.Run("sends the page with its links", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/orders?page=2&per_page=2&sort=date", nil)
+
+		JSONResponder().WithRequest(req).SendPage(w, Page[string]{Items: []string{"c", "d"}, Total: 5, Page: 2, PerPage: 2})
+
+		want := `{"items":["c","d"],"total":5,"page":2,"per_page":2}`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+
+		link := `</orders?page=1&per_page=2&sort=date>; rel="first", ` +
+			`</orders?page=1&per_page=2&sort=date>; rel="prev", ` +
+			`</orders?page=3&per_page=2&sort=date>; rel="next", ` +
+			`</orders?page=3&per_page=2&sort=date>; rel="last"`
+		if v := w.Header().Get("Link"); v != link {
+			t.Errorf("expected Link %q, got %q", link, v)
+		}
+	})
+
+	t.Run("omits the links beyond the bounds", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendPage(w, Page[int]{Items: []int{1}, Total: 1, Page: 1, PerPage: 10})
+
+		link := `<?page=1&per_page=10>; rel="first", <?page=1&per_page=10>; rel="last"`
+		if v := w.Header().Get("Link"); v != link {
+			t.Errorf("expected Link %q, got %q", link, v)
+		}
+	})
+
+	t.Run("links the pages beyond the last one to it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendPage(w, Page[int]{Total: 4, Page: 9, PerPage: 2})
+
+		link := `<?page=1&per_page=2>; rel="first", <?page=2&per_page=2>; rel="prev", <?page=2&per_page=2>; rel="last"`
+		if v := w.Header().Get("Link"); v != link {
+			t.Errorf("expected Link %q, got %q", link, v)
+		}
+	})
+
+	t.Run("encodes the pages as XML", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		XMLResponder().SendPage(w, Page[string]{Items: []string{"a"}, Total: 1, Page: 1, PerPage: 1})
+
+		want := `<Page><items><item>a</item></items><total>1</total><page>1</page><per_page>1</per_page></Page>`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+	})
+}
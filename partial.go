@@ -0,0 +1,77 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// sizedReader is a reader reporting the number of bytes left to read,
+// so that the Content-Length of the streamed body is sent.
+type sizedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.n -= int64(n)
+
+	return n, err
+}
+
+func (s *sizedReader) Len() int {
+	return int(s.n)
+}
+
+// send206 sends the range of the content requested by the Range header.
+func (r *responder) send206(rw responseWriter, req *http.Request, content io.ReadSeeker, total int64) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	h := rw.Header()
+	h.Set("Accept-Ranges", "bytes")
+
+	start, length, code := int64(0), total, status200
+
+	if header := req.Header.Get("Range"); header != "" && ifRange(req, h) {
+		ranges, err := internal.ParseRange(header, total)
+
+		switch {
+		case errors.Is(err, internal.ErrUnsatisfiableRange):
+			h.Set("Content-Range", "bytes */"+strconv.FormatInt(total, 10))
+
+			return r.sendError(rw, status416, err, http.StatusText(status416))
+		case err == nil && len(ranges) == 1:
+			// The invalid and multiple ranges are ignored, as permitted by RFC 9110,
+			// the whole content being sent instead.
+			start, length, code = ranges[0].Start, ranges[0].Length, status206
+			h.Set("Content-Range", ranges[0].ContentRange(total))
+		}
+	}
+
+	if _, err := content.Seek(start, io.SeekStart); err != nil {
+		return r.sendFailure(rw, fmt.Errorf("%w: failed to seek content: %w", ErrInvalidContent, err))
+	}
+
+	// The content type set on the writer, e.g. the one of a media file, is kept.
+	return r.withContentType(h.Get("Content-Type")).
+		streamBody(rw, code, &sizedReader{r: io.LimitReader(content, length), n: length})
+}
+
+// ifRange evaluates the If-Range precondition of the request against
+// the ETag and Last-Modified headers set on the writer.
+func ifRange(req *http.Request, h http.Header) bool {
+	modtime, _ := http.ParseTime(h.Get("Last-Modified"))
+
+	return IfRange(req, h.Get("ETag"), modtime)
+}
+
+func (r *responder) Send206(rw responseWriter, req *http.Request, content io.ReadSeeker, total int64) {
+	_ = r.send206(rw, req, content, total)
+}
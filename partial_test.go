@@ -0,0 +1,102 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSend206(t *testing.T) {
+	content := "0123456789"
+
+	send := func(responder Responder, w *httptest.ResponseRecorder, header map[string]string) {
+		req := httptest.NewRequest(http.MethodGet, "/video", nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+
+		responder.Send206(w, req, strings.NewReader(content), int64(len(content)))
+	}
+
+	t.Run("sends the requested range", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "video/mp4")
+
+		send(JSONResponder(), w, map[string]string{"Range": "bytes=2-5"})
+
+		if w.Code != http.StatusPartialContent || w.Body.String() != "2345" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+
+		for k, v := range map[string]string{
+			"Content-Range":  "bytes 2-5/10",
+			"Content-Length": "4",
+			"Accept-Ranges":  "bytes",
+			"Content-Type":   "video/mp4",
+		} {
+			if got := w.Header().Get(k); got != v {
+				t.Errorf("expected %s %q, got %q", k, v, got)
+			}
+		}
+	})
+
+	t.Run("sends the whole content otherwise", func(t *testing.T) {
+		for _, header := range []map[string]string{
+			nil,
+			{"Range": "bytes=0-1,4-5"},
+			{"Range": "lines=1-2"},
+			{"Range": "bytes=0-1", "If-Range": `"old"`},
+		} {
+			w := httptest.NewRecorder()
+			w.Header().Set("ETag", `"new"`)
+
+			send(TextResponder(), w, header)
+
+			if w.Code != http.StatusOK || w.Body.String() != content || w.Header().Get("Content-Range") != "" {
+				t.Errorf("unexpected response %d %q for %v", w.Code, w.Body.String(), header)
+			}
+
+			if w.Header().Get("Content-Length") != "10" || w.Header().Get("Accept-Ranges") != "bytes" {
+				t.Errorf("unexpected headers %v", w.Header())
+			}
+		}
+	})
+
+	t.Run("honors the matching If-Range precondition", func(t *testing.T) {
+		modtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		w := httptest.NewRecorder()
+		w.Header().Set("Last-Modified", modtime.Format(http.TimeFormat))
+
+		send(TextResponder(), w, map[string]string{"Range": "bytes=-3", "If-Range": modtime.Format(http.TimeFormat)})
+
+		if w.Code != http.StatusPartialContent || w.Body.String() != "789" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("sends a formatted error for unsatisfiable ranges", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "video/mp4")
+
+		send(JSONResponder(), w, map[string]string{"Range": "bytes=20-"})
+
+		if w.Code != http.StatusRequestedRangeNotSatisfiable {
+			t.Errorf("expected status %d, got %d", http.StatusRequestedRangeNotSatisfiable, w.Code)
+		}
+
+		if w.Header().Get("Content-Range") != "bytes */10" {
+			t.Errorf("unexpected Content-Range %q", w.Header().Get("Content-Range"))
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("unexpected Content-Type %q", ct)
+		}
+
+		if w.Body.String() != `{"error":"Requested Range Not Satisfiable"}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
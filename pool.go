@@ -0,0 +1,103 @@
+package responder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity above which the buffers are not returned
+// to the pools, so that a few large responses do not keep their memory alive.
+const maxPooledBuffer = 64 << 10
+
+// bufferPool holds the buffers the bodies are encoded into.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	b, _ := bufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+
+	return b
+}
+
+// releaseBytes returns the content of the buffer and hands the buffer back to the pool.
+// The content of the pooled buffers is copied so that they can be reused, the large
+// buffers being handed over as is since they are not pooled.
+func releaseBytes(b *bytes.Buffer) []byte {
+	if b.Cap() > maxPooledBuffer {
+		return b.Bytes()
+	}
+
+	body := bytes.Clone(b.Bytes())
+	bufferPool.Put(b)
+
+	return body
+}
+
+// jsonEncoder is a JSON encoder writing into its own buffer.
+type jsonEncoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+// jsonEncoderPool holds the encoders of the JSON responders.
+var jsonEncoderPool = sync.Pool{
+	New: func() any {
+		e := new(jsonEncoder)
+		e.enc = json.NewEncoder(&e.buf)
+
+		return e
+	},
+}
+
+// encode marshals the value, without the newline added by json.Encoder,
+// and hands the encoder back to the pool.
+func (e *jsonEncoder) encode(v any, escapeHTML bool) ([]byte, error) {
+	e.buf.Reset()
+	e.enc.SetEscapeHTML(escapeHTML)
+
+	if err := e.enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	body := bytes.TrimSuffix(e.buf.Bytes(), []byte("\n"))
+	if e.buf.Cap() > maxPooledBuffer {
+		return body, nil
+	}
+
+	body = bytes.Clone(body)
+	jsonEncoderPool.Put(e)
+
+	return body, nil
+}
+
+// gzipWriterPool holds the writers of the built-in gzip compressor,
+// which allocate large compression tables.
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// pooledGzipWriter is a gzip writer handed back to the pool once closed.
+type pooledGzipWriter struct {
+	*gzip.Writer
+}
+
+func newPooledGzipWriter(w io.Writer) *pooledGzipWriter {
+	gw, _ := gzipWriterPool.Get().(*gzip.Writer)
+	gw.Reset(w)
+
+	return &pooledGzipWriter{Writer: gw}
+}
+
+func (w *pooledGzipWriter) Close() error {
+	err := w.Writer.Close()
+	gzipWriterPool.Put(w.Writer)
+	w.Writer = nil
+
+	return err
+}
@@ -0,0 +1,79 @@
+package responder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReleaseBytes(t *testing.T) {
+	t.Run("copies the content of the pooled buffers", func(t *testing.T) {
+		b := getBuffer()
+		b.WriteString("first")
+
+		body := releaseBytes(b)
+
+		b = getBuffer()
+		b.WriteString("second")
+
+		if string(body) != "first" {
+			t.Errorf("expected the body not to be overwritten, got %q", body)
+		}
+	})
+
+	t.Run("hands the large buffers over", func(t *testing.T) {
+		b := getBuffer()
+		b.Write(make([]byte, maxPooledBuffer+1))
+
+		if body := releaseBytes(b); &body[0] != &b.Bytes()[0] {
+			t.Error("expected the content of the large buffer not to be copied")
+		}
+	})
+}
+
+func TestPooledEncoders(t *testing.T) {
+	t.Run("does not share the JSON bodies", func(t *testing.T) {
+		r := JSONResponder()
+		bodies := make([][]byte, 3)
+
+		for i, v := range []any{map[string]int{"a": 1}, []string{"b"}, struct{ C bool }{true}} {
+			w := httptest.NewRecorder()
+			r.Send200(w, v)
+			bodies[i] = w.Body.Bytes()
+		}
+
+		want := []string{`{"a":1}`, `["b"]`, `{"C":true}`}
+		for i, b := range bodies {
+			if string(b) != want[i] {
+				t.Errorf("expected body %s, got %s", want[i], b)
+			}
+		}
+	})
+
+	t.Run("reuses the gzip writers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		r := TextResponder(WithCompression()).WithRequest(req)
+
+		for _, s := range []string{"a", "b", "c"} {
+			body := strings.Repeat(s, 2048)
+			w := httptest.NewRecorder()
+
+			r.Send200(w, body)
+
+			zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if b, _ := io.ReadAll(zr); string(b) != body {
+				t.Errorf("unexpected decompressed body of %d bytes", len(b))
+			}
+		}
+	})
+}
@@ -3,23 +3,60 @@ package responder
 import "github.com/mickaelvieira/responder/internal"
 
 type jsonError struct {
-	Error string `json:"error"`
+	Error  string       `json:"error"`
+	Offset int64        `json:"offset,omitempty"`
+	Fields []FieldError `json:"errors,omitempty"`
+	Detail *debugDetail `json:"detail,omitempty"`
+}
+
+// debugDetail holds the details of the errors sent in verbose mode.
+type debugDetail struct {
+	Causes []string `json:"causes,omitempty"`
+	Stack  string   `json:"stack,omitempty"`
+}
+
+// JSONErrorShape builds the value marshaled as the body of the JSON error
+// responses from their status code, internal error and message.
+type JSONErrorShape func(status int, err error, message any) any
+
+// WithJSONErrorShape sets the shape of the bodies of the JSON error responses,
+// e.g. to send { "error": { "code": status, "message": message } } envelopes.
+// It takes precedence over the error formatter for the JSON content types.
+// The message is a DebugMessage in verbose mode, see WithDebugToken.
+// Note that the internal error must not be exposed to the clients as is.
+func WithJSONErrorShape(f JSONErrorShape) OptionsModifier {
+	return func(o *options) {
+		o.jsonErrorShape = f
+	}
 }
 
 func jsonFormatter(message any) any {
-	return jsonError{
-		Error: internal.MessageToString(message),
+	var e jsonError
+
+	if m, ok := message.(DebugMessage); ok {
+		e.Detail = &debugDetail{Causes: m.Causes, Stack: m.Stack}
+		message = m.Message
+	}
+
+	switch m := message.(type) {
+	case SyntaxMessage:
+		e.Error, e.Offset = m.Message, m.Offset
+	case ValidationMessage:
+		e.Error, e.Fields = m.Message, m.Fields
+	default:
+		e.Error = internal.MessageToString(message)
 	}
+
+	return e
 }
 
 // JSONResponder creates a new JSON response handler.
 // The Content-Type will be set to application/json with UTF-8 charset
-// and the message will be formatted as a JSON error object { "error": string }.
+// and the message will be formatted as a JSON error object { "error": string },
+// unless another error formatter or shape is provided, see WithJSONErrorShape.
 func JSONResponder(options ...OptionsModifier) Responder {
-	var o []OptionsModifier
-
+	o := []OptionsModifier{WithErrorFormatter(jsonFormatter)}
 	o = append(o, options...)
-	o = append(o, WithErrorFormatter(jsonFormatter))
 
 	return New(JSONContentType, o...)
 }
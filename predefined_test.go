@@ -2,6 +2,7 @@ package responder
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -71,7 +72,7 @@ func TestJSONResponder(t *testing.T) {
 		}
 	})
 
-	t.Run("default JSON error formatter is always applied", func(t *testing.T) {
+	t.Run("custom error formatters take precedence", func(t *testing.T) {
 		jsonContentFormatter := func(content any) []byte {
 			data, _ := json.Marshal(content)
 			return data
@@ -84,20 +85,43 @@ func TestJSONResponder(t *testing.T) {
 			}
 		}
 
-		// JSONResponder always applies jsonFormatter last, so it overrides custom formatters
 		responder := JSONResponder(WithDataFormatter(jsonContentFormatter), WithErrorFormatter(customFormatter))
 		w := httptest.NewRecorder()
 
 		responder.Send400(w, errors.New("test"), "validation error")
 
-		var result jsonError
+		var result map[string]string
 		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
 			t.Fatalf("failed to unmarshal response: %v", err)
 		}
 
-		// The default jsonError formatter is applied, not the custom one
-		if result.Error != "validation error" {
-			t.Errorf("expected error message %q, got %q", "validation error", result.Error)
+		if result["custom_error"] != "validation error" || result["formatted"] != "true" {
+			t.Errorf("expected the custom error formatter to be applied, got %v", result)
+		}
+	})
+
+	t.Run("applies the JSON error shape", func(t *testing.T) {
+		shape := func(status int, _ error, message any) any {
+			return map[string]any{
+				"error": map[string]any{"code": status, "message": internal.MessageToString(message)},
+			}
+		}
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONErrorShape(shape)).Send404(w, errors.New("no row"), "user not found")
+
+		expected := `{"error":{"code":404,"message":"user not found"}}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+
+		TextResponder(WithJSONErrorShape(shape)).Send404(w, nil, "user not found")
+
+		if w.Body.String() != "user not found" {
+			t.Errorf("expected the shape to only apply to JSON, got %q", w.Body.String())
 		}
 	})
 
@@ -1843,3 +1867,64 @@ func TestXMLResponder(t *testing.T) {
 		}
 	})
 }
+
+type xmlBook struct {
+	XMLName xml.Name `xml:"book"`
+	ID      string   `xml:"id,attr"`
+	Title   string   `xml:"title"`
+}
+
+type xmlAuthor struct {
+	Name string `xml:"name"`
+}
+
+func TestXMLMarshaling(t *testing.T) {
+	t.Run("marshals structs", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		XMLResponder().Send200(w, xmlBook{ID: "bk101", Title: "XML Developer's Guide"})
+
+		expected := `<book id="bk101"><title>XML Developer&#39;s Guide</title></book>`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("wraps slices in a root element", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		XMLResponder().Send200(w, []xmlBook{{ID: "1", Title: "A"}, {ID: "2", Title: "B"}})
+
+		expected := `<items><book id="1"><title>A</title></book><book id="2"><title>B</title></book></items>`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("applies the header and root element options", func(t *testing.T) {
+		responder := XMLResponder(WithXMLHeader(true), WithXMLRootElement("catalog"))
+
+		w := httptest.NewRecorder()
+		responder.Send200(w, []xmlBook{{ID: "1", Title: "A"}})
+
+		expected := xml.Header + `<catalog><book id="1"><title>A</title></book></catalog>`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		responder.Send200(w, xmlAuthor{Name: "Kim"})
+
+		expected = xml.Header + `<catalog><name>Kim</name></catalog>`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("reports the values that cannot be marshaled", func(t *testing.T) {
+		err := Checked(XMLResponder()).Send200(httptest.NewRecorder(), map[string]int{"a": 1})
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package responder
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithPrefer honors the Prefer header of the requests (RFC 7240) in the
+// successful responses: return=minimal sends a 204 No Content instead of
+// a 200 OK and empty bodies for the other status codes, return=representation
+// sends the full bodies, and respond-async is acknowledged by the 202 Accepted
+// responses, e.g. the ones of SendQueued. The preferences applied are echoed
+// in the Preference-Applied header. Handlers can check whether the clients
+// prefer an asynchronous processing with PrefersAsync. It only applies to
+// the responders bound to a request with WithRequest, and not to streamed bodies.
+func WithPrefer(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.prefer = enabled
+	}
+}
+
+// Preferences returns the preferences of the Prefer headers of the request,
+// keyed by their lowercased name, along with their value, if any.
+// The parameters of the preferences are ignored.
+func Preferences(req *http.Request) map[string]string {
+	prefs := make(map[string]string)
+
+	for _, header := range req.Header.Values("Prefer") {
+		for pref := range strings.SplitSeq(header, ",") {
+			pref, _, _ = strings.Cut(pref, ";")
+
+			name, value, _ := strings.Cut(pref, "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+
+			if _, ok := prefs[name]; name == "" || ok {
+				continue
+			}
+
+			prefs[name] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+
+	return prefs
+}
+
+// PrefersAsync reports whether the client prefers the request to be processed
+// asynchronously, with the respond-async preference.
+func PrefersAsync(req *http.Request) bool {
+	_, ok := Preferences(req)["respond-async"]
+
+	return ok
+}
+
+// applyPreferences applies the preferences of the request to the successful
+// response, returning the status code and body to send.
+func (r *responder) applyPreferences(rw responseWriter, code int, body []byte) (int, []byte) {
+	if !r.options.prefer || r.request == nil || !Is2xx(code) {
+		return code, body
+	}
+
+	prefs := Preferences(r.request)
+
+	var applied []string
+
+	switch prefs["return"] {
+	case "minimal":
+		applied = append(applied, "return=minimal")
+		body = nil
+
+		if code == status200 {
+			code = status204
+		}
+	case "representation":
+		applied = append(applied, "return=representation")
+	}
+
+	if _, ok := prefs["respond-async"]; ok && code == status202 {
+		applied = append(applied, "respond-async")
+	}
+
+	if len(applied) > 0 {
+		rw.Header().Set("Preference-Applied", strings.Join(applied, ", "))
+		addVary(rw.Header(), "Prefer")
+	}
+
+	return code, body
+}
@@ -0,0 +1,112 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithPrefer(t *testing.T) {
+	request := func(prefer ...string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/users", nil)
+		for _, p := range prefer {
+			req.Header.Add("Prefer", p)
+		}
+
+		return req
+	}
+
+	responder := JSONResponder(WithPrefer(true))
+
+	t.Run("sends minimal responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		responder.WithRequest(request("return=minimal")).Send200(w, map[string]int{"id": 1})
+
+		if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+			t.Errorf("expected an empty 204, got %d %q", w.Code, w.Body.String())
+		}
+
+		if v := w.Header().Get("Preference-Applied"); v != "return=minimal" {
+			t.Errorf("expected Preference-Applied %q, got %q", "return=minimal", v)
+		}
+
+		if v := w.Header().Get("Vary"); v != "Prefer" {
+			t.Errorf("expected Vary %q, got %q", "Prefer", v)
+		}
+
+		w = httptest.NewRecorder()
+
+		responder.WithRequest(request(`return="minimal"; foo=bar`)).Send201(w, map[string]int{"id": 1})
+
+		if w.Code != http.StatusCreated || w.Body.Len() != 0 || w.Header().Get("Content-Length") != "0" {
+			t.Errorf("expected an empty 201, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("sends full representations", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		responder.WithRequest(request("return=representation")).Send201(w, map[string]int{"id": 1})
+
+		if w.Code != http.StatusCreated || w.Body.String() != `{"id":1}` {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+
+		if v := w.Header().Get("Preference-Applied"); v != "return=representation" {
+			t.Errorf("expected Preference-Applied %q, got %q", "return=representation", v)
+		}
+	})
+
+	t.Run("acknowledges asynchronous processing", func(t *testing.T) {
+		req := request("respond-async, wait=10", "return=minimal")
+		w := httptest.NewRecorder()
+
+		if !PrefersAsync(req) {
+			t.Fatal("expected the client to prefer asynchronous processing")
+		}
+
+		responder.WithRequest(req).SendQueued(w, 3, time.Minute)
+
+		if v := w.Header().Get("Preference-Applied"); v != "return=minimal, respond-async" {
+			t.Errorf("unexpected Preference-Applied %q", v)
+		}
+
+		if w.Code != http.StatusAccepted || w.Header().Get("X-Queue-Position") != "3" {
+			t.Errorf("unexpected response %d", w.Code)
+		}
+	})
+
+	t.Run("ignores the preferences when disabled or for errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().WithRequest(request("return=minimal")).Send200(w, "ok")
+
+		if w.Code != http.StatusOK || w.Header().Get("Preference-Applied") != "" {
+			t.Errorf("unexpected response %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+
+		responder.WithRequest(request("return=minimal")).Send400(w, nil, "invalid")
+
+		if w.Code != http.StatusBadRequest || w.Body.Len() == 0 || w.Header().Get("Preference-Applied") != "" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestPreferences(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("Prefer", "Return=minimal; x=y, handling=lenient")
+	req.Header.Add("Prefer", "return=representation, wait=5")
+
+	prefs := Preferences(req)
+
+	for name, value := range map[string]string{"return": "minimal", "handling": "lenient", "wait": "5"} {
+		if prefs[name] != value {
+			t.Errorf("expected preference %s=%q, got %q", name, value, prefs[name])
+		}
+	}
+}
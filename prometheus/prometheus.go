@@ -0,0 +1,90 @@
+// Package prometheus provides a responder.MetricsRecorder reporting the metrics
+// of the responses to Prometheus, see responder.WithMetrics.
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mickaelvieira/responder"
+)
+
+// Recorder records the number of responses, the size of their body, the time spent
+// sending them and the time spent formatting them, labeled by status code and content
+// type. When the trace ID of the request is known, see responder.WithTracing, it is
+// attached to the observations of the duration and size histograms as a trace_id
+// exemplar, linking the slow or failing responses to their trace.
+// The exemplars are only exposed in the OpenMetrics format, see promhttp.HandlerOpts.
+type Recorder struct {
+	responses     *prometheus.CounterVec
+	bodySize      *prometheus.HistogramVec
+	duration      *prometheus.HistogramVec
+	serialization *prometheus.HistogramVec
+}
+
+// NewRecorder creates a new Recorder registering its collectors with the registerer,
+// prometheus.DefaultRegisterer when nil. It fails when the collectors are already registered.
+func NewRecorder(reg prometheus.Registerer) (*Recorder, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	labels := []string{"code", "content_type"}
+
+	r := &Recorder{
+		responses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "responder",
+			Name:      "responses_total",
+			Help:      "Number of responses sent by the responders.",
+		}, labels),
+		bodySize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "responder",
+			Name:      "response_size_bytes",
+			Help:      "Size of the body of the responses sent by the responders.",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 8),
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "responder",
+			Name:      "response_duration_seconds",
+			Help:      "Time spent sending the responses by the responders.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, labels),
+		serialization: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "responder",
+			Name:      "serialization_duration_seconds",
+			Help:      "Time spent formatting the body of the responses sent by the responders.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 8),
+		}, labels),
+	}
+
+	for _, c := range []prometheus.Collector{r.responses, r.bodySize, r.duration, r.serialization} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// RecordResponse records the metrics of the response.
+func (r *Recorder) RecordResponse(_ context.Context, m responder.ResponseMetrics) {
+	code := strconv.Itoa(m.Status)
+
+	r.responses.WithLabelValues(code, m.ContentType).Inc()
+	observe(r.bodySize.WithLabelValues(code, m.ContentType), float64(m.BodySize), m.TraceID)
+	observe(r.duration.WithLabelValues(code, m.ContentType), m.Duration.Seconds(), m.TraceID)
+	r.serialization.WithLabelValues(code, m.ContentType).Observe(m.Serialization.Seconds())
+}
+
+// observe adds the observation to the histogram, with the trace ID as exemplar when not empty.
+func observe(o prometheus.Observer, v float64, traceID string) {
+	if e, ok := o.(prometheus.ExemplarObserver); ok && traceID != "" {
+		e.ObserveWithExemplar(v, prometheus.Labels{"trace_id": traceID})
+
+		return
+	}
+
+	o.Observe(v)
+}
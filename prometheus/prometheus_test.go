@@ -0,0 +1,142 @@
+package prometheus
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/mickaelvieira/responder"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Run("records the responses", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		rec, err := NewRecorder(reg)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		r := responder.JSONResponder(responder.WithMetrics(rec))
+		r.Send200(httptest.NewRecorder(), map[string]int{"id": 1})
+		r.Send200(httptest.NewRecorder(), map[string]int{"id": 2})
+		r.Send404(httptest.NewRecorder(), nil, "not found")
+
+		want := `
+# HELP responder_responses_total Number of responses sent by the responders.
+# TYPE responder_responses_total counter
+responder_responses_total{code="200",content_type="application/json"} 2
+responder_responses_total{code="404",content_type="application/json"} 1
+`
+		if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "responder_responses_total"); err != nil {
+			t.Error(err)
+		}
+
+		if n := testutil.CollectAndCount(rec.bodySize); n != 2 {
+			t.Errorf("expected 2 size histograms, got %d", n)
+		}
+
+		if n := testutil.CollectAndCount(rec.duration); n != 2 {
+			t.Errorf("expected 2 duration histograms, got %d", n)
+		}
+
+		if n := testutil.CollectAndCount(rec.serialization); n != 2 {
+			t.Errorf("expected 2 serialization histograms, got %d", n)
+		}
+	})
+
+	t.Run("fails when the collectors are already registered", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		if _, err := NewRecorder(reg); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if _, err := NewRecorder(reg); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("records the size of the body", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		rec, err := NewRecorder(reg)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		responder.TextResponder(responder.WithMetrics(rec)).Send(httptest.NewRecorder(), responder.Success(http.StatusCreated, "created"))
+
+		want := `
+# HELP responder_response_size_bytes Size of the body of the responses sent by the responders.
+# TYPE responder_response_size_bytes histogram
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="64"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="256"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="1024"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="4096"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="16384"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="65536"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="262144"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="1.048576e+06"} 1
+responder_response_size_bytes_bucket{code="201",content_type="text/plain",le="+Inf"} 1
+responder_response_size_bytes_sum{code="201",content_type="text/plain"} 7
+responder_response_size_bytes_count{code="201",content_type="text/plain"} 1
+`
+		if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "responder_response_size_bytes"); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+type span struct{}
+
+func (span) RecordError(error) {}
+func (span) SetError(string)   {}
+func (span) TraceID() string   { return "4bf92f3577b34da6a3ce929d0e0e4736" }
+
+func TestRecorderExemplars(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	rec, err := NewRecorder(reg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	r := responder.JSONResponder(
+		responder.WithMetrics(rec),
+		responder.WithTracing(func(context.Context) responder.Span { return span{} }),
+	)
+	r.WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).Send500(httptest.NewRecorder(), nil, "boom")
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather the metrics: %v", err)
+	}
+
+	exemplars := make(map[string]int)
+
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			for _, b := range m.GetHistogram().GetBucket() {
+				if e := b.GetExemplar(); e != nil {
+					exemplars[f.GetName()]++
+
+					if l := e.GetLabel(); len(l) != 1 || l[0].GetName() != "trace_id" || l[0].GetValue() != (span{}).TraceID() {
+						t.Errorf("expected the trace ID as exemplar, got %v", l)
+					}
+				}
+			}
+		}
+	}
+
+	want := map[string]int{"responder_response_duration_seconds": 1, "responder_response_size_bytes": 1}
+	if !maps.Equal(exemplars, want) {
+		t.Errorf("expected an exemplar on the duration and size histograms, got %v", exemplars)
+	}
+}
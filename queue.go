@@ -0,0 +1,29 @@
+package responder
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// QueueStatus is the body sent by SendQueued.
+type QueueStatus struct {
+	// Position is the position of the job in the queue, zero being the next one.
+	Position int `json:"position" xml:"position"`
+	// ETASeconds is the estimated number of seconds before the job is processed.
+	ETASeconds int `json:"eta_seconds" xml:"eta_seconds"`
+}
+
+// durationSeconds rounds the duration up to the second.
+func durationSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// retryAfter formats the duration as the value of a Retry-After header.
+func retryAfter(d time.Duration) string {
+	return strconv.FormatInt(durationSeconds(d), 10)
+}
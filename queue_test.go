@@ -0,0 +1,60 @@
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendQueued(t *testing.T) {
+	t.Run("sends 202 with the queue metadata", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendQueued(w, 3, 1500*time.Millisecond)
+
+		if w.Code != http.StatusAccepted {
+			t.Errorf("expected status %d, got %d", http.StatusAccepted, w.Code)
+		}
+
+		if v := w.Header().Get("X-Queue-Position"); v != "3" {
+			t.Errorf("expected X-Queue-Position %q, got %q", "3", v)
+		}
+
+		if v := w.Header().Get("Retry-After"); v != "2" {
+			t.Errorf("expected Retry-After %q, got %q", "2", v)
+		}
+
+		var result QueueStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if result.Position != 3 || result.ETASeconds != 2 {
+			t.Errorf("unexpected queue status %+v", result)
+		}
+	})
+
+	t.Run("sends 429 when the job could not be enqueued", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().SendQueued(w, -1, time.Minute)
+
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+		}
+
+		if v := w.Header().Get("Retry-After"); v != "60" {
+			t.Errorf("expected Retry-After %q, got %q", "60", v)
+		}
+
+		if v := w.Header().Get("X-Queue-Position"); v != "" {
+			t.Errorf("expected no X-Queue-Position, got %q", v)
+		}
+
+		if w.Body.String() != "the queue is full" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
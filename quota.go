@@ -0,0 +1,40 @@
+package responder
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// QuotaInfo describes the usage of the quota of the client issuing a request.
+type QuotaInfo struct {
+	// Limit is the number of requests allowed in the current window.
+	// No header is sent when it is not positive.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time left before the window resets.
+	Reset time.Duration
+}
+
+// WithQuotaHeaders sets a function reporting the quota usage of the client,
+// sent in the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers
+// of every response, successful ones included, so that clients can pace
+// themselves before hitting 429 Too Many Requests responses.
+// The headers are only sent by responders bound to a request with WithRequest.
+func WithQuotaHeaders(f func(r *http.Request) QuotaInfo) OptionsModifier {
+	return func(o *options) {
+		o.quota = f
+	}
+}
+
+// setQuotaHeaders writes the quota headers of the request.
+func setQuotaHeaders(h http.Header, q QuotaInfo) {
+	if q.Limit <= 0 {
+		return
+	}
+
+	h.Set("RateLimit-Limit", strconv.Itoa(q.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(max(q.Remaining, 0)))
+	h.Set("RateLimit-Reset", strconv.FormatInt(durationSeconds(q.Reset), 10))
+}
@@ -0,0 +1,73 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithQuotaHeaders(t *testing.T) {
+	quota := func(r *http.Request) QuotaInfo {
+		if r.Header.Get("X-Client") == "" {
+			return QuotaInfo{}
+		}
+
+		return QuotaInfo{Limit: 100, Remaining: 42, Reset: 30 * time.Second}
+	}
+
+	t.Run("sends the quota headers on success responses", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Client", "acme")
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithQuotaHeaders(quota)).WithRequest(req).Send200(w, "ok")
+
+		expected := map[string]string{
+			"RateLimit-Limit":     "100",
+			"RateLimit-Remaining": "42",
+			"RateLimit-Reset":     "30",
+		}
+
+		for k, v := range expected {
+			if got := w.Header().Get(k); got != v {
+				t.Errorf("expected %s %q, got %q", k, v, got)
+			}
+		}
+	})
+
+	t.Run("skips the headers without quota", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithQuotaHeaders(quota)).WithRequest(req).Send200(w, "ok")
+
+		if v := w.Header().Get("RateLimit-Limit"); v != "" {
+			t.Errorf("expected no RateLimit-Limit, got %q", v)
+		}
+	})
+
+	t.Run("skips the headers when not bound to a request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithQuotaHeaders(quota)).Send200(w, "ok")
+
+		if v := w.Header().Get("RateLimit-Limit"); v != "" {
+			t.Errorf("expected no RateLimit-Limit, got %q", v)
+		}
+	})
+}
+
+func TestWithRequest(t *testing.T) {
+	original := TextResponder()
+	bound := original.WithRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if bound == original {
+		t.Error("expected a copy of the responder")
+	}
+
+	if original.(*responder).request != nil {
+		t.Error("expected the original responder to be left unbound")
+	}
+}
@@ -0,0 +1,135 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// StatusCoder is implemented by values, typically errors, that carry
+// the HTTP status code they should be reported with.
+type StatusCoder interface {
+	// StatusCode returns the HTTP status code associated with the value.
+	StatusCode() int
+}
+
+// PanicResponse converts a value recovered from a panic into an error Response.
+// Errors implementing StatusCoder, directly or wrapped, are rendered as by
+// DefaultErrorMapper. Any other value results
+// in a 500 Internal Server Error with a generic message, the value being kept
+// as the internal error for logging purposes.
+func PanicResponse(v any) Response {
+	if err, ok := v.(error); ok {
+		return DefaultErrorMapper(err)
+	}
+
+	err := fmt.Errorf("panic: %v", v)
+
+	if sc, ok := v.(StatusCoder); ok && validErrorStatus(sc.StatusCode()) {
+		return Error(sc.StatusCode(), err, http.StatusText(sc.StatusCode()))
+	}
+
+	return Error(status500, err, http.StatusText(status500))
+}
+
+// DefaultErrorMapper converts an error into an error Response. Errors wrapping
+// or joining ValidationErrors result in a 422 Unprocessable Entity reporting
+// their fields. Errors implementing StatusCoder, directly or wrapped, are
+// rendered with their own status code. The client message is the text of the
+// StatusCoder error itself, not the one of the errors wrapping it, unless it
+// wraps other errors in turn, in which case the status text is sent instead.
+// Any other error results in a 500 Internal Server Error with a generic message.
+// The whole error is kept as the internal error for logging purposes.
+func DefaultErrorMapper(err error) Response {
+	if fields := fieldErrors(err); len(fields) > 0 {
+		return unprocessable(err, fields)
+	}
+
+	var sc StatusCoder
+	if errors.As(err, &sc) && validErrorStatus(sc.StatusCode()) {
+		return Error(sc.StatusCode(), err, statusCoderMessage(sc))
+	}
+
+	return Error(status500, err, http.StatusText(status500))
+}
+
+// statusCoderMessage returns the client message of the StatusCoder, its error text
+// when it is an error wrapping nothing, so that the text of the wrapped errors
+// is not leaked to the client, or the status text otherwise.
+func statusCoderMessage(sc StatusCoder) string {
+	if err, ok := sc.(error); ok && errors.Unwrap(err) == nil {
+		if _, joined := err.(interface{ Unwrap() []error }); !joined {
+			return err.Error()
+		}
+	}
+
+	return http.StatusText(sc.StatusCode())
+}
+
+// validErrorStatus reports whether the status code is a client or server error.
+func validErrorStatus(code int) bool {
+	return Is4xx(code) || Is5xx(code)
+}
+
+// Recoverer returns a middleware recovering the panics of the handlers. The panic
+// is logged along with its stack trace with the logger of the responder, and the
+// PanicResponse of the recovered value is sent with the responder, i.e. a 500
+// Internal Server Error formatted in its content type unless the value carries
+// its own status code. http.ErrAbortHandler is propagated so that the server
+// aborts the response. Nothing can be sent when the handler already wrote
+// the header before panicking.
+func Recoverer(r Responder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer func() {
+				if v := recover(); v != nil {
+					if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+						panic(v)
+					}
+
+					r.WithRequest(req).Send(w, recoveredResponse(v, debug.Stack()))
+				}
+			}()
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// recoveredResponse returns the PanicResponse of the value,
+// whose internal error carries the stack trace of the panic.
+func recoveredResponse(v any, stack []byte) Response {
+	resp := PanicResponse(v)
+
+	if e, ok := resp.(ErrorResponse); ok {
+		e.err = &panicError{err: e.err, stack: stack}
+
+		return e
+	}
+
+	return resp
+}
+
+// panicError is an error recovered from a panic along with its stack trace.
+type panicError struct {
+	err   error
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return e.err.Error()
+}
+
+func (e *panicError) Unwrap() error {
+	return e.err
+}
+
+// LogValue logs the stack trace along with the error message.
+func (e *panicError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("message", e.err.Error()),
+		slog.String("stack", string(e.stack)),
+	)
+}
@@ -0,0 +1,157 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type statusError struct {
+	status int
+	msg    string
+}
+
+func (e statusError) Error() string   { return e.msg }
+func (e statusError) StatusCode() int { return e.status }
+
+type wrappingStatusError struct {
+	status int
+	err    error
+}
+
+func (e wrappingStatusError) Error() string   { return "upstream: " + e.err.Error() }
+func (e wrappingStatusError) StatusCode() int { return e.status }
+func (e wrappingStatusError) Unwrap() error   { return e.err }
+
+type statusValue int
+
+func (v statusValue) StatusCode() int { return int(v) }
+
+func TestPanicResponse(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      any
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "arbitrary value",
+			value:      "boom",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error",
+		},
+		{
+			name:       "plain error",
+			value:      errors.New("database is down"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error",
+		},
+		{
+			name:       "error implementing StatusCoder",
+			value:      statusError{status: http.StatusConflict, msg: "already exists"},
+			wantStatus: http.StatusConflict,
+			wantBody:   "already exists",
+		},
+		{
+			name:       "wrapped error implementing StatusCoder",
+			value:      fmt.Errorf("creating user: %w", statusError{status: http.StatusNotFound, msg: "no such team"}),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "no such team",
+		},
+		{
+			name:       "StatusCoder error wrapping another error",
+			value:      fmt.Errorf("creating user: %w", wrappingStatusError{status: http.StatusBadGateway, err: errors.New("dial tcp 10.0.0.1:5432")}),
+			wantStatus: http.StatusBadGateway,
+			wantBody:   "Bad Gateway",
+		},
+		{
+			name:       "value implementing StatusCoder",
+			value:      statusValue(http.StatusServiceUnavailable),
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "Service Unavailable",
+		},
+		{
+			name:       "StatusCoder with a non-error status",
+			value:      statusError{status: http.StatusOK, msg: "not an error"},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			TextResponder().Send(w, PanicResponse(tc.value))
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, w.Code)
+			}
+
+			if w.Body.String() != tc.wantBody {
+				t.Errorf("expected body %q, got %q", tc.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRecoverer(t *testing.T) {
+	newResponder := func(logs *bytes.Buffer) Responder {
+		return JSONResponder(WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	}
+
+	t.Run("sends a formatted 500 and logs the stack", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		h := Recoverer(newResponder(&logs))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %d", http.StatusInternalServerError, w.Code)
+		}
+
+		if want := `{"error":"Internal Server Error"}`; w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+
+		if !strings.Contains(logs.String(), "panic: boom") || !strings.Contains(logs.String(), "error.stack=") {
+			t.Errorf("expected the panic and its stack to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("passes through when there is no panic", func(t *testing.T) {
+		h := Recoverer(TextResponder())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusTeapot {
+			t.Errorf("expected status %d, got %d", http.StatusTeapot, w.Code)
+		}
+	})
+
+	t.Run("propagates aborted handlers", func(t *testing.T) {
+		h := Recoverer(TextResponder())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		defer func() {
+			if v := recover(); v != http.ErrAbortHandler {
+				t.Errorf("expected http.ErrAbortHandler to be propagated, got %v", v)
+			}
+		}()
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -0,0 +1,78 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrRedirectNotAllowed is returned by the redirect validators rejecting a target.
+var ErrRedirectNotAllowed = errors.New("responder: redirect target not allowed")
+
+// WithRedirectValidator sets the validator applied to the targets of the redirects,
+// e.g. AllowHosts, to prevent open redirects. The rejected redirects are replaced
+// with a 400 Bad Request response, or reported to checked responders.
+func WithRedirectValidator(f func(target string) error) OptionsModifier {
+	return func(o *options) {
+		o.redirectValidator = f
+	}
+}
+
+// SameOrigin is a redirect validator only allowing the relative targets,
+// i.e. the paths of the same origin.
+func SameOrigin(target string) error {
+	return AllowHosts()(target)
+}
+
+// AllowHosts returns a redirect validator allowing the relative targets
+// and the absolute HTTP(S) targets whose host is one of the given ones.
+// Scheme-relative targets such as //example.com are considered absolute,
+// and the paths starting with several slashes or backslashes are rejected.
+func AllowHosts(hosts ...string) func(target string) error {
+	return func(target string) error {
+		// Browsers treat backslashes as slashes, /\example.com being scheme-relative.
+		u, err := url.Parse(strings.ReplaceAll(target, `\`, "/"))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrRedirectNotAllowed, err)
+		}
+
+		if u.Scheme == "" && u.Host == "" {
+			// ///example.com has no host but browsers resolve it to //example.com.
+			if strings.HasPrefix(u.Path, "//") {
+				return fmt.Errorf("%w: %q", ErrRedirectNotAllowed, target)
+			}
+
+			return nil
+		}
+
+		if (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "") &&
+			slices.ContainsFunc(hosts, func(h string) bool { return strings.EqualFold(h, u.Hostname()) }) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %q", ErrRedirectNotAllowed, target)
+	}
+}
+
+// validateRedirect applies the redirect validator to the target.
+// The rejected redirects are reported to checked responders,
+// and replaced with a 400 Bad Request otherwise.
+func (r *responder) validateRedirect(rw responseWriter, target string) (bool, error) {
+	if r.options.redirectValidator == nil {
+		return true, nil
+	}
+
+	err := r.options.redirectValidator(target)
+	if err == nil {
+		return true, nil
+	}
+
+	if r.checked {
+		return false, err
+	}
+
+	return false, r.sendError(rw, status400, err, http.StatusText(status400))
+}
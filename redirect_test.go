@@ -0,0 +1,98 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllowHosts(t *testing.T) {
+	validate := AllowHosts("example.com")
+
+	for target, allowed := range map[string]bool{
+		"/users/1":                  true,
+		"users?page=2":              true,
+		"https://example.com/login": true,
+		"http://EXAMPLE.com:8080/":  true,
+		"//example.com/path":        true,
+		"https://evil.com/":         false,
+		"//evil.com":                false,
+		`/\evil.com`:                false,
+		`/\/evil.com`:               false,
+		`\\evil.com`:                false,
+		"///evil.com":               false,
+		"javascript:alert(1)":       false,
+		"ftp://example.com/":        false,
+	} {
+		t.Run(target, func(t *testing.T) {
+			err := validate(target)
+			if allowed && err != nil {
+				t.Errorf("expected %q to be allowed, got %v", target, err)
+			}
+
+			if !allowed && !errors.Is(err, ErrRedirectNotAllowed) {
+				t.Errorf("expected %q to be rejected, got %v", target, err)
+			}
+		})
+	}
+
+	t.Run("same origin rejects absolute targets", func(t *testing.T) {
+		for _, target := range []string{"https://example.com/", `/\/evil.com`, `\\evil.com`, `/\evil.com`} {
+			if err := SameOrigin(target); !errors.Is(err, ErrRedirectNotAllowed) {
+				t.Errorf("expected %q to be rejected, got %v", target, err)
+			}
+		}
+
+		if err := SameOrigin("/home"); err != nil {
+			t.Errorf("expected the target to be allowed, got %v", err)
+		}
+	})
+}
+
+func TestWithRedirectValidator(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/login", nil)
+
+	t.Run("redirects to the allowed targets", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithRedirectValidator(SameOrigin)).Redirect303(w, req, "/home")
+
+		if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/home" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Header().Get("Location"))
+		}
+	})
+
+	t.Run("replaces the rejected redirects with a 400", func(t *testing.T) {
+		responder := JSONResponder(WithRedirectValidator(SameOrigin))
+
+		for _, redirect := range []func(http.ResponseWriter){
+			func(w http.ResponseWriter) { responder.Redirect302(w, req, "https://evil.com") },
+			func(w http.ResponseWriter) { responder.Redirect(w, req, "//evil.com", http.StatusMovedPermanently) },
+		} {
+			w := httptest.NewRecorder()
+			redirect(w)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			if v := w.Header().Get("Location"); v != "" {
+				t.Errorf("expected no Location, got %q", v)
+			}
+		}
+	})
+
+	t.Run("reports the rejected redirects to checked responders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := Checked(JSONResponder(WithRedirectValidator(SameOrigin))).Redirect307(w, req, "https://evil.com")
+		if !errors.Is(err, ErrRedirectNotAllowed) {
+			t.Errorf("expected ErrRedirectNotAllowed, got %v", err)
+		}
+
+		if w.Body.Len() != 0 || w.Header().Get("Location") != "" {
+			t.Errorf("expected nothing to be written, got %q", w.Body.String())
+		}
+	})
+}
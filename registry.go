@@ -0,0 +1,82 @@
+package responder
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// TenantResolver extracts the tenant identifier from a request.
+type TenantResolver func(*http.Request) string
+
+// HostResolver is the default TenantResolver.
+// It identifies tenants by the lowercased request host, without the port.
+func HostResolver(r *http.Request) string {
+	return normalizeHost(r.Host)
+}
+
+// normalizeHost lowercases the host and strips its port.
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.ToLower(host)
+}
+
+// Registry holds a Responder per tenant, for multi-tenant applications
+// where the response formatting differs per customer.
+// It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	fallback Responder
+	resolve  TenantResolver
+	byHost   bool
+	tenants  map[string]Responder
+}
+
+// NewRegistry creates a new Registry returning the fallback Responder for unknown tenants.
+// Tenants are identified with the given resolver, or by host when it is nil.
+func NewRegistry(fallback Responder, resolve TenantResolver) *Registry {
+	byHost := resolve == nil
+	if byHost {
+		resolve = HostResolver
+	}
+
+	return &Registry{
+		fallback: fallback,
+		resolve:  resolve,
+		byHost:   byHost,
+		tenants:  make(map[string]Responder),
+	}
+}
+
+// Register sets the Responder of the given tenant, replacing any previous one.
+// The hosts registered with the default resolver are lowercased and stripped
+// of their port, as HostResolver does.
+func (g *Registry) Register(tenant string, r Responder) {
+	if g.byHost {
+		tenant = normalizeHost(tenant)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.tenants[tenant] = r
+}
+
+// For returns the Responder of the tenant the request belongs to,
+// or the fallback Responder when the tenant is not registered.
+func (g *Registry) For(r *http.Request) Responder {
+	tenant := g.resolve(r)
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if resp, ok := g.tenants[tenant]; ok {
+		return resp
+	}
+
+	return g.fallback
+}
@@ -0,0 +1,62 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	fallback := TextResponder()
+	acme := JSONResponder()
+
+	t.Run("returns the responder of the tenant", func(t *testing.T) {
+		registry := NewRegistry(fallback, nil)
+		registry.Register("acme.example.com", acme)
+
+		req := httptest.NewRequest(http.MethodGet, "http://ACME.example.com:8080/", nil)
+		if registry.For(req) != acme {
+			t.Error("expected the tenant responder")
+		}
+	})
+
+	t.Run("normalizes the registered hosts", func(t *testing.T) {
+		registry := NewRegistry(fallback, nil)
+		registry.Register("ACME.example.com:443", acme)
+
+		req := httptest.NewRequest(http.MethodGet, "http://acme.example.com/", nil)
+		if registry.For(req) != acme {
+			t.Error("expected the tenant responder")
+		}
+	})
+
+	t.Run("returns the fallback for unknown tenants", func(t *testing.T) {
+		registry := NewRegistry(fallback, nil)
+		registry.Register("acme.example.com", acme)
+
+		req := httptest.NewRequest(http.MethodGet, "http://other.example.com/", nil)
+		if registry.For(req) != fallback {
+			t.Error("expected the fallback responder")
+		}
+	})
+
+	t.Run("uses a custom tenant resolver", func(t *testing.T) {
+		registry := NewRegistry(fallback, func(r *http.Request) string {
+			return r.Header.Get("X-Tenant")
+		})
+		registry.Register("Acme", acme)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Tenant", "Acme")
+
+		if registry.For(req) != acme {
+			t.Error("expected the tenant responder")
+		}
+
+		req.Header.Set("X-Tenant", "acme")
+
+		if registry.For(req) != fallback {
+			t.Error("expected the custom tenants to be kept as is")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package responder
+
+import "net/http"
+
+// RequestIDHeader is the header the request IDs are read from by default.
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestID echoes the ID of the request the responder is bound to in the
+// header of every response, and attaches it to the error logs as a "request_id"
+// attribute. The ID is returned by the extract function, e.g. reading the context
+// of a request ID middleware, or read from the header of the request when nil.
+// The ID is also the one reported in the journal, the error store and the error info.
+func WithRequestID(header string, extract func(*http.Request) string) OptionsModifier {
+	return func(o *options) {
+		o.requestIDHeader = header
+		o.requestIDExtract = extract
+	}
+}
+
+// requestID returns the ID of the request the responder is bound to, if any.
+func (r *responder) requestID() string {
+	if r.request == nil {
+		return ""
+	}
+
+	if r.options.requestIDExtract != nil {
+		return r.options.requestIDExtract(r.request)
+	}
+
+	header := r.options.requestIDHeader
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	return r.request.Header.Get(header)
+}
+
+// echoRequestID sets the request ID header of the response unless already set.
+func (r *responder) echoRequestID(rw responseWriter) {
+	if r.options.requestIDHeader == "" || rw.Header().Get(r.options.requestIDHeader) != "" {
+		return
+	}
+
+	if id := r.requestID(); id != "" {
+		rw.Header().Set(r.options.requestIDHeader, id)
+	}
+}
@@ -0,0 +1,97 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type requestIDKey struct{}
+
+func TestWithRequestID(t *testing.T) {
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", id)
+
+		return req
+	}
+
+	t.Run("echoes the request ID in every response", func(t *testing.T) {
+		responder := JSONResponder(WithRequestID("X-Request-ID", nil)).WithRequest(newRequest("req-1"))
+
+		for _, send := range []func(http.ResponseWriter){
+			func(w http.ResponseWriter) { responder.Send200(w, "ok") },
+			func(w http.ResponseWriter) { responder.Send404(w, nil, "not found") },
+			func(w http.ResponseWriter) {
+				responder.Redirect302(w, httptest.NewRequest(http.MethodGet, "/", nil), "/elsewhere")
+			},
+		} {
+			w := httptest.NewRecorder()
+			send(w)
+
+			if v := w.Header().Get("X-Request-ID"); v != "req-1" {
+				t.Errorf("expected X-Request-ID %q, got %q", "req-1", v)
+			}
+		}
+	})
+
+	t.Run("extracts the ID from the request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), requestIDKey{}, "ctx-1"))
+
+		extract := func(r *http.Request) string {
+			id, _ := r.Context().Value(requestIDKey{}).(string)
+
+			return id
+		}
+
+		w := httptest.NewRecorder()
+
+		TextResponder(WithRequestID("Request-Id", extract)).WithRequest(req).Send200(w, "ok")
+
+		if v := w.Header().Get("Request-Id"); v != "ctx-1" {
+			t.Errorf("expected Request-Id %q, got %q", "ctx-1", v)
+		}
+	})
+
+	t.Run("attaches the ID to the error logs", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		TextResponder(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))), WithRequestID("X-Request-ID", nil)).
+			WithRequest(newRequest("req-2")).
+			Send500(httptest.NewRecorder(), errors.New("boom"), "error")
+
+		if !strings.Contains(logs.String(), "request_id=req-2") {
+			t.Errorf("expected the request ID in the logs, got %q", logs.String())
+		}
+	})
+
+	t.Run("does not override the header set by the handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Set("X-Request-ID", "handler")
+
+		TextResponder(WithRequestID("X-Request-ID", nil)).WithRequest(newRequest("req-3")).Send200(w, "ok")
+
+		if v := w.Header().Get("X-Request-ID"); v != "handler" {
+			t.Errorf("expected X-Request-ID %q, got %q", "handler", v)
+		}
+	})
+
+	t.Run("is reported in the error info", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(
+			WithRequestID("X-Correlation-ID", func(*http.Request) string { return "corr-1" }),
+			WithErrorInfoFormatter(func(e ErrorInfo) any { return e.RequestID }),
+		).WithRequest(newRequest("req-4")).Send400(w, nil, "bad request")
+
+		if w.Body.String() != "corr-1" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
@@ -6,17 +6,29 @@
 package responder
 
 import (
+	"context"
 	"encoding"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"html"
+	"image"
+	"io"
+	"iter"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mickaelvieira/responder/internal"
 )
 
-type responseWriter http.ResponseWriter
+// responseWriter is an alias so that the interfaces of the package
+// can be implemented outside of it, e.g. by mocks.
+type responseWriter = http.ResponseWriter
 
 const (
 	// TextContentType is the content type for plain text responses
@@ -36,57 +48,85 @@ const (
 	status201 = http.StatusCreated
 	status202 = http.StatusAccepted
 	status204 = http.StatusNoContent
+	status205 = http.StatusResetContent
+	status206 = http.StatusPartialContent
 	status301 = http.StatusMovedPermanently
 	status302 = http.StatusFound
 	status303 = http.StatusSeeOther
+	status304 = http.StatusNotModified
 	status307 = http.StatusTemporaryRedirect
+	status308 = http.StatusPermanentRedirect
 	status400 = http.StatusBadRequest
 	status401 = http.StatusUnauthorized
 	status403 = http.StatusForbidden
 	status404 = http.StatusNotFound
+	status405 = http.StatusMethodNotAllowed
+	status406 = http.StatusNotAcceptable
+	status409 = http.StatusConflict
+	status410 = http.StatusGone
+	status415 = http.StatusUnsupportedMediaType
+	status416 = http.StatusRequestedRangeNotSatisfiable
+	status422 = http.StatusUnprocessableEntity
+	status429 = http.StatusTooManyRequests
 	status500 = http.StatusInternalServerError
+	status501 = http.StatusNotImplemented
+	status502 = http.StatusBadGateway
+	status503 = http.StatusServiceUnavailable
+	status504 = http.StatusGatewayTimeout
 )
 
-//nolint:revive // revive complains about the cognitive-complexity but to be fair, it is not that hard to read.
-func defaultDataFormatter(c any) []byte {
+// formatData is the default data formatter reporting the marshaling failures.
+func formatData(c any) ([]byte, error) {
+	return formatValue(c, json.Marshal)
+}
+
+// formatValue formats the data, the values without a dedicated encoding
+// being marshaled with the given function.
+func formatValue(c any, marshal func(any) ([]byte, error)) ([]byte, error) {
 	if c == nil {
-		return []byte{}
+		return []byte{}, nil
 	}
 
 	switch v := c.(type) {
 	case string:
-		return []byte(v)
+		return []byte(v), nil
 	case []byte:
-		return v
+		return v, nil
 	case xml.Marshaler:
 		// Create a simple encoder to marshal XML
-		b, err := xml.Marshal(v)
-		if err != nil {
-			return fmt.Appendf(nil, "received invalid content - %s", err)
-		}
-
-		return b
+		return xml.Marshal(v)
 	case json.Marshaler:
-		b, err := v.MarshalJSON()
-		if err != nil {
-			return fmt.Appendf(nil, "received invalid content - %s", err)
-		}
-
-		return b
+		return v.MarshalJSON()
 	case encoding.TextMarshaler:
-		b, err := v.MarshalText()
-		if err != nil {
-			return fmt.Appendf(nil, "received invalid content - %s", err)
-		}
-
-		return b
+		return v.MarshalText()
 	default:
-		b, err := json.Marshal(v)
-		if err != nil {
-			return fmt.Appendf(nil, "received invalid content - %s", err)
+		return marshal(v)
+	}
+}
+
+// formatText is the default data formatter of the textual content types,
+// sending the fmt.Stringer and error values as plain text.
+func formatText(c any) ([]byte, error) {
+	if marshaled(c) {
+		switch v := c.(type) {
+		case fmt.Stringer:
+			return []byte(v.String()), nil
+		case error:
+			return []byte(v.Error()), nil
 		}
+	}
 
-		return b
+	return formatData(c)
+}
+
+// marshaled reports whether formatValue marshals the data
+// rather than using its dedicated encoding.
+func marshaled(c any) bool {
+	switch c.(type) {
+	case nil, string, []byte, xml.Marshaler, json.Marshaler, encoding.TextMarshaler:
+		return false
+	default:
+		return true
 	}
 }
 
@@ -94,14 +134,16 @@ func defaultDataFormatter(c any) []byte {
 // before sending them in the response.
 // It receives the original error message as any type and returns
 // the formatted message as an any type.
-// The output of this function is passed to the DataFormatter.
+// The output of this function is passed to the Encoder.
 // The default error formatter converts the message to a string.
+// See ErrorInfoFormatter for the formatters needing the status code.
 type ErrorFormatter func(any) any
 
 // DataFormatter defines a function type for formatting
 // the data before sending it in the response.
 // It receives the original data as an any type and returns
 // the formatted data as a []byte.
+// It cannot report failures, see Encoder.
 type DataFormatter func(any) []byte
 
 // stringFormatter is the default error formatter that converts
@@ -120,10 +162,12 @@ func WithLogger(l *slog.Logger) OptionsModifier {
 	}
 }
 
-// WithDataFormatter sets a custom data formatter
+// WithDataFormatter sets a custom data formatter, see WithEncoder
+// for the formatters reporting their failures.
 func WithDataFormatter(f DataFormatter) OptionsModifier {
 	return func(o *options) {
-		o.dataFormatter = f
+		o.encoder = FormatterEncoder("", f)
+		o.directEncoder = nil
 	}
 }
 
@@ -134,15 +178,111 @@ func WithErrorFormatter(f ErrorFormatter) OptionsModifier {
 	}
 }
 
+// WithDefaultHeaders sets headers sent with every response, e.g. X-Frame-Options
+// or an API version header. They do not override the headers already set
+// on the writer or attached to the Response.
+func WithDefaultHeaders(h http.Header) OptionsModifier {
+	return func(o *options) {
+		for k, v := range h {
+			o.defaultHeaders[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+}
+
+// WithHeader adds a header sent with every response, see WithDefaultHeaders.
+func WithHeader(key, value string) OptionsModifier {
+	return func(o *options) {
+		o.defaultHeaders.Add(key, value)
+	}
+}
+
+// WithBodyViolationLogging enables logging of formatted bodies that were discarded
+// because the status code forbids content (1xx, 204, 205 and 304).
+// A logger must be provided for the violations to be reported.
+func WithBodyViolationLogging(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.logBodyViolations = enabled
+	}
+}
+
+// WithEmptyBodyAs204 makes the 200 OK responses whose formatted body is empty
+// be sent as 204 No Content, since some clients are confused by empty
+// 200 OK responses. The downgrades are logged if a logger was provided.
+func WithEmptyBodyAs204(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.emptyBodyAs204 = enabled
+	}
+}
+
 // options holds the configuration options for the Responder.
 type options struct {
-	logger         *slog.Logger
-	dataFormatter  DataFormatter
-	errorFormatter ErrorFormatter
+	logger             *slog.Logger
+	encoder            Encoder
+	errorFormatter     ErrorFormatter
+	logBodyViolations  bool
+	emptyBodyAs204     bool
+	incident           func() *Incident
+	redactor           Redactor
+	noStore            func(int) bool
+	cacheControl       *CacheControl
+	jsonTransform      JSONTransform
+	digestTrailer      bool
+	quota              func(*http.Request) QuotaInfo
+	logAttrs           func(context.Context) []slog.Attr
+	requestIDHeader    string
+	requestIDExtract   func(*http.Request) string
+	metrics            MetricsRecorder
+	spanFromContext    func(context.Context) Span
+	beforeSend         []BeforeSendHook
+	afterSend          []AfterSendHook
+	logLevelMapper     func(int) slog.Level
+	structuredErrorLog bool
+	defaultHeaders     http.Header
+	slo                *SLOPolicy
+	compression        compressionOptions
+	etag               bool
+	weakETag           bool
+	seo                seoOptions
+	encrypter          Encrypter
+	errorMapper        ErrorMapper
+	principalKey       any
+	challenges         []string
+	journal            Journal
+	journalHeaders     []string
+	redirectValidator  func(string) error
+	watermarker        Watermarker
+	csv                csvOptions
+	shadow             *shadow
+	shadowRate         float64
+	bandwidth          int
+	globalBandwidth    *internal.Limiter
+	fault              *FaultPolicy
+	attachment         string
+	fileETag           ETagFunc
+	xml                xmlOptions
+	markdown           markdownOptions
+	image              imageOptions
+	writeDeadline      time.Duration
+	directEncoding     bool
+	directEncoder      func(io.Writer, any) (bool, error)
+	jsonIndent         *jsonIndent
+	prefer             bool
+	jsonEscapeHTML     bool
+	jsonErrorShape     JSONErrorShape
+	errorInfoFormatter ErrorInfoFormatter
+	errorTemplates     *errorTemplates
+	errorStore         ErrorStore
+	debug              bool
+	localizer          Localizer
+	locales            []Locale
+	sitemapBaseURL     string
+	languages          []string
+	debugHeader        string
+	debugToken         func(string) bool
 }
 
-// Responder defines the interface for sending HTTP responses.
-type Responder interface {
+// SuccessSender sends the successful responses.
+type SuccessSender interface {
 	// Send200 sends a 200 OK response.
 	// It takes as second argument the data to be sent to the client.
 	Send200(responseWriter, any)
@@ -156,8 +296,84 @@ type Responder interface {
 	Send202(responseWriter, any)
 
 	// Send204 sends a 204 No Content response.
+	// No body and no Content-Length are written, whatever the formatter returns.
 	Send204(responseWriter)
 
+	// Send205 sends a 205 Reset Content response.
+	// No body is written and the Content-Length is set to zero.
+	Send205(responseWriter)
+
+	// Send304 sends a 304 Not Modified response to a conditional request,
+	// see NotModified. No body and no Content-Length are written.
+	Send304(responseWriter)
+
+	// Send206 sends the range of the content of the given total size requested
+	// by the Range header of the request in a 206 Partial Content response,
+	// with the Content-Range and Accept-Ranges headers. The whole content is sent
+	// in a 200 OK response when the request has no Range header, a Range header
+	// that is invalid or holds several ranges, or an If-Range precondition that
+	// does not match the ETag and Last-Modified headers set on the writer.
+	// A 416 Range Not Satisfiable response is sent when the range is beyond the content.
+	// The Content-Type set on the writer, if any, takes precedence over the responder's.
+	Send206(responseWriter, *http.Request, io.ReadSeeker, int64)
+
+	// SendSuccessStatus sends a response with the given 2xx status code, e.g. 206 or 207.
+	// It takes as third argument the data to be sent to the client.
+	// Other status codes result in a 500 Internal Server Error.
+	SendSuccessStatus(responseWriter, int, any)
+
+	// SendQueued reports the position of a job in a processing queue along with
+	// the estimated time before it is processed. It sends a 202 Accepted response,
+	// or a 429 Too Many Requests response when the position is negative, meaning
+	// the job could not be enqueued. The position and the estimation are sent
+	// in the X-Queue-Position and Retry-After headers as well as in the body.
+	SendQueued(responseWriter, int, time.Duration)
+
+	// SendPage sends a 200 OK response with the page of results, e.g. a Page,
+	// along with a Link header linking to the first, previous, next and last pages.
+	// The links are built from the URL of the request the responder is bound to,
+	// see WithRequest, and are query-only references when it is not bound.
+	SendPage(responseWriter, Paginated)
+
+	// SendSitemap sends a 200 OK response with the sitemap of the URLs, as an XML
+	// document whatever the content type of the responder. Above 50,000 URLs,
+	// the sitemap is split into parts served by the URL of the request the responder
+	// is bound to with a sitemap query parameter, e.g. /sitemap.xml?sitemap=2,
+	// the request without the parameter being sent the sitemap index of the parts.
+	SendSitemap(responseWriter, []SitemapURL)
+
+	// SendEcho sends a 200 OK response describing the request: its method,
+	// headers and query, values being redacted with the configured Redactor.
+	// The description is an Echo, sent as escaped plain text by the HTML responders.
+	// It is meant for diagnostic endpoints, e.g. to debug connectivity behind proxies.
+	SendEcho(responseWriter, *http.Request)
+
+	// SendFile serves the content of the file with the given name and modification
+	// time inline, with http.ServeContent which handles the range and conditional
+	// requests and detects the content type from the name or the content.
+	// See WithFileETag to send an ETag.
+	SendFile(responseWriter, *http.Request, string, io.ReadSeeker, time.Time)
+
+	// SendAttachment sends a 200 OK response with the data as an attachment saved
+	// under the given filename by the browsers. The raw data, i.e. strings, bytes
+	// and readers, is sent with the content type of the filename extension when known.
+	SendAttachment(responseWriter, string, any)
+
+	// SendBlob sends a 200 OK response with the bytes as is, bypassing the data
+	// formatter, with the given content type. When it is empty, the content type
+	// is detected from the bytes with http.DetectContentType.
+	SendBlob(responseWriter, []byte, string)
+
+	// SendImage sends a 200 OK response with the image encoded in the given format,
+	// png, jpeg or gif, PNG being used when it is empty, and the matching content type.
+	// See WithJPEGQuality and WithPNGCompression to tune the encoding.
+	// Other formats, e.g. WebP which has no standard encoder, result
+	// in a 500 Internal Server Error.
+	SendImage(responseWriter, image.Image, string)
+}
+
+// Redirector sends the redirect responses.
+type Redirector interface {
 	// Redirect301 sends a 301 Moved Permanently response to the given URL.
 	Redirect301(responseWriter, *http.Request, string)
 
@@ -170,6 +386,16 @@ type Responder interface {
 	// Redirect307 sends a 307 Temporary Redirect response to the given URL.
 	Redirect307(responseWriter, *http.Request, string)
 
+	// Redirect308 sends a 308 Permanent Redirect response to the given URL.
+	Redirect308(responseWriter, *http.Request, string)
+
+	// Redirect sends a redirect response with the given 3xx status code to the given URL.
+	// Status codes that are not redirects result in a 500 Internal Server Error.
+	Redirect(responseWriter, *http.Request, string, int)
+}
+
+// ErrorSender sends the client and server error responses.
+type ErrorSender interface {
 	// Send400 sends a 400 Bad Request response. It takes as second argument
 	// the error that caused the bad request, and as third argument a message
 	// to be sent to the client.
@@ -194,6 +420,49 @@ type Responder interface {
 	// The error will be logged if a logger was provided.
 	Send404(responseWriter, error, any)
 
+	// Send405 sends a 405 Method Not Allowed response. It takes as second argument
+	// the error that caused the method not allowed response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send405(responseWriter, error, any)
+
+	// Send406 sends a 406 Not Acceptable response. It takes as second argument
+	// the error that caused the not acceptable response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send406(responseWriter, error, any)
+
+	// Send409 sends a 409 Conflict response. It takes as second argument
+	// the error that caused the conflict, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send409(responseWriter, error, any)
+
+	// Send410 sends a 410 Gone response. It takes as second argument
+	// the error that caused the gone response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send410(responseWriter, error, any)
+
+	// Send415 sends a 415 Unsupported Media Type response. It takes as second argument
+	// the error that caused the unsupported media type response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send415(responseWriter, error, any)
+
+	// Send422 sends a 422 Unprocessable Entity response. It takes as second argument
+	// the error that caused the unprocessable entity response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send422(responseWriter, error, any)
+
+	// Send429 sends a 429 Too Many Requests response. It takes as second argument
+	// the error that caused the too many requests response, as third argument
+	// a message to be sent to the client, and as fourth argument the time after
+	// which the client may retry, sent in the Retry-After header when positive.
+	// The error will be logged if a logger was provided.
+	Send429(responseWriter, error, any, time.Duration)
+
 	// Send500 sends a 500 Internal Server Error response.
 	// It takes as second argument the error that caused the
 	// internal server error, and as third argument
@@ -201,17 +470,103 @@ type Responder interface {
 	// The error will be logged if a logger was provided.
 	Send500(responseWriter, error, any)
 
+	// Send501 sends a 501 Not Implemented response. It takes as second argument
+	// the error that caused the not implemented response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send501(responseWriter, error, any)
+
+	// Send502 sends a 502 Bad Gateway response. It takes as second argument
+	// the error that caused the bad gateway response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send502(responseWriter, error, any)
+
+	// Send503 sends a 503 Service Unavailable response. It takes as second argument
+	// the error that caused the service unavailable response, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send503(responseWriter, error, any)
+
+	// Send504 sends a 504 Gateway Timeout response. It takes as second argument
+	// the error that caused the gateway timeout, and as third argument
+	// a message to be sent to the client.
+	// The error will be logged if a logger was provided.
+	Send504(responseWriter, error, any)
+
+	// SendError sends the error response the error maps to, see WithErrorMapper.
+	// The error will be logged if a logger was provided.
+	SendError(responseWriter, error)
+
+	// SendValidation sends a 422 Unprocessable Entity response reporting
+	// the errors of the invalid fields, see Unprocessable.
+	SendValidation(responseWriter, []FieldError)
+
+	// SendErrorStatus sends a response with the given 4xx or 5xx status code, e.g. 418.
+	// It takes as third argument the error that caused the response, and as fourth
+	// argument a message to be sent to the client.
+	// Other status codes result in a 500 Internal Server Error.
+	// The error will be logged if a logger was provided.
+	SendErrorStatus(responseWriter, int, error, any)
+}
+
+// Streamer streams the responses as their data is produced.
+type Streamer interface {
+	// SendStream sends a 200 OK response streaming the values received from
+	// the channel as newline-delimited JSON, until the channel is closed.
+	// The data is flushed to the client whenever the channel has no value ready.
+	// Producers should stop on request cancellation since the stream stops
+	// reading the channel when the client goes away.
+	SendStream(responseWriter, <-chan any)
+
+	// SendSeq sends a 200 OK response streaming the values of the iterator
+	// as newline-delimited JSON, flushing the data to the client periodically.
+	SendSeq(responseWriter, iter.Seq[any])
+}
+
+// Responder defines the interface for sending HTTP responses.
+// It is the union of the SuccessSender, Redirector, ErrorSender and Streamer
+// interfaces. Consumers and mocks only needing a subset of the responses
+// can depend on the narrower interfaces instead.
+type Responder interface {
+	SuccessSender
+	Redirector
+	ErrorSender
+	Streamer
+
 	// Send sends a response with the given status code and body.
 	Send(responseWriter, Response)
+
+	// WithRequest returns a copy of the responder bound to the request
+	// being handled, enabling the features depending on it. The successful
+	// responses, the streams included, are not sent once the request is
+	// canceled, whereas the error responses and the responses of the
+	// requests exceeding their deadline still are.
+	WithRequest(*http.Request) Responder
+
+	// HandlerFunc adapts a handler returning the Response to send into an http.Handler,
+	// recovering the panics of the handler.
+	HandlerFunc(func(*http.Request) Response) http.Handler
+
+	// SelfTest exercises the configuration of the responder with sample responses,
+	// returning the aggregated failures, e.g. to catch misconfigurations at startup.
+	SelfTest() error
 }
 
 // New creates a new Responder with the given content type and options.
 func New(contentType string, optionsModifiers ...OptionsModifier) Responder {
 	o := &options{
 		errorFormatter: stringFormatter,
-		dataFormatter:  defaultDataFormatter,
+		redactor:       DefaultRedactor,
+		noStore:        DefaultNoStore,
+		defaultHeaders: make(http.Header),
+		compression:    newCompressionOptions(),
+		shadowRate:     1,
+		jsonEscapeHTML: true,
 	}
 
+	o.setFormatters(contentType)
+
 	for _, modify := range optionsModifiers {
 		modify(o)
 	}
@@ -222,118 +577,718 @@ func New(contentType string, optionsModifiers ...OptionsModifier) Responder {
 	}
 }
 
+// setFormatters sets the default encoders of the content type,
+// the registered encoder taking precedence over the built-in ones.
+func (o *options) setFormatters(contentType string) {
+	o.encoder = formatterEncoder{contentType: contentType, format: formatData}
+	o.directEncoder = nil
+
+	switch mt := mediaType(contentType); {
+	case mt == "text/csv":
+		o.encoder = formatterEncoder{contentType: contentType, format: o.formatCSV, localized: o.formatLocalizedCSV}
+	case mt == "application/xml", mt == "text/xml":
+		o.errorFormatter = xmlErrorFormatter
+		o.encoder = formatterEncoder{contentType: contentType, format: o.formatXML}
+		o.directEncoder = o.encodeXML
+	case mt == "text/html":
+		o.errorFormatter = htmlErrorFormatter
+		o.encoder = formatterEncoder{contentType: contentType, format: formatText}
+	case mt == XLSXContentType:
+		o.encoder = formatterEncoder{contentType: contentType, format: formatXLSX, localized: formatLocalizedXLSX}
+	case isJSON(contentType):
+		o.encoder = formatterEncoder{contentType: contentType, format: o.formatJSON}
+		o.directEncoder = o.encodeJSON
+	case strings.HasPrefix(mt, "text/"):
+		o.encoder = formatterEncoder{contentType: contentType, format: formatText}
+	}
+
+	if e := registeredEncoder(contentType); e != nil {
+		o.encoder = e
+		o.directEncoder = directEncoder(e)
+	}
+}
+
 type responder struct {
-	contentType string
-	options     *options
+	contentType  string
+	options      *options
+	request      *http.Request
+	boundAt      time.Time
+	checked      bool
+	cacheControl *CacheControl
+}
+
+func (r *responder) WithRequest(req *http.Request) Responder {
+	if r == nil {
+		return r
+	}
+
+	c := *r
+	c.request = req
+	c.boundAt = time.Now()
+
+	return &c
+}
+
+// bodyAllowed reports whether the status code permits a response body.
+func bodyAllowed(code int) bool {
+	switch {
+	case Is1xx(code):
+		return false
+	case code == status204, code == status205, code == status304:
+		return false
+	default:
+		return true
+	}
+}
+
+// mediaType returns the media type of the content type, without its parameters.
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return mt
+}
+
+// contentLengthAllowed reports whether a Content-Length header
+// may be sent along with the status code.
+func contentLengthAllowed(code int) bool {
+	return code == status205 || bodyAllowed(code)
+}
+
+// ready reports whether the responder is able to write to the writer.
+// A nil or zero-value responder, a nil writer and a writer the response is
+// already written to, see Guard, turn the send into a no-op.
+func (r *responder) ready(rw responseWriter) error {
+	if r == nil || r.options == nil {
+		return errNoResponder
+	}
+
+	if rw == nil {
+		if r.options.logger != nil {
+			r.options.logger.Error("cannot send response to a nil writer")
+		}
+
+		return ErrNilWriter
+	}
+
+	if Written(rw) {
+		if r.options.logger != nil {
+			r.options.logger.Warn("superfluous response suppressed, the response is already written")
+		}
+
+		return ErrAlreadyWritten
+	}
+
+	return nil
+}
+
+// conditional sets the ETag of the body and turns the response into
+// a 304 Not Modified when the request already holds the representation.
+// The ETag is weak when the body is compressed, the ETag being computed
+// from the uncompressed body.
+func (r *responder) conditional(rw responseWriter, body []byte) (int, []byte) {
+	etag := bodyETag(body, r.options.weakETag || r.compresses(rw, body))
+	rw.Header().Set("ETag", etag)
+
+	if r.request != nil && (r.request.Method == http.MethodGet || r.request.Method == http.MethodHead) &&
+		noneMatch(r.request, etag) {
+		return status304, nil
+	}
+
+	return status200, body
+}
+
+// prepareHeader sets the headers shared by all the responses
+// apart from the Content-Length, which depends on how the body is written.
+func (r *responder) prepareHeader(rw responseWriter, code int, contentType string) {
+	r.applyDefaultHeaders(rw)
+	rw.Header().Set("Content-Type", contentType)
+	r.setSEOHeaders(rw.Header(), contentType)
+	r.setChallenges(rw.Header(), code)
+	r.setContentDisposition(rw.Header(), code)
+	r.setCacheControl(rw.Header(), code)
+	r.varyLocale(rw.Header())
+
+	if r.options.quota != nil && r.request != nil {
+		setQuotaHeaders(rw.Header(), r.options.quota(r.request))
+	}
+
+	if r.options.noStore != nil && r.options.noStore(code) && rw.Header().Get("Cache-Control") == "" {
+		rw.Header().Set("Cache-Control", "no-store")
+	}
+
+	if r.options.slo != nil {
+		r.classify(rw, code)
+	}
 }
 
-func (r responder) send(rw responseWriter, code int, body []byte) {
-	rw.Header().Set("Content-Type", r.contentType)
-	rw.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+// classify applies the SLO policy to the response.
+func (r *responder) classify(rw responseWriter, code int) {
+	var latency time.Duration
+	if !r.boundAt.IsZero() {
+		latency = time.Since(r.boundAt)
+	}
+
+	class := r.options.slo.classify(code, latency)
+
+	if r.options.slo.Header != "" {
+		rw.Header().Set(r.options.slo.Header, string(class))
+	}
+
+	if r.options.slo.Observe != nil {
+		r.options.slo.Observe(code, latency, class)
+	}
+}
+
+func (r *responder) send(rw responseWriter, code int, body []byte, serialization time.Duration) error {
+	if err := r.canceled(code); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	if sent, err := r.injectError(rw, code); sent {
+		return err
+	}
+
+	r.injectDelay(code)
+
+	code, body = r.applyPreferences(rw, code, body)
+
+	if r.options.jsonTransform != nil && isJSON(r.contentType) {
+		body = transformJSON(body, r.options.jsonTransform)
+	}
+
+	if r.options.jsonIndent != nil && isJSON(r.contentType) && len(body) > 0 {
+		body = r.options.jsonIndent.apply(body)
+	}
+
+	body, mark := r.watermark(code, body)
+
+	if r.options.emptyBodyAs204 && code == status200 && len(body) == 0 {
+		if r.options.logger != nil {
+			r.options.logger.Info("sending 204 No Content for an empty 200 OK response")
+		}
+
+		code = status204
+	}
+
+	if r.options.etag && code == status200 {
+		code, body = r.conditional(rw, body)
+	}
+
+	if !bodyAllowed(code) {
+		if len(body) > 0 && r.options.logBodyViolations && r.options.logger != nil {
+			r.options.logger.Warn("discarding body for a status that forbids content",
+				"status", code,
+				"size", len(body),
+			)
+		}
+
+		body = nil
+	}
+
+	body, contentType, err := r.encrypt(code, body)
+	if err != nil {
+		return r.sendFailure(rw, err)
+	}
+
+	r.prepareHeader(rw, code, contentType)
+	body = r.compress(rw, code, contentType, body)
+
+	if contentLengthAllowed(code) {
+		rw.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+	} else {
+		rw.Header().Del("Content-Length")
+	}
+
+	r.beforeSend(rw, code, body)
 	rw.WriteHeader(code)
 
-	_, err := rw.Write(body)
-	if err != nil && r.options.logger != nil {
-		r.options.logger.Error("failed to write response",
-			"status", code,
-			"error", err,
-		)
+	body = r.injectTruncation(code, body)
+	w, digest := r.journalWriter(r.throttle(r.extendDeadline(rw, rw)))
+
+	var n int
+
+	if len(body) > 0 {
+		n, err = w.Write(body)
+		if err != nil && r.options.logger != nil {
+			r.options.logger.Error("failed to write response",
+				"status", code,
+				"error", err,
+			)
+		}
+	}
+
+	r.record(rw, code, digest, err, mark)
+	r.recordMetrics(rw, code, n, serialization, serialization+time.Since(start))
+	r.afterSend(code, n, err)
+
+	return err
+}
+
+// applyDefaultHeaders sets the default headers that are not already set,
+// including the request ID echo.
+func (r *responder) applyDefaultHeaders(rw responseWriter) {
+	for k, v := range r.options.defaultHeaders {
+		if _, ok := rw.Header()[k]; !ok {
+			// Capping the capacity makes appends on the writer reallocate
+			// rather than modifying the shared values.
+			rw.Header()[k] = v[:len(v):len(v)]
+		}
+	}
+
+	r.echoRequestID(rw)
+}
+
+// format encodes the data with the encoder, reporting its failures
+// as ErrInvalidContent. Checked responders also report the formatter panics.
+func (r *responder) format(data any) (body []byte, err error) {
+	if r.checked {
+		defer recoverFormatter(&err)
+	}
+
+	body, err = encodeValue(r.encoder(), data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContent, err)
+	}
+
+	return body, nil
+}
+
+// formatMessage formats the error message with the error and data formatters,
+// or with the error info formatter, the error templates or the JSON error shape
+// when one is set.
+func (r *responder) formatMessage(info ErrorInfo) (body []byte, err error) {
+	if r.checked {
+		defer recoverFormatter(&err)
+	}
+
+	if r.options.errorInfoFormatter != nil {
+		return r.format(r.options.errorInfoFormatter(info))
+	}
+
+	if r.options.errorTemplates != nil && mediaType(r.contentType) == "text/html" {
+		return r.renderErrorPage(info)
+	}
+
+	if r.options.jsonErrorShape != nil && isJSON(r.contentType) {
+		return r.format(r.options.jsonErrorShape(info.Status, info.Err, info.Message))
+	}
+
+	return r.format(r.options.errorFormatter(info.Message))
+}
+
+// recoverFormatter turns a formatter panic into an ErrInvalidContent error.
+func recoverFormatter(err *error) {
+	if v := recover(); v != nil {
+		*err = fmt.Errorf("%w: formatter panicked: %v", ErrInvalidContent, v)
+	}
+}
+
+// sendFailure reports the failure preventing the response from being sent
+// to checked responders, and sends a 500 Internal Server Error otherwise.
+func (r *responder) sendFailure(rw responseWriter, err error) error {
+	if r.checked {
+		return err
+	}
+
+	return r.sendError(rw, status500, err, http.StatusText(status500))
+}
+
+// sendData formats the data and sends it with the given status code.
+func (r *responder) sendData(rw responseWriter, code int, data any) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	switch data.(type) {
+	case io.Reader, io.WriterTo:
+		if r.options.encrypter != nil {
+			return r.sendBuffered(rw, code, data)
+		}
+
+		return r.streamBody(rw, code, data)
+	}
+
+	if r.encodesDirectly(code) {
+		return r.shadowed(rw, SuccessResponse{status: code, body: data}, func(rw responseWriter) error {
+			return r.encodeDirect(rw, code, data)
+		})
 	}
+
+	started := time.Now()
+
+	body, err := r.format(data)
+	if err != nil {
+		return r.sendFailure(rw, err)
+	}
+
+	serialization := time.Since(started)
+
+	return r.shadowed(rw, SuccessResponse{status: code, body: data}, func(rw responseWriter) error {
+		return r.send(rw, code, body, serialization)
+	})
+}
+
+// sendError logs the error, formats the message and sends it with the given status code.
+func (r *responder) sendError(rw responseWriter, code int, err error, message any) error {
+	return r.sendLeveledError(rw, code, err, message, nil)
+}
+
+// sendLeveledError sends the error response, logging the error at the given level,
+// or at the error level when it is nil.
+func (r *responder) sendLeveledError(rw responseWriter, code int, err error, message any, level slog.Leveler) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	if binaryContentType(r.contentType) {
+		// The error messages are sent as text rather than as a workbook or an image.
+		r = r.partResponder(TextContentType)
+	}
+
+	var attrs []any
+
+	id := r.storeError(rw, code, err, message)
+	if id != "" {
+		attrs = append(attrs, "error_id", id)
+	}
+
+	r.logError(err, code, message, level, attrs...)
+	r.traceError(code, err)
+
+	message = r.localize(rw, message)
+	started := time.Now()
+
+	body, ferr := r.formatMessage(r.errorInfo(code, err, r.debugMessage(err, message), id))
+	if ferr != nil {
+		if r.checked {
+			return ferr
+		}
+
+		// The status text is sent in place of the error body that cannot be formatted.
+		r.logError(ferr, code, message, nil)
+		body, r = []byte(http.StatusText(code)), r.withContentType(TextContentType)
+	}
+
+	serialization := time.Since(started)
+
+	if code >= status500 && r.options.incident != nil && mediaType(r.contentType) == "text/html" {
+		if incident := r.options.incident(); incident != nil {
+			body = injectBanner(body, incident.banner())
+		}
+	}
+
+	resp := ErrorResponse{status: code, err: err, message: message, level: level}
+
+	return r.shadowed(rw, resp, func(rw responseWriter) error {
+		return r.send(rw, code, body, serialization)
+	})
+}
+
+// redirect replies to the request with a redirect to the location.
+func (r *responder) redirect(rw responseWriter, req *http.Request, loc string, code int) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	if ok, err := r.validateRedirect(rw, loc); !ok {
+		return err
+	}
+
+	r.applyDefaultHeaders(rw)
+
+	w := &ResponseRecorderWriter{ResponseWriter: rw, start: time.Now()}
+	http.Redirect(w, req, loc, code)
+
+	r.record(rw, code, nil, nil, "")
+	r.recordMetrics(rw, code, w.BytesWritten(), 0, w.Duration())
+	r.afterSend(code, w.BytesWritten(), nil)
+
+	return nil
 }
 
-func (r *responder) logError(err error, code int, message any) {
+func (r *responder) logError(err error, code int, message any, level slog.Leveler, extra ...any) {
 	if err == nil || r.options.logger == nil {
 		return
 	}
 
-	r.options.logger.Error(internal.MessageToString(message),
+	if level == nil && r.options.logLevelMapper != nil {
+		level = r.options.logLevelMapper(code)
+	}
+
+	if level == nil {
+		level = slog.LevelError
+	}
+
+	attrs := []any{
 		"status", code,
 		"error", err,
-	)
+	}
+
+	attrs = append(attrs, extra...)
+	attrs = append(attrs, r.authAttrs(code)...)
+
+	if r.options.requestIDHeader != "" {
+		if id := r.requestID(); id != "" {
+			attrs = append(attrs, "request_id", id)
+		}
+	}
+
+	if r.options.structuredErrorLog {
+		attrs = append(attrs, errorAttr(r.requestID(), code, err, message))
+	}
+
+	ctx := r.context()
+
+	if r.options.logAttrs != nil {
+		for _, a := range r.options.logAttrs(ctx) {
+			attrs = append(attrs, a)
+		}
+	}
+
+	r.options.logger.Log(ctx, level.Level(), internal.MessageToString(message), attrs...)
 }
 
-func (r *responder) Send(rw responseWriter, resp Response) {
+// sendResponse applies the headers of the response and sends it.
+func (r *responder) sendResponse(rw responseWriter, resp Response) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
 	switch v := resp.(type) {
 	case ErrorResponse:
-		r.logError(v.err, v.status, v.message)
-		r.send(rw, resp.Status(), r.options.dataFormatter(
-			r.options.errorFormatter(v.message),
-		))
+		applyHeader(rw, v.header)
+
+		return r.withContentType(v.contentType).withCacheControl(v.cacheControl).sendLeveledError(rw, v.status, v.err, v.message, v.level)
 	case SuccessResponse:
-		r.send(rw, resp.Status(), r.options.dataFormatter(
-			v.body,
-		))
+		applyHeader(rw, v.header)
+
+		return r.withContentType(v.contentType).withCacheControl(v.cacheControl).sendData(rw, v.status, v.body)
+	case MultipartResponse:
+		return r.sendMultipart(rw, v)
+	case nil:
+		err := errors.New("nil response")
+		r.logError(err, status500, "failed to send response", nil)
+
+		return err
 	default:
-		r.logError(fmt.Errorf("unknown response type %T", resp),
-			resp.Status(),
-			"failed to send response",
-		)
+		err := fmt.Errorf("unknown response type %T", resp)
+		r.logError(err, resp.Status(), "failed to send response", nil)
+
+		return err
+	}
+}
+
+// withContentType returns a copy of the responder sending the content type,
+// or the responder itself when the content type is empty. The data are
+// formatted with the default encoders of the content type when its media type
+// differs from the one of the responder, as for the multipart parts, so that
+// the body matches the header, the encoders of the responder being kept otherwise.
+func (r *responder) withContentType(contentType string) *responder {
+	if contentType == "" {
+		return r
+	}
+
+	if mediaType(contentType) != mediaType(r.contentType) {
+		return r.partResponder(contentType)
+	}
+
+	c := *r
+	c.contentType = contentType
+
+	return &c
+}
+
+// sendQueued sends the position of a job in a processing queue.
+func (r *responder) sendQueued(rw responseWriter, position int, eta time.Duration) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	rw.Header().Set("Retry-After", retryAfter(eta))
+
+	if position < 0 {
+		return r.sendError(rw, status429, nil, "the queue is full")
+	}
+
+	rw.Header().Set("X-Queue-Position", strconv.Itoa(position))
+
+	return r.sendData(rw, status202, QueueStatus{
+		Position:   position,
+		ETASeconds: int(durationSeconds(eta)),
+	})
+}
+
+// sendEmpty sends a response without body.
+func (r *responder) sendEmpty(rw responseWriter, code int) error {
+	if err := r.ready(rw); err != nil {
+		return err
 	}
+
+	return r.send(rw, code, nil, 0)
+}
+
+func (r *responder) Send(rw responseWriter, resp Response) {
+	_ = r.sendResponse(rw, resp)
 }
 
 func (r *responder) Send200(rw responseWriter, data any) {
-	r.send(rw, status200, r.options.dataFormatter(data))
+	_ = r.sendData(rw, status200, data)
 }
 
 func (r *responder) Send201(rw responseWriter, data any) {
-	r.send(rw, status201, r.options.dataFormatter(data))
+	_ = r.sendData(rw, status201, data)
 }
 
 func (r *responder) Send202(rw responseWriter, data any) {
-	r.send(rw, status202, r.options.dataFormatter(data))
+	_ = r.sendData(rw, status202, data)
 }
 
 func (r *responder) Send204(rw responseWriter) {
-	r.send(rw, status204, r.options.dataFormatter(nil))
+	_ = r.sendEmpty(rw, status204)
+}
+
+func (r *responder) Send205(rw responseWriter) {
+	_ = r.sendEmpty(rw, status205)
+}
+
+func (r *responder) Send304(rw responseWriter) {
+	_ = r.sendEmpty(rw, status304)
+}
+
+func (r *responder) SendQueued(rw responseWriter, position int, eta time.Duration) {
+	_ = r.sendQueued(rw, position, eta)
+}
+
+func (r *responder) SendPage(rw responseWriter, p Paginated) {
+	_ = r.sendPage(rw, p)
+}
+
+func (r *responder) SendSitemap(rw responseWriter, urls []SitemapURL) {
+	_ = r.sendSitemap(rw, urls)
+}
+
+func (r *responder) SendEcho(rw responseWriter, req *http.Request) {
+	_ = r.sendEcho(rw, req)
+}
+
+// sendEcho sends the description of the request.
+func (r *responder) sendEcho(rw responseWriter, req *http.Request) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	echo := newEcho(req, r.options.redactor)
+
+	if mediaType(r.contentType) == "text/html" {
+		// The values come from the client, they are escaped rather than sent as is.
+		return r.send(rw, status200, []byte("<pre>"+html.EscapeString(echo.String())+"</pre>"), 0)
+	}
+
+	return r.sendData(rw, status200, echo)
+}
+
+func (r *responder) Redirect301(rw responseWriter, req *http.Request, loc string) {
+	_ = r.redirect(rw, req, loc, status301)
 }
 
-func (responder) Redirect301(rw responseWriter, req *http.Request, loc string) {
-	http.Redirect(rw, req, loc, status301)
+func (r *responder) Redirect302(rw responseWriter, req *http.Request, loc string) {
+	_ = r.redirect(rw, req, loc, status302)
 }
 
-func (responder) Redirect302(rw responseWriter, req *http.Request, loc string) {
-	http.Redirect(rw, req, loc, status302)
+func (r *responder) Redirect303(rw responseWriter, req *http.Request, loc string) {
+	_ = r.redirect(rw, req, loc, status303)
 }
 
-func (responder) Redirect303(rw responseWriter, req *http.Request, loc string) {
-	http.Redirect(rw, req, loc, status303)
+func (r *responder) Redirect307(rw responseWriter, req *http.Request, loc string) {
+	_ = r.redirect(rw, req, loc, status307)
 }
 
-func (responder) Redirect307(rw responseWriter, req *http.Request, loc string) {
-	http.Redirect(rw, req, loc, status307)
+func (r *responder) Redirect308(rw responseWriter, req *http.Request, loc string) {
+	_ = r.redirect(rw, req, loc, status308)
+}
+
+func (r *responder) Redirect(rw responseWriter, req *http.Request, loc string, code int) {
+	_ = r.redirectStatus(rw, req, loc, code)
 }
 
 func (r *responder) Send400(rw responseWriter, err error, message any) {
-	r.logError(err, status400, message)
-	r.send(rw, status400, r.options.dataFormatter(
-		r.options.errorFormatter(message)),
-	)
+	_ = r.sendError(rw, status400, err, message)
 }
 
 func (r *responder) Send401(rw responseWriter, err error, message any) {
-	r.logError(err, status401, message)
-	r.send(rw, status401, r.options.dataFormatter(
-		r.options.errorFormatter(message)),
-	)
+	_ = r.sendError(rw, status401, err, message)
 }
 
 func (r *responder) Send403(rw responseWriter, err error, message any) {
-	r.logError(err, status403, message)
-	r.send(rw, status403, r.options.dataFormatter(
-		r.options.errorFormatter(message)),
-	)
+	_ = r.sendError(rw, status403, err, message)
 }
 
 func (r *responder) Send404(rw responseWriter, err error, message any) {
-	r.logError(err, status404, message)
-	r.send(rw, status404, r.options.dataFormatter(
-		r.options.errorFormatter(message)),
-	)
+	_ = r.sendError(rw, status404, err, message)
+}
+
+func (r *responder) Send405(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status405, err, message)
+}
+
+func (r *responder) Send406(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status406, err, message)
+}
+
+func (r *responder) Send409(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status409, err, message)
+}
+
+func (r *responder) Send410(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status410, err, message)
+}
+
+func (r *responder) Send415(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status415, err, message)
+}
+
+func (r *responder) Send422(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status422, err, message)
+}
+
+func (r *responder) Send429(rw responseWriter, err error, message any, after time.Duration) {
+	_ = r.sendTooManyRequests(rw, err, message, after)
+}
+
+// sendTooManyRequests sends a 429 Too Many Requests response with its Retry-After header.
+func (r *responder) sendTooManyRequests(rw responseWriter, err error, message any, after time.Duration) error {
+	if rerr := r.ready(rw); rerr != nil {
+		return rerr
+	}
+
+	if after > 0 {
+		rw.Header().Set("Retry-After", retryAfter(after))
+	}
+
+	return r.sendError(rw, status429, err, message)
 }
 
 func (r *responder) Send500(rw responseWriter, err error, message any) {
-	r.logError(err, status500, message)
-	r.send(rw, status500, r.options.dataFormatter(
-		r.options.errorFormatter(message)),
-	)
+	_ = r.sendError(rw, status500, err, message)
+}
+
+func (r *responder) Send501(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status501, err, message)
+}
+
+func (r *responder) Send502(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status502, err, message)
+}
+
+func (r *responder) Send503(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status503, err, message)
+}
+
+func (r *responder) Send504(rw responseWriter, err error, message any) {
+	_ = r.sendError(rw, status504, err, message)
 }
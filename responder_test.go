@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestContentFormatter(t *testing.T) {
 	t.Run("handles nil content", func(t *testing.T) {
-		result := defaultDataFormatter(nil)
+		result, _ := formatData(nil)
 		if len(result) != 0 {
 			t.Errorf("expected empty byte slice for nil, got %v", result)
 		}
@@ -19,7 +24,7 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles string content", func(t *testing.T) {
 		input := "Hello, World!"
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 		expected := []byte(input)
 
 		if !bytes.Equal(result, expected) {
@@ -29,7 +34,7 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles empty string content", func(t *testing.T) {
 		input := ""
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		if len(result) != 0 {
 			t.Errorf("expected empty string, got %q", string(result))
@@ -38,7 +43,7 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles byte slice content", func(t *testing.T) {
 		input := []byte("byte content")
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		if !bytes.Equal(result, input) {
 			t.Errorf("expected %q, got %q", string(input), string(result))
@@ -47,7 +52,7 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles empty byte slice", func(t *testing.T) {
 		input := []byte{}
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		if len(result) != 0 {
 			t.Errorf("expected empty byte slice, got %v", result)
@@ -61,7 +66,7 @@ func TestContentFormatter(t *testing.T) {
 		}
 
 		input := CustomJSON{Name: "test", Value: 42}
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		// Now it should successfully marshal via the default case
 		var parsed CustomJSON
@@ -77,7 +82,7 @@ func TestContentFormatter(t *testing.T) {
 	t.Run("handles struct implementing json.Marshaler", func(t *testing.T) {
 		// Create a proper json.Marshaler
 		marshaler := customJSONMarshaler{value: "custom_value"}
-		result := defaultDataFormatter(marshaler)
+		result, _ := formatData(marshaler)
 
 		var parsed map[string]string
 		if err := json.Unmarshal(result, &parsed); err != nil {
@@ -91,17 +96,16 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles json.Marshaler with error", func(t *testing.T) {
 		marshaler := errorJSONMarshaler{}
-		result := defaultDataFormatter(marshaler)
+		_, err := formatData(marshaler)
 
-		expectedPrefix := "received invalid content"
-		if !strings.Contains(string(result), expectedPrefix) {
-			t.Errorf("expected error message to contain %q, got %q", expectedPrefix, string(result))
+		if err == nil {
+			t.Errorf("expected an error")
 		}
 	})
 
 	t.Run("handles xml.Marshaler implementation", func(t *testing.T) {
 		marshaler := customXMLMarshaler{Name: "test", Value: 42}
-		result := defaultDataFormatter(marshaler)
+		result, _ := formatData(marshaler)
 
 		// Verify it was marshaled as XML
 		var parsed customXMLMarshaler
@@ -116,17 +120,16 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles xml.Marshaler with error", func(t *testing.T) {
 		marshaler := errorXMLMarshaler{}
-		result := defaultDataFormatter(marshaler)
+		_, err := formatData(marshaler)
 
-		expectedPrefix := "received invalid content"
-		if !strings.Contains(string(result), expectedPrefix) {
-			t.Errorf("expected error message to contain %q, got %q", expectedPrefix, string(result))
+		if err == nil {
+			t.Errorf("expected an error")
 		}
 	})
 
 	t.Run("handles encoding.TextMarshaler implementation", func(t *testing.T) {
 		marshaler := customTextMarshaler{value: "text_value"}
-		result := defaultDataFormatter(marshaler)
+		result, _ := formatData(marshaler)
 
 		expected := "TEXT:text_value"
 		if string(result) != expected {
@@ -136,11 +139,53 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("handles encoding.TextMarshaler with error", func(t *testing.T) {
 		marshaler := errorTextMarshaler{}
-		result := defaultDataFormatter(marshaler)
+		_, err := formatData(marshaler)
 
-		expectedPrefix := "received invalid content"
-		if !strings.Contains(string(result), expectedPrefix) {
-			t.Errorf("expected error message to contain %q, got %q", expectedPrefix, string(result))
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("handles fmt.Stringer as plain text", func(t *testing.T) {
+		result, _ := formatText(customStringer{value: "stringer"})
+
+		expected := "STRING:stringer"
+		if string(result) != expected {
+			t.Errorf("expected %q, got %q", expected, string(result))
+		}
+	})
+
+	t.Run("handles error as plain text", func(t *testing.T) {
+		result, _ := formatText(fmt.Errorf("something %s", "failed"))
+
+		expected := "something failed"
+		if string(result) != expected {
+			t.Errorf("expected %q, got %q", expected, string(result))
+		}
+	})
+
+	t.Run("prefers marshalers over fmt.Stringer", func(t *testing.T) {
+		result, _ := formatText(stringerTextMarshaler{})
+
+		expected := "text"
+		if string(result) != expected {
+			t.Errorf("expected %q, got %q", expected, string(result))
+		}
+	})
+
+	t.Run("marshals fmt.Stringer and error for the JSON responders", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		JSONResponder().Send200(w, customStringer{value: "stringer"})
+
+		if b := w.Body.String(); b != "{}" {
+			t.Errorf("expected the value to be marshaled, got %q", b)
+		}
+
+		w = httptest.NewRecorder()
+		TextResponder().Send200(w, customStringer{value: "stringer"})
+
+		if b := w.Body.String(); b != "STRING:stringer" {
+			t.Errorf("expected the value to be sent as text, got %q", b)
 		}
 	})
 
@@ -150,7 +195,7 @@ func TestContentFormatter(t *testing.T) {
 		}
 
 		input := SimpleStruct{Field: "value"}
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		// Should now successfully marshal via the default case
 		var parsed SimpleStruct
@@ -257,7 +302,7 @@ func TestContentFormatter(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
-				result := defaultDataFormatter(tc.input)
+				result, _ := formatData(tc.input)
 				tc.validate(t, result)
 			})
 		}
@@ -275,11 +320,8 @@ func TestContentFormatter(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
-				result := defaultDataFormatter(tc.input)
-
-				expectedPrefix := "received invalid content"
-				if !strings.Contains(string(result), expectedPrefix) {
-					t.Errorf("expected error message for %s, got %q", tc.name, string(result))
+				if _, err := formatData(tc.input); err == nil {
+					t.Errorf("expected an error for %s", tc.name)
 				}
 			})
 		}
@@ -294,7 +336,7 @@ func TestContentFormatter(t *testing.T) {
 
 		input := builder.String()
 
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		if string(result) != input {
 			t.Errorf("large string content mismatch: expected %d bytes, got %d bytes",
@@ -309,7 +351,7 @@ func TestContentFormatter(t *testing.T) {
 			input[i] = byte(i % 256)
 		}
 
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		if len(result) != len(input) {
 			t.Errorf("expected %d bytes, got %d bytes", len(input), len(result))
@@ -318,7 +360,7 @@ func TestContentFormatter(t *testing.T) {
 
 	t.Run("preserves byte slice reference", func(t *testing.T) {
 		input := []byte("test")
-		result := defaultDataFormatter(input)
+		result, _ := formatData(input)
 
 		// Modify the result and check if input is affected
 		result[0] = 'X'
@@ -330,6 +372,95 @@ func TestContentFormatter(t *testing.T) {
 	})
 }
 
+func TestBodylessStatuses(t *testing.T) {
+	t.Run("Send204 ignores the formatter output", func(t *testing.T) {
+		responder := TextResponder(WithDataFormatter(func(any) []byte {
+			return []byte("unexpected")
+		}))
+		w := httptest.NewRecorder()
+
+		responder.Send204(w)
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected empty body, got %q", w.Body.String())
+		}
+
+		if _, ok := w.Header()["Content-Length"]; ok {
+			t.Errorf("expected no Content-Length header, got %q", w.Header().Get("Content-Length"))
+		}
+	})
+
+	t.Run("Send205 writes a zero Content-Length", func(t *testing.T) {
+		responder := TextResponder()
+		w := httptest.NewRecorder()
+
+		responder.Send205(w)
+
+		if w.Code != http.StatusResetContent {
+			t.Errorf("expected status %d, got %d", http.StatusResetContent, w.Code)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected empty body, got %q", w.Body.String())
+		}
+
+		if cl := w.Header().Get("Content-Length"); cl != "0" {
+			t.Errorf("expected Content-Length %q, got %q", "0", cl)
+		}
+	})
+
+	t.Run("Send discards the body of a 304 response", func(t *testing.T) {
+		responder := TextResponder()
+		w := httptest.NewRecorder()
+
+		responder.Send(w, Success(http.StatusNotModified, "body"))
+
+		if w.Code != http.StatusNotModified {
+			t.Errorf("expected status %d, got %d", http.StatusNotModified, w.Code)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected empty body, got %q", w.Body.String())
+		}
+
+		if _, ok := w.Header()["Content-Length"]; ok {
+			t.Errorf("expected no Content-Length header, got %q", w.Header().Get("Content-Length"))
+		}
+	})
+
+	t.Run("logs violations when enabled", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		responder := TextResponder(WithLogger(logger), WithBodyViolationLogging(true))
+		w := httptest.NewRecorder()
+
+		responder.Send(w, Success(http.StatusNoContent, "body"))
+
+		if !strings.Contains(logs.String(), "discarding body") {
+			t.Errorf("expected a violation to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("does not log violations by default", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		responder := TextResponder(WithLogger(logger))
+		w := httptest.NewRecorder()
+
+		responder.Send(w, Success(http.StatusNoContent, "body"))
+
+		if logs.Len() != 0 {
+			t.Errorf("expected no logs, got %q", logs.String())
+		}
+	})
+}
+
 // Helper types for testing marshalers
 
 type customJSONMarshaler struct {
@@ -360,6 +491,24 @@ func (e errorTextMarshaler) MarshalText() ([]byte, error) {
 	return nil, fmt.Errorf("intentional text marshal error")
 }
 
+type customStringer struct {
+	value string
+}
+
+func (c customStringer) String() string {
+	return "STRING:" + c.value
+}
+
+type stringerTextMarshaler struct{}
+
+func (stringerTextMarshaler) String() string {
+	return "string"
+}
+
+func (stringerTextMarshaler) MarshalText() ([]byte, error) {
+	return []byte("text"), nil
+}
+
 type customXMLMarshaler struct {
 	XMLName xml.Name `xml:"custom"`
 	Name    string   `xml:"name"`
@@ -389,3 +538,242 @@ type errorXMLMarshaler struct{}
 func (e errorXMLMarshaler) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return fmt.Errorf("intentional XML marshal error")
 }
+
+func TestClientErrors(t *testing.T) {
+	testCases := []struct {
+		status int
+		send   func(Responder, responseWriter, error, any)
+	}{
+		{status: http.StatusMethodNotAllowed, send: Responder.Send405},
+		{status: http.StatusNotAcceptable, send: Responder.Send406},
+		{status: http.StatusConflict, send: Responder.Send409},
+		{status: http.StatusGone, send: Responder.Send410},
+		{status: http.StatusUnsupportedMediaType, send: Responder.Send415},
+		{status: http.StatusUnprocessableEntity, send: Responder.Send422},
+	}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			var logs bytes.Buffer
+
+			w := httptest.NewRecorder()
+			responder := JSONResponder(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+			tc.send(responder, w, errors.New("cause"), "message")
+
+			if w.Code != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, w.Code)
+			}
+
+			if want := `{"error":"message"}`; w.Body.String() != want {
+				t.Errorf("expected body %q, got %q", want, w.Body.String())
+			}
+
+			if !strings.Contains(logs.String(), "error=cause") {
+				t.Errorf("expected the error to be logged, got %q", logs.String())
+			}
+		})
+	}
+}
+
+func TestSend429(t *testing.T) {
+	t.Run("sets the Retry-After header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send429(w, nil, "slow down", 1500*time.Millisecond)
+
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+		}
+
+		if got := w.Header().Get("Retry-After"); got != "2" {
+			t.Errorf("expected Retry-After %q, got %q", "2", got)
+		}
+
+		if want := `{"error":"slow down"}`; w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+
+	t.Run("omits the Retry-After header without duration", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send429(w, nil, "slow down", 0)
+
+		if got := w.Header().Get("Retry-After"); got != "" {
+			t.Errorf("expected no Retry-After, got %q", got)
+		}
+	})
+}
+
+func TestServerErrors(t *testing.T) {
+	testCases := []struct {
+		status int
+		send   func(Responder, responseWriter, error, any)
+	}{
+		{status: http.StatusNotImplemented, send: Responder.Send501},
+		{status: http.StatusBadGateway, send: Responder.Send502},
+		{status: http.StatusServiceUnavailable, send: Responder.Send503},
+		{status: http.StatusGatewayTimeout, send: Responder.Send504},
+	}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			var logs bytes.Buffer
+
+			w := httptest.NewRecorder()
+			responder := JSONResponder(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+			tc.send(responder, w, errors.New("upstream"), "message")
+
+			if w.Code != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, w.Code)
+			}
+
+			if want := `{"error":"message"}`; w.Body.String() != want {
+				t.Errorf("expected body %q, got %q", want, w.Body.String())
+			}
+
+			if !strings.Contains(logs.String(), "level=ERROR") || !strings.Contains(logs.String(), "error=upstream") {
+				t.Errorf("expected the error to be logged, got %q", logs.String())
+			}
+		})
+	}
+}
+
+func TestMarshalingFailures(t *testing.T) {
+	t.Run("sends a 500 with the error body", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		w := httptest.NewRecorder()
+		responder := JSONResponder(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+		responder.Send200(w, map[string]any{"c": make(chan int)})
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+
+		if want := `{"error":"Internal Server Error"}`; w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+
+		if !strings.Contains(logs.String(), "level=ERROR") || !strings.Contains(logs.String(), "unsupported type") {
+			t.Errorf("expected the failure to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("sends the status text when the error body fails as well", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		responder := JSONResponder(WithErrorFormatter(func(any) any { return make(chan int) }))
+
+		responder.Send200(w, make(chan int))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != TextContentType {
+			t.Errorf("expected the text content type, got %q", ct)
+		}
+
+		if want := "Internal Server Error"; w.Body.String() != want {
+			t.Errorf("expected body %q, got %q", want, w.Body.String())
+		}
+	})
+}
+
+func TestWithEmptyBodyAs204(t *testing.T) {
+	t.Run("downgrades empty 200 responses", func(t *testing.T) {
+		for _, data := range []any{nil, "", []byte{}} {
+			var logs bytes.Buffer
+
+			w := httptest.NewRecorder()
+			responder := TextResponder(WithEmptyBodyAs204(true), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+			responder.Send200(w, data)
+
+			if w.Code != http.StatusNoContent {
+				t.Errorf("expected status %d for %#v, got %d", http.StatusNoContent, data, w.Code)
+			}
+
+			if cl := w.Header().Get("Content-Length"); cl != "" {
+				t.Errorf("expected no Content-Length, got %q", cl)
+			}
+
+			if !strings.Contains(logs.String(), "204 No Content") {
+				t.Errorf("expected the downgrade to be logged, got %q", logs.String())
+			}
+		}
+	})
+
+	t.Run("keeps 200 responses with a body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithEmptyBodyAs204(true)).Send200(w, "data")
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send200(w, "")
+
+		if w.Code != http.StatusOK || w.Header().Get("Content-Length") != "0" {
+			t.Errorf("expected an empty 200, got %d with Content-Length %q", w.Code, w.Header().Get("Content-Length"))
+		}
+	})
+}
+
+type notFoundSender struct {
+	ErrorSender
+	sent int
+}
+
+func (s *notFoundSender) Send404(w http.ResponseWriter, _ error, _ any) {
+	s.sent++
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func TestInterfaceSplit(t *testing.T) {
+	t.Run("responders implement the narrower interfaces", func(t *testing.T) {
+		var r Responder = JSONResponder()
+
+		for _, v := range []any{r, Noop()} {
+			if _, ok := v.(SuccessSender); !ok {
+				t.Errorf("expected %T to implement SuccessSender", v)
+			}
+
+			if _, ok := v.(Redirector); !ok {
+				t.Errorf("expected %T to implement Redirector", v)
+			}
+
+			if _, ok := v.(ErrorSender); !ok {
+				t.Errorf("expected %T to implement ErrorSender", v)
+			}
+
+			if _, ok := v.(Streamer); !ok {
+				t.Errorf("expected %T to implement Streamer", v)
+			}
+		}
+	})
+
+	t.Run("consumers can depend on a subset of the responses", func(t *testing.T) {
+		mock := &notFoundSender{}
+		handler := func(s ErrorSender) http.HandlerFunc {
+			return func(w http.ResponseWriter, _ *http.Request) {
+				s.Send404(w, nil, "missing")
+			}
+		}
+
+		w := httptest.NewRecorder()
+		handler(mock)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if mock.sent != 1 || w.Code != http.StatusNotFound {
+			t.Errorf("expected the mock to send a 404, got %d calls and status %d", mock.sent, w.Code)
+		}
+	})
+}
@@ -1,5 +1,10 @@
 package responder
 
+import (
+	"log/slog"
+	"net/http"
+)
+
 // Response represents an HTTP response with status, body, message, and error.
 // It can be used to encapsulate both successful and error responses.
 type Response interface {
@@ -7,12 +12,44 @@ type Response interface {
 	Status() int
 }
 
+// ResponseBuilder is a Response whose copies carry additional headers, cookies,
+// content type or caching policy. It is implemented by the responses of Success,
+// Error and Multipart.
+type ResponseBuilder interface {
+	Response
+
+	// WithHeader returns a copy of the response carrying the additional header,
+	// e.g. Location or Link. Calling it several times with the same key adds
+	// several values.
+	WithHeader(key, value string) ResponseBuilder
+
+	// WithCookie returns a copy of the response setting the cookie.
+	// Invalid cookies are silently dropped.
+	WithCookie(cookie *http.Cookie) ResponseBuilder
+
+	// WithContentType returns a copy of the response sent with the content type
+	// instead of the one of the responder, e.g. application/problem+json. The body
+	// is formatted with the default encoders of the content type when its media type
+	// differs from the one of the responder.
+	WithContentType(contentType string) ResponseBuilder
+
+	// WithCacheControl returns a copy of the response sent with the caching policy
+	// instead of the one of the responder, whatever its status code.
+	WithCacheControl(c CacheControl) ResponseBuilder
+}
+
 // SuccessResponse represents a successful HTTP response with status, body.
 type SuccessResponse struct {
 	// status represents the HTTP status code of the response.
 	status int
 	// body contains the response payload to be sent to the client.
 	body any
+	// header holds the headers specific to the response.
+	header http.Header
+	// contentType overrides the content type of the responder when not empty.
+	contentType string
+	// cacheControl overrides the caching policy of the responder when not nil.
+	cacheControl *CacheControl
 }
 
 // Status returns the HTTP status code of the successful response.
@@ -20,14 +57,51 @@ func (r SuccessResponse) Status() int {
 	return r.status
 }
 
+// WithHeader returns a copy of the successful response carrying the additional header.
+func (r SuccessResponse) WithHeader(key, value string) ResponseBuilder {
+	r.header = withHeader(r.header, key, value)
+
+	return r
+}
+
+// WithCookie returns a copy of the successful response setting the cookie.
+func (r SuccessResponse) WithCookie(cookie *http.Cookie) ResponseBuilder {
+	r.header = withCookie(r.header, cookie)
+
+	return r
+}
+
+// WithContentType returns a copy of the successful response sent with the content type.
+func (r SuccessResponse) WithContentType(contentType string) ResponseBuilder {
+	r.contentType = contentType
+
+	return r
+}
+
+// WithCacheControl returns a copy of the successful response sent with the caching policy.
+func (r SuccessResponse) WithCacheControl(c CacheControl) ResponseBuilder {
+	r.cacheControl = &c
+
+	return r
+}
+
 // ErrorResponse represents an HTTP response with status, message, and error.
 type ErrorResponse struct {
 	// status represents the HTTP status code of the response.
 	status int
 	// message is a human-readable message associated with an error.
-	message string
+	// It is passed to the ErrorFormatter before being sent to the client.
+	message any
 	// err holds the internal error for logging purposes.
 	err error
+	// header holds the headers specific to the response.
+	header http.Header
+	// contentType overrides the content type of the responder when not empty.
+	contentType string
+	// cacheControl overrides the caching policy of the responder when not nil.
+	cacheControl *CacheControl
+	// level is the level the error is logged at, the error level when nil.
+	level slog.Leveler
 }
 
 // Status returns the HTTP status code of the error response.
@@ -35,14 +109,54 @@ func (r ErrorResponse) Status() int {
 	return r.status
 }
 
+// WithHeader returns a copy of the error response carrying the additional header.
+func (r ErrorResponse) WithHeader(key, value string) ResponseBuilder {
+	r.header = withHeader(r.header, key, value)
+
+	return r
+}
+
+// WithCookie returns a copy of the error response setting the cookie.
+func (r ErrorResponse) WithCookie(cookie *http.Cookie) ResponseBuilder {
+	r.header = withCookie(r.header, cookie)
+
+	return r
+}
+
+// WithContentType returns a copy of the error response sent with the content type.
+func (r ErrorResponse) WithContentType(contentType string) ResponseBuilder {
+	r.contentType = contentType
+
+	return r
+}
+
+// WithCacheControl returns a copy of the error response sent with the caching policy.
+func (r ErrorResponse) WithCacheControl(c CacheControl) ResponseBuilder {
+	r.cacheControl = &c
+
+	return r
+}
+
+// WithLogLevel returns a copy of the error response whose error is logged
+// at the given level, e.g. slog.LevelWarn for expected client errors.
+func (r ErrorResponse) WithLogLevel(level slog.Leveler) ErrorResponse {
+	r.level = level
+
+	return r
+}
+
 // Error returns the internal error associated with the error response.
 func (r ErrorResponse) Error() string {
+	if r.err == nil {
+		return ""
+	}
+
 	return r.err.Error()
 }
 
 // Error creates a new error Response with the given status code, message, and error.
 // The message is intended to be sent to the client, while the error is for internal logging.
-func Error(status int, err error, message string) Response {
+func Error(status int, err error, message string) ResponseBuilder {
 	return ErrorResponse{
 		status:  status,
 		err:     err,
@@ -51,9 +165,50 @@ func Error(status int, err error, message string) Response {
 }
 
 // Success creates a new successful Response with the given status code and body.
-func Success(status int, body any) Response {
+func Success(status int, body any) ResponseBuilder {
 	return SuccessResponse{
 		status: status,
 		body:   body,
 	}
 }
+
+// withHeader returns a copy of the header with the additional value,
+// leaving the original untouched so that responses can be safely reused.
+func withHeader(h http.Header, key, value string) http.Header {
+	c := h.Clone()
+	if c == nil {
+		c = make(http.Header)
+	}
+
+	c.Add(key, value)
+
+	return c
+}
+
+// withCookie returns a copy of the header setting the cookie.
+func withCookie(h http.Header, cookie *http.Cookie) http.Header {
+	if cookie == nil {
+		return h
+	}
+
+	v := cookie.String()
+	if v == "" {
+		return h
+	}
+
+	return withHeader(h, "Set-Cookie", v)
+}
+
+// applyHeader copies the response headers to the writer, replacing the values
+// already set for the same keys, apart from the cookies which are added.
+func applyHeader(rw http.ResponseWriter, h http.Header) {
+	for k, v := range h {
+		if k == "Set-Cookie" {
+			rw.Header()[k] = append(rw.Header()[k], v...)
+
+			continue
+		}
+
+		rw.Header()[k] = v[:len(v):len(v)]
+	}
+}
@@ -0,0 +1,205 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseWithHeader(t *testing.T) {
+	t.Run("sends the headers of success responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		resp := Success(http.StatusCreated, "created").
+			WithHeader("Location", "/users/1").
+			WithHeader("Link", `</users>; rel="collection"`).
+			WithHeader("Link", `</>; rel="home"`)
+
+		TextResponder().Send(w, resp)
+
+		if v := w.Header().Get("Location"); v != "/users/1" {
+			t.Errorf("expected Location %q, got %q", "/users/1", v)
+		}
+
+		if v := w.Header().Values("Link"); len(v) != 2 {
+			t.Errorf("expected 2 Link headers, got %v", v)
+		}
+	})
+
+	t.Run("sends the headers of error responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Error(http.StatusUnauthorized, errors.New("no token"), "unauthorized").
+			WithHeader("WWW-Authenticate", `Bearer realm="api"`))
+
+		if v := w.Header().Get("WWW-Authenticate"); v != `Bearer realm="api"` {
+			t.Errorf("unexpected WWW-Authenticate %q", v)
+		}
+	})
+
+	t.Run("leaves the original response untouched", func(t *testing.T) {
+		base := Success(http.StatusOK, "ok").WithHeader("X-A", "a")
+		_ = base.WithHeader("X-B", "b")
+
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, base)
+
+		if v := w.Header().Get("X-B"); v != "" {
+			t.Errorf("expected X-B not to leak into the original response, got %q", v)
+		}
+	})
+}
+
+func TestResponseWithCookie(t *testing.T) {
+	t.Run("adds the cookies to the ones set on the writer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Add("Set-Cookie", "session=abc")
+
+		resp := Success(http.StatusOK, "ok").
+			WithCookie(&http.Cookie{Name: "theme", Value: "dark", Path: "/"}).
+			WithCookie(&http.Cookie{Name: "lang", Value: "fr"})
+
+		TextResponder().Send(w, resp)
+
+		want := []string{"session=abc", "theme=dark; Path=/", "lang=fr"}
+		if got := w.Header().Values("Set-Cookie"); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("expected Set-Cookie %q, got %q", want, got)
+		}
+	})
+
+	t.Run("drops invalid cookies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Error(http.StatusBadRequest, nil, "bad").
+			WithCookie(&http.Cookie{Name: "in valid", Value: "x"}).
+			WithCookie(nil))
+
+		if got := w.Header().Values("Set-Cookie"); len(got) != 0 {
+			t.Errorf("expected no Set-Cookie, got %q", got)
+		}
+	})
+}
+
+func TestResponseWithContentType(t *testing.T) {
+	t.Run("overrides the content type of the responder", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		responder := JSONResponder()
+
+		responder.Send(w, Error(http.StatusNotFound, nil, "missing").WithContentType("application/problem+json"))
+
+		if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("expected Content-Type %q, got %q", "application/problem+json", ct)
+		}
+
+		w = httptest.NewRecorder()
+		responder.Send200(w, "data")
+
+		if ct := w.Header().Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("expected the responder to be left untouched, got %q", ct)
+		}
+	})
+
+	t.Run("applies to success responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Success(http.StatusOK, "# Title").WithContentType("text/markdown"))
+
+		if ct := w.Header().Get("Content-Type"); ct != "text/markdown" {
+			t.Errorf("expected Content-Type %q, got %q", "text/markdown", ct)
+		}
+	})
+
+	t.Run("formats the body in the content type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send(w, Success(http.StatusOK, selfTestData{Status: "ok"}).WithContentType(XMLContentType))
+
+		if b := w.Body.String(); b != "<selftest><status>ok</status></selftest>" {
+			t.Errorf("expected the body to be formatted in XML, got %q", b)
+		}
+	})
+
+	t.Run("keeps the encoder of the responder for its media type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithDataFormatter(func(any) []byte { return []byte("custom") })).
+			Send(w, Success(http.StatusOK, "data").WithContentType("application/json; charset=utf-8"))
+
+		if b := w.Body.String(); b != "custom" {
+			t.Errorf("expected the custom formatter to be used, got %q", b)
+		}
+	})
+}
+
+func TestWithDefaultHeaders(t *testing.T) {
+	defaults := http.Header{}
+	defaults.Set("X-Frame-Options", "DENY")
+	defaults.Set("API-Version", "2")
+
+	t.Run("sends the default headers with every response", func(t *testing.T) {
+		responder := JSONResponder(WithDefaultHeaders(defaults), WithHeader("x-powered-by", "responder"))
+
+		for _, send := range []func(http.ResponseWriter){
+			func(w http.ResponseWriter) { responder.Send200(w, "ok") },
+			func(w http.ResponseWriter) { responder.Send500(w, errors.New("boom"), "error") },
+			func(w http.ResponseWriter) {
+				responder.Redirect302(w, httptest.NewRequest(http.MethodGet, "/", nil), "/elsewhere")
+			},
+		} {
+			w := httptest.NewRecorder()
+			send(w)
+
+			if v := w.Header().Get("X-Frame-Options"); v != "DENY" {
+				t.Errorf("expected X-Frame-Options %q, got %q", "DENY", v)
+			}
+
+			if v := w.Header().Get("X-Powered-By"); v != "responder" {
+				t.Errorf("expected X-Powered-By %q, got %q", "responder", v)
+			}
+		}
+	})
+
+	t.Run("does not override response headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithDefaultHeaders(defaults)).Send(w, Success(http.StatusOK, "ok").WithHeader("API-Version", "3"))
+
+		if v := w.Header().Values("Api-Version"); len(v) != 1 || v[0] != "3" {
+			t.Errorf("expected API-Version %q, got %v", "3", v)
+		}
+	})
+
+	t.Run("copies the given headers", func(t *testing.T) {
+		h := http.Header{"X-A": {"a"}}
+		responder := TextResponder(WithDefaultHeaders(h))
+		h.Set("X-A", "changed")
+
+		w := httptest.NewRecorder()
+		responder.Send200(w, "ok")
+
+		if v := w.Header().Get("X-A"); v != "a" {
+			t.Errorf("expected X-A %q, got %q", "a", v)
+		}
+	})
+}
+
+type statusResponse int
+
+func (r statusResponse) Status() int { return int(r) }
+
+func TestResponseBuilder(t *testing.T) {
+	// The Response interface is kept implementable by the types of other packages.
+	var _ Response = statusResponse(http.StatusOK)
+
+	for _, resp := range []Response{
+		Success(http.StatusOK, "ok"),
+		Error(http.StatusNotFound, nil, "not found"),
+		Multipart(http.StatusOK),
+	} {
+		if _, ok := resp.(ResponseBuilder); !ok {
+			t.Errorf("expected %T to implement ResponseBuilder", resp)
+		}
+	}
+}
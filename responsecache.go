@@ -0,0 +1,406 @@
+package responder
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheEntries is the default maximum number of responses held by a ResponseCache.
+const defaultCacheEntries = 1024
+
+// ResponseCache is an in-memory cache of the responses to GET requests,
+// meant for expensive endpoints such as aggregates. Fresh entries are served
+// from memory. Stale entries are served immediately while they are refreshed
+// in the background, a single refresh running at a time per entry.
+//
+// The responses are cached by URL, i.e. by scheme, host and request URI, and by
+// the request headers listed in their Vary header. Only the 200 OK responses
+// without Set-Cookie header and without a no-store, no-cache or private
+// Cache-Control directive are stored. The requests carrying credentials,
+// i.e. an Authorization or a Cookie header, bypass the cache unless
+// the responses are explicitly public.
+//
+// The cache holds a bounded number of responses, the least recently used ones
+// being evicted first, along with the expired ones, see WithMaxEntries.
+type ResponseCache struct {
+	fresh      time.Duration
+	stale      time.Duration
+	now        func() time.Time
+	maxEntries int
+
+	mu         sync.Mutex
+	urls       map[string]*cacheURL
+	lru        *list.List
+	refreshing map[*cacheEntry]bool
+}
+
+// cacheURL holds the responses stored for a URL, one per variant of the request
+// headers listed in the Vary header of the last response stored.
+type cacheURL struct {
+	vary     []string
+	variants map[string]*list.Element
+}
+
+// cacheEntry is a response stored in the cache.
+type cacheEntry struct {
+	url      string
+	variant  string
+	header   http.Header
+	body     []byte
+	public   bool
+	storedAt time.Time
+}
+
+// NewResponseCache creates a cache serving the responses as fresh during
+// the first duration, then as stale while revalidating during the second.
+// It holds up to 1024 responses by default.
+func NewResponseCache(fresh, staleWhileRevalidate time.Duration) *ResponseCache {
+	return &ResponseCache{
+		fresh:      fresh,
+		stale:      staleWhileRevalidate,
+		now:        time.Now,
+		maxEntries: defaultCacheEntries,
+		urls:       make(map[string]*cacheURL),
+		lru:        list.New(),
+		refreshing: make(map[*cacheEntry]bool),
+	}
+}
+
+// WithMaxEntries sets the maximum number of responses held by the cache and returns it.
+// It must be called before the cache is used.
+func (c *ResponseCache) WithMaxEntries(n int) *ResponseCache {
+	c.maxEntries = max(n, 1)
+
+	return c
+}
+
+// Middleware returns a middleware serving the responses of the handler from the cache.
+// The responses served from the cache carry an Age header, and a
+// Warning: 110 header when they are stale.
+func (c *ResponseCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		c.mu.Lock()
+		e := c.lookup(req)
+		c.mu.Unlock()
+
+		if e == nil || (hasCredentials(req) && !e.public) {
+			c.fill(w, req, next)
+
+			return
+		}
+
+		age := c.now().Sub(e.storedAt)
+
+		if age >= c.fresh {
+			c.revalidate(req, e, next)
+		}
+
+		e.serve(w, age, age >= c.fresh)
+	})
+}
+
+// lookup returns the entry of the request that is not expired, if any.
+// It must be called with the lock held.
+func (c *ResponseCache) lookup(req *http.Request) *cacheEntry {
+	u := c.urls[requestURL(req)]
+	if u == nil {
+		return nil
+	}
+
+	el := u.variants[variantKey(req, u.vary)]
+	if el == nil {
+		return nil
+	}
+
+	e, _ := el.Value.(*cacheEntry)
+	if c.expired(e) {
+		c.remove(el)
+
+		return nil
+	}
+
+	c.lru.MoveToFront(el)
+
+	return e
+}
+
+// fill serves the request with the handler, storing the response in the cache.
+func (c *ResponseCache) fill(w http.ResponseWriter, req *http.Request, next http.Handler) {
+	rec := newCacheRecorder()
+	next.ServeHTTP(rec, req)
+
+	c.store(req, rec)
+	rec.replay(w)
+}
+
+// revalidate refreshes the entry in the background unless it is already being refreshed.
+// The request is detached from the cancellation of the client request.
+func (c *ResponseCache) revalidate(req *http.Request, e *cacheEntry, next http.Handler) {
+	c.mu.Lock()
+	if c.refreshing[e] {
+		c.mu.Unlock()
+
+		return
+	}
+
+	c.refreshing[e] = true
+	c.mu.Unlock()
+
+	bg := req.Clone(context.WithoutCancel(req.Context()))
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, e)
+			c.mu.Unlock()
+		}()
+
+		rec := newCacheRecorder()
+		next.ServeHTTP(rec, bg)
+
+		c.store(bg, rec)
+	}()
+}
+
+// store stores the recorded response when it may be cached.
+func (c *ResponseCache) store(req *http.Request, rec *cacheRecorder) {
+	if rec.code != status200 || !storable(rec.header) {
+		return
+	}
+
+	public := hasDirective(rec.header, "public")
+	if hasCredentials(req) && !public {
+		return
+	}
+
+	vary := varyFields(rec.header)
+	if slices.Contains(vary, "*") {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	url := requestURL(req)
+
+	u := c.urls[url]
+	if u == nil {
+		u = &cacheURL{variants: make(map[string]*list.Element)}
+		c.urls[url] = u
+	}
+
+	if !slices.Equal(u.vary, vary) {
+		// The variants stored with the previous Vary header are keyed differently.
+		for _, el := range u.variants {
+			c.remove(el)
+		}
+
+		u = &cacheURL{vary: vary, variants: make(map[string]*list.Element)}
+		c.urls[url] = u
+	}
+
+	e := &cacheEntry{
+		url:      url,
+		variant:  variantKey(req, vary),
+		header:   rec.header,
+		body:     rec.body.Bytes(),
+		public:   public,
+		storedAt: c.now(),
+	}
+
+	if el := u.variants[e.variant]; el != nil {
+		el.Value = e
+		c.lru.MoveToFront(el)
+
+		return
+	}
+
+	u.variants[e.variant] = c.lru.PushFront(e)
+
+	if c.lru.Len() > c.maxEntries {
+		c.purge()
+	}
+
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// purge removes the expired entries. It must be called with the lock held.
+func (c *ResponseCache) purge() {
+	for el := c.lru.Back(); el != nil; {
+		prev := el.Prev()
+
+		if e, _ := el.Value.(*cacheEntry); c.expired(e) {
+			c.remove(el)
+		}
+
+		el = prev
+	}
+}
+
+// remove removes the entry. It must be called with the lock held.
+func (c *ResponseCache) remove(el *list.Element) {
+	e, _ := c.lru.Remove(el).(*cacheEntry)
+
+	u := c.urls[e.url]
+	if u == nil || u.variants[e.variant] != el {
+		return
+	}
+
+	delete(u.variants, e.variant)
+
+	if len(u.variants) == 0 {
+		delete(c.urls, e.url)
+	}
+}
+
+// expired reports whether the entry can no longer be served, even stale.
+func (c *ResponseCache) expired(e *cacheEntry) bool {
+	return c.now().Sub(e.storedAt) >= c.fresh+c.stale
+}
+
+// requestURL returns the absolute URL of the request, whose URL
+// only holds the path and the query on the server side.
+func requestURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+
+	uri := req.RequestURI
+	if uri == "" {
+		uri = req.URL.RequestURI()
+	}
+
+	return scheme + "://" + strings.ToLower(req.Host) + uri
+}
+
+// storable reports whether a shared cache may store the response.
+func storable(h http.Header) bool {
+	if _, ok := h["Set-Cookie"]; ok {
+		return false
+	}
+
+	return !hasDirective(h, "no-store") && !hasDirective(h, "private") && !hasDirective(h, "no-cache")
+}
+
+// hasDirective reports whether the Cache-Control header holds the directive.
+func hasDirective(h http.Header, directive string) bool {
+	for _, v := range h.Values("Cache-Control") {
+		for d := range strings.SplitSeq(v, ",") {
+			d, _, _ = strings.Cut(d, "=")
+			if strings.EqualFold(strings.TrimSpace(d), directive) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasCredentials reports whether the request carries credentials.
+func hasCredentials(req *http.Request) bool {
+	return req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != ""
+}
+
+// varyFields returns the canonical request headers listed in the Vary header, sorted.
+func varyFields(h http.Header) []string {
+	var fields []string
+
+	for _, v := range h.Values("Vary") {
+		for f := range strings.SplitSeq(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				fields = append(fields, http.CanonicalHeaderKey(f))
+			}
+		}
+	}
+
+	slices.Sort(fields)
+
+	return slices.Compact(fields)
+}
+
+// variantKey returns the key of the request among the variants of its URL.
+func variantKey(req *http.Request, vary []string) string {
+	var b strings.Builder
+
+	for _, f := range vary {
+		b.WriteString(f)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(req.Header.Values(f), ","))
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// serve writes the stored response.
+func (e *cacheEntry) serve(w http.ResponseWriter, age time.Duration, stale bool) {
+	for k, v := range e.header {
+		w.Header()[k] = v[:len(v):len(v)]
+	}
+
+	w.Header().Set("Age", strconv.FormatInt(int64(age/time.Second), 10))
+
+	if stale {
+		w.Header().Add("Warning", `110 - "Response is Stale"`)
+	}
+
+	w.WriteHeader(status200)
+	_, _ = w.Write(e.body)
+}
+
+// cacheRecorder records the response of the handler.
+type cacheRecorder struct {
+	header      http.Header
+	body        bytes.Buffer
+	code        int
+	wroteHeader bool
+}
+
+func newCacheRecorder() *cacheRecorder {
+	return &cacheRecorder{header: make(http.Header), code: status200}
+}
+
+func (r *cacheRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *cacheRecorder) WriteHeader(code int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.code = code
+	r.wroteHeader = true
+}
+
+func (r *cacheRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+
+	return r.body.Write(b)
+}
+
+// replay writes the recorded response to the writer.
+func (r *cacheRecorder) replay(w http.ResponseWriter) {
+	for k, v := range r.header {
+		w.Header()[k] = v[:len(v):len(v)]
+	}
+
+	w.WriteHeader(r.code)
+	_, _ = w.Write(r.body.Bytes())
+}
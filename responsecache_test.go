@@ -0,0 +1,311 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	newCache := func() (*ResponseCache, *time.Time) {
+		now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+		c := NewResponseCache(time.Minute, time.Hour)
+		c.now = func() time.Time { return now }
+
+		return c, &now
+	}
+
+	newHandler := func(calls *atomic.Int32, done chan struct{}) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			n := calls.Add(1)
+			TextResponder().Send200(w, "version "+strconv.Itoa(int(n)))
+
+			if done != nil && n > 1 {
+				done <- struct{}{}
+			}
+		})
+	}
+
+	get := func(h http.Handler) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+		return w
+	}
+
+	t.Run("serves fresh entries from memory", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, now := newCache()
+		h := c.Middleware(newHandler(&calls, nil))
+
+		get(h)
+
+		*now = now.Add(30 * time.Second)
+		w := get(h)
+
+		if calls.Load() != 1 {
+			t.Errorf("expected the handler to be called once, got %d", calls.Load())
+		}
+
+		if w.Body.String() != "version 1" || w.Header().Get("Age") != "30" {
+			t.Errorf("expected the cached response with Age 30, got %q with Age %q", w.Body.String(), w.Header().Get("Age"))
+		}
+
+		if w.Header().Get("Warning") != "" {
+			t.Errorf("expected no Warning, got %q", w.Header().Get("Warning"))
+		}
+	})
+
+	t.Run("serves stale entries while revalidating", func(t *testing.T) {
+		var calls atomic.Int32
+
+		done := make(chan struct{}, 1)
+		c, now := newCache()
+		h := c.Middleware(newHandler(&calls, done))
+
+		get(h)
+
+		*now = now.Add(2 * time.Minute)
+		w := get(h)
+
+		if w.Body.String() != "version 1" {
+			t.Errorf("expected the stale response, got %q", w.Body.String())
+		}
+
+		if want := `110 - "Response is Stale"`; w.Header().Get("Warning") != want {
+			t.Errorf("expected Warning %q, got %q", want, w.Header().Get("Warning"))
+		}
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected the entry to be refreshed in the background")
+		}
+
+		// Wait for the refresh to be stored.
+		for range 100 {
+			c.mu.Lock()
+			refreshing := len(c.refreshing)
+			c.mu.Unlock()
+
+			if refreshing == 0 {
+				break
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+
+		if w := get(h); w.Body.String() != "version 2" {
+			t.Errorf("expected the refreshed response, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("refetches expired entries", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, now := newCache()
+		h := c.Middleware(newHandler(&calls, nil))
+
+		get(h)
+
+		*now = now.Add(2 * time.Hour)
+
+		if w := get(h); w.Body.String() != "version 2" || w.Header().Get("Age") != "" {
+			t.Errorf("expected a new response, got %q with Age %q", w.Body.String(), w.Header().Get("Age"))
+		}
+	})
+
+	t.Run("does not cache errors and uncacheable responses", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, _ := newCache()
+		h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				TextResponder().Send500(w, nil, "error")
+
+				return
+			}
+
+			w.Header().Set("Cache-Control", "private")
+			TextResponder().Send200(w, "mine")
+		}))
+
+		get(h)
+		get(h)
+		get(h)
+
+		if calls.Load() != 3 {
+			t.Errorf("expected the handler to be called 3 times, got %d", calls.Load())
+		}
+	})
+
+	t.Run("keys the responses by host", func(t *testing.T) {
+		c, _ := newCache()
+		h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			TextResponder().Send200(w, req.Host)
+		}))
+
+		for range 2 {
+			for _, host := range []string{"a.example.com", "b.example.com"} {
+				req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+				req.Host = host
+
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, req)
+
+				if w.Body.String() != host {
+					t.Errorf("expected the response of %s, got %q", host, w.Body.String())
+				}
+			}
+		}
+	})
+
+	t.Run("does not cache the responses setting cookies", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, _ := newCache()
+		h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+			TextResponder().Send200(w, "mine")
+		}))
+
+		get(h)
+
+		if w := get(h); w.Header().Get("Set-Cookie") == "" || calls.Load() != 2 {
+			t.Errorf("expected the handler to be called twice, got %d", calls.Load())
+		}
+	})
+
+	t.Run("bypasses the requests with credentials", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, _ := newCache()
+		h := c.Middleware(newHandler(&calls, nil))
+
+		for _, header := range []string{"Authorization", "Cookie", ""} {
+			req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+			if header != "" {
+				req.Header.Set(header, "secret")
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Header().Get("Age") != "" {
+				t.Errorf("expected the %q request not to be served from the cache", header)
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+		req.Header.Set("Authorization", "secret")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Header().Get("Age") != "" || calls.Load() != 4 {
+			t.Errorf("expected the private response not to be served to the credentials, got %d calls", calls.Load())
+		}
+	})
+
+	t.Run("serves the public responses to the requests with credentials", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, _ := newCache()
+		h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Cache-Control", "public, max-age=60")
+			TextResponder().Send200(w, "shared")
+		}))
+
+		for range 2 {
+			req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+			req.Header.Set("Authorization", "secret")
+			h.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		if calls.Load() != 1 {
+			t.Errorf("expected the handler to be called once, got %d", calls.Load())
+		}
+	})
+
+	t.Run("keys the responses by the Vary headers", func(t *testing.T) {
+		c, _ := newCache()
+		h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Vary", "Accept-Language")
+			TextResponder().Send200(w, req.Header.Get("Accept-Language"))
+		}))
+
+		for _, lang := range []string{"en", "fr", "en", "fr"} {
+			req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+			req.Header.Set("Accept-Language", lang)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Body.String() != lang {
+				t.Errorf("expected the %q variant, got %q", lang, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("evicts the least recently used entries", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, _ := newCache()
+		c.WithMaxEntries(2)
+		h := c.Middleware(newHandler(&calls, nil))
+
+		for _, path := range []string{"/a", "/b", "/a", "/c", "/a", "/b"} {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}
+
+		// /b is evicted by /c, /a being used more recently.
+		if calls.Load() != 4 {
+			t.Errorf("expected the handler to be called 4 times, got %d", calls.Load())
+		}
+
+		if c.lru.Len() != 2 || len(c.urls) != 2 {
+			t.Errorf("expected 2 entries, got %d", c.lru.Len())
+		}
+	})
+
+	t.Run("purges the expired entries", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, now := newCache()
+		c.WithMaxEntries(3)
+		h := c.Middleware(newHandler(&calls, nil))
+
+		for _, step := range []struct {
+			path  string
+			after time.Duration
+		}{{"/a", 0}, {"/b", time.Minute}, {"/c", 30 * time.Minute}, {"/d", 45 * time.Minute}} {
+			*now = now.Add(step.after)
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, step.path, nil))
+		}
+
+		if c.lru.Len() != 2 || len(c.urls) != 2 {
+			t.Errorf("expected the expired entries to be purged, got %d entries", c.lru.Len())
+		}
+	})
+
+	t.Run("ignores other methods", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c, _ := newCache()
+		h := c.Middleware(newHandler(&calls, nil))
+
+		for range 2 {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/stats", nil))
+		}
+
+		if calls.Load() != 2 {
+			t.Errorf("expected the handler to be called twice, got %d", calls.Load())
+		}
+	})
+}
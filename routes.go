@@ -0,0 +1,67 @@
+package responder
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Routes maps URL path prefixes to responders, e.g. /api/* to a JSONResponder,
+// /admin/* to an HTMLResponder and /export/* to a CSVResponder, so that
+// applications serving mixed content do not pass several responders around.
+// Routes must be fully registered before serving requests.
+type Routes struct {
+	fallback Responder
+	routes   []route
+}
+
+type route struct {
+	prefix    string
+	responder Responder
+}
+
+// NewRoutes creates a new Routes returning the fallback responder
+// for the paths that do not match any prefix.
+func NewRoutes(fallback Responder) *Routes {
+	return &Routes{fallback: fallback}
+}
+
+// Handle maps the paths starting with the prefix to the responder.
+// A trailing wildcard is accepted, "/api/*" being equivalent to "/api/".
+// The longest matching prefix wins.
+func (rt *Routes) Handle(prefix string, r Responder) *Routes {
+	rt.routes = append(rt.routes, route{
+		prefix:    strings.TrimSuffix(prefix, "*"),
+		responder: r,
+	})
+
+	sort.SliceStable(rt.routes, func(i, j int) bool {
+		return len(rt.routes[i].prefix) > len(rt.routes[j].prefix)
+	})
+
+	return rt
+}
+
+// For returns the responder mapped to the path of the request.
+func (rt *Routes) For(r *http.Request) Responder {
+	for _, route := range rt.routes {
+		if strings.HasPrefix(r.URL.Path, route.prefix) {
+			return route.responder
+		}
+	}
+
+	return rt.fallback
+}
+
+// Middleware stores the responder mapped to the request in the request context,
+// bound to the request, where handlers retrieve it with FromContext.
+func (rt *Routes) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := rt.For(r)
+		if resp != nil {
+			r = r.WithContext(NewContext(r.Context(), resp.WithRequest(r)))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
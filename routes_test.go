@@ -0,0 +1,61 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoutes(t *testing.T) {
+	routes := NewRoutes(TextResponder()).
+		Handle("/api/*", JSONResponder()).
+		Handle("/api/export/", CSVResponder()).
+		Handle("/admin/", HTMLResponder())
+
+	handler := routes.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := FromContext(r.Context())
+		if !ok {
+			t.Fatal("expected a responder in the context")
+		}
+
+		resp.Send404(w, errors.New("not found"), "not found")
+	}))
+
+	testCases := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/users", want: JSONContentType},
+		{path: "/api/export/users.csv", want: CSVContentType},
+		{path: "/admin/settings", want: HTMLContentType},
+		{path: "/", want: TextContentType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if ct := w.Header().Get("Content-Type"); ct != tc.want {
+				t.Errorf("expected Content-Type %q, got %q", tc.want, ct)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if _, ok := FromContext(req.Context()); ok {
+		t.Error("expected no responder in an empty context")
+	}
+
+	responder := JSONResponder()
+	ctx := NewContext(req.Context(), responder)
+
+	if r, ok := FromContext(ctx); !ok || r != responder {
+		t.Error("expected the responder stored in the context")
+	}
+}
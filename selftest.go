@@ -0,0 +1,101 @@
+package responder
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// selfTestData is the data of the sample successful response sent by SelfTest,
+// encodable by all the default formatters.
+type selfTestData struct {
+	XMLName xml.Name `json:"-" xml:"selftest"`
+	Status  string   `json:"status" xml:"status" csv:"status"`
+}
+
+// errSelfTest is the error of the sample error response sent by SelfTest.
+var errSelfTest = errors.New("responder: self test")
+
+// SelfTest sends sample responses against an in-memory writer with a responder
+// bound to a sample request, exercising the formatters and the hooks such as
+// the transformations, the encryption and the redirect validation, and writes
+// sample data with each compressor. It is meant to be called at startup so that
+// misconfigurations surface at boot rather than on the first real request.
+// The failures and the panics are aggregated into the returned error.
+// The sample responses have no side effects: nothing is logged, journaled,
+// stored, measured, traced, shadowed nor throttled, no fault is injected
+// and the send hooks are not called.
+func (r *responder) SelfTest() error {
+	if r == nil || r.options == nil {
+		return errNoResponder
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	o := *r.options
+	o.logger = nil
+	o.journal = nil
+	o.errorStore = nil
+	o.metrics = nil
+	o.spanFromContext = nil
+	o.beforeSend = nil
+	o.afterSend = nil
+	o.fault = nil
+	o.shadow = nil
+	o.globalBandwidth = nil
+
+	c := *r
+	c.options = &o
+	c.checked = true
+	c.request = req
+
+	var errs []error
+
+	check := func(name string, f func() error) {
+		defer func() {
+			if v := recover(); v != nil {
+				errs = append(errs, fmt.Errorf("%s: panicked: %v", name, v))
+			}
+		}()
+
+		if err := f(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	check("success response", func() error {
+		return c.sendData(newCacheRecorder(), status200, selfTestData{Status: "ok"})
+	})
+	check("error response", func() error {
+		return c.sendError(newCacheRecorder(), status500, errSelfTest, http.StatusText(status500))
+	})
+	check("redirect", func() error {
+		return c.redirect(newCacheRecorder(), req, "/", status302)
+	})
+
+	for encoding, compressor := range o.compression.compressors {
+		check("compressor "+encoding, func() error {
+			w, err := compressor(io.Discard)
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(w, "self test"); err != nil {
+				return err
+			}
+
+			return w.Close()
+		})
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("responder: self test failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package responder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	t.Run("passes with valid configurations", func(t *testing.T) {
+		for _, r := range []Responder{JSONResponder(), TextResponder(), HTMLResponder(), CSVResponder(), XMLResponder(), Noop()} {
+			if err := r.SelfTest(); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		}
+	})
+
+	t.Run("aggregates the failures", func(t *testing.T) {
+		err := JSONResponder(
+			WithDataFormatter(func(any) []byte { panic("broken formatter") }),
+			WithCompressor("br", func(io.Writer) (io.WriteCloser, error) { return nil, errors.New("missing dictionary") }),
+			WithRedirectValidator(func(string) error { return ErrRedirectNotAllowed }),
+		).SelfTest()
+
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		for _, msg := range []string{"success response", "error response", "broken formatter", "compressor br: missing dictionary", "redirect"} {
+			if !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected the error to contain %q, got %q", msg, err)
+			}
+		}
+
+		if !errors.Is(err, ErrInvalidContent) || !errors.Is(err, ErrRedirectNotAllowed) {
+			t.Errorf("expected the error to wrap the failures, got %v", err)
+		}
+	})
+
+	t.Run("reports the hook failures", func(t *testing.T) {
+		err := JSONResponder(WithBodyEncryption(reverseEncrypter{err: errors.New("no key")})).SelfTest()
+		if err == nil || !strings.Contains(err.Error(), "no key") {
+			t.Errorf("expected the encryption failure, got %v", err)
+		}
+	})
+
+	t.Run("neither logs nor journals", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		journal := &memoryJournal{}
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+		if err := JSONResponder(WithLogger(logger), WithJournal(journal)).SelfTest(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if logs.Len() != 0 || len(journal.entries) != 0 {
+			t.Errorf("expected no side effects, got logs %q and %d entries", logs.String(), len(journal.entries))
+		}
+	})
+	t.Run("has no side effects", func(t *testing.T) {
+		var (
+			hooks  int
+			shadow bytes.Buffer
+		)
+
+		store := &memoryErrorStore{}
+		metrics := &memoryMetrics{}
+		traced := false
+
+		err := JSONResponder(
+			WithErrorStore(store),
+			WithMetrics(metrics),
+			WithTracing(func(context.Context) Span { traced = true; return &memorySpan{} }),
+			WithBeforeSend(func(int, http.Header, []byte) { hooks++ }),
+			WithAfterSend(func(int, int, error) { hooks++ }),
+			WithFaultInjection(FaultPolicy{ErrorRate: 1, TruncateRate: 1}),
+			WithShadow(TextResponder(), &shadow),
+			WithGlobalBandwidthLimit(1),
+		).SelfTest()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(store.records) != 0 || len(metrics.metrics) != 0 || traced || hooks != 0 || shadow.Len() != 0 {
+			t.Errorf("expected no side effects, got %d records, %d metrics, traced %t, %d hook calls and shadow output %q",
+				len(store.records), len(metrics.metrics), traced, hooks, shadow.String())
+		}
+	})
+}
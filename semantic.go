@@ -0,0 +1,194 @@
+package responder
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// SyntaxMessage is the message of the 400 Bad Request responses created by
+// BadSyntax. The JSON responders send it as {"error": message, "offset": offset},
+// the offset being omitted when unknown.
+type SyntaxMessage struct {
+	// Message describes the error to the client.
+	Message string
+	// Offset is the offset in the request body at which the syntax error
+	// was detected, or zero when unknown.
+	Offset int64
+}
+
+// String returns the message followed by the offset, if any.
+func (m SyntaxMessage) String() string {
+	if m.Offset > 0 {
+		return m.Message + " at offset " + strconv.FormatInt(m.Offset, 10)
+	}
+
+	return m.Message
+}
+
+// FieldError describes why the value of a field of the request is invalid.
+type FieldError struct {
+	// Field is the name of the field, e.g. "email" or "address.city".
+	Field string `json:"field" xml:"field"`
+	// Message describes the error to the client.
+	Message string `json:"message" xml:"message"`
+}
+
+// ValidationError is the error returned by validators for an invalid field.
+// The rule that failed, e.g. "required" or "max", is meant for the logs and
+// is not sent to the client. DefaultErrorMapper sends the validation errors
+// wrapped or joined in an error as a 422 Unprocessable Entity, see Unprocessable.
+type ValidationError struct {
+	// Field is the name of the field, e.g. "email" or "address.city".
+	Field string
+	// Rule is the name of the validation rule the value does not satisfy.
+	Rule string
+	// Message describes the error to the client.
+	Message string
+}
+
+// Error returns the field, the rule and the message of the error.
+func (e *ValidationError) Error() string {
+	if e.Rule == "" {
+		return e.Field + ": " + e.Message
+	}
+
+	return e.Field + ": " + e.Message + " (" + e.Rule + ")"
+}
+
+// FieldError returns the error sent to the client.
+func (e *ValidationError) FieldError() FieldError {
+	return FieldError{Field: e.Field, Message: e.Message}
+}
+
+// fieldErrors returns the field errors of the validation errors found
+// in the tree of the error, in depth-first order.
+func fieldErrors(err error) []FieldError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *ValidationError:
+		return []FieldError{e.FieldError()}
+	case interface{ Unwrap() []error }:
+		var fields []FieldError
+		for _, err := range e.Unwrap() {
+			fields = append(fields, fieldErrors(err)...)
+		}
+
+		return fields
+	default:
+		return fieldErrors(errors.Unwrap(err))
+	}
+}
+
+// ValidationMessage is the message of the 422 Unprocessable Entity responses
+// created by Unprocessable. The field errors are sent as a list, i.e.
+// {"error": message, "errors": [{"field": field, "message": message}]} by the JSON responders,
+// <errors><error><field>field</field><message>message</message></error></errors>
+// by the XML ones and <ul class="errors"><li data-field="field">message</li></ul>
+// by the HTML ones.
+type ValidationMessage struct {
+	// Message describes the error to the client.
+	Message string
+	// Fields holds the errors of the invalid fields.
+	Fields []FieldError
+}
+
+// String returns the message followed by the field errors.
+func (m ValidationMessage) String() string {
+	if len(m.Fields) == 0 {
+		return m.Message
+	}
+
+	fields := make([]string, len(m.Fields))
+	for i, f := range m.Fields {
+		fields[i] = f.Field + ": " + f.Message
+	}
+
+	return m.Message + ": " + strings.Join(fields, ", ")
+}
+
+// BadSyntax creates a 400 Bad Request Response for the requests that cannot
+// be parsed, e.g. malformed JSON bodies, as opposed to the well-formed
+// requests failing validation, see Unprocessable. The offset of the
+// *json.SyntaxError wrapped by the error, if any, is reported to the client.
+func BadSyntax(err error) Response {
+	m := SyntaxMessage{Message: "malformed request"}
+
+	var se *json.SyntaxError
+	if errors.As(err, &se) {
+		m.Offset = se.Offset
+	}
+
+	return ErrorResponse{status: status400, err: err, message: m}
+}
+
+// Unprocessable creates a 422 Unprocessable Entity Response for the well-formed
+// requests whose fields fail validation, reporting the errors of the fields.
+func Unprocessable(fieldErrs ...FieldError) Response {
+	return unprocessable(nil, fieldErrs)
+}
+
+// unprocessable creates the 422 Unprocessable Entity Response of the field errors.
+func unprocessable(err error, fieldErrs []FieldError) ErrorResponse {
+	return ErrorResponse{
+		status:  status422,
+		err:     err,
+		message: ValidationMessage{Message: "validation failed", Fields: fieldErrs},
+	}
+}
+
+func (r *responder) SendValidation(rw responseWriter, fieldErrs []FieldError) {
+	_ = r.sendResponse(rw, Unprocessable(fieldErrs...))
+}
+
+// validationMessage returns the validation message, unwrapping the debug messages.
+func validationMessage(message any) (ValidationMessage, bool) {
+	if m, ok := message.(DebugMessage); ok {
+		message = m.Message
+	}
+
+	m, ok := message.(ValidationMessage)
+
+	return m, ok
+}
+
+// xmlErrors is the XML body of the validation messages.
+type xmlErrors struct {
+	XMLName xml.Name     `xml:"errors"`
+	Errors  []FieldError `xml:"error"`
+}
+
+// xmlErrorFormatter is the default error formatter of the XML responders,
+// sending the validation messages as a list of errors.
+func xmlErrorFormatter(message any) any {
+	if m, ok := validationMessage(message); ok {
+		return xmlErrors{Errors: m.Fields}
+	}
+
+	return stringFormatter(message)
+}
+
+// htmlErrorFormatter is the default error formatter of the HTML responders,
+// sending the validation messages as a list of errors.
+func htmlErrorFormatter(message any) any {
+	m, ok := validationMessage(message)
+	if !ok {
+		return stringFormatter(message)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(`<ul class="errors">`)
+
+	for _, f := range m.Fields {
+		b.WriteString(`<li data-field="` + html.EscapeString(f.Field) + `">` + html.EscapeString(f.Message) + `</li>`)
+	}
+
+	b.WriteString(`</ul>`)
+
+	return b.String()
+}
@@ -0,0 +1,194 @@
+package responder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBadSyntax(t *testing.T) {
+	t.Run("reports the offset of JSON syntax errors", func(t *testing.T) {
+		var v map[string]any
+
+		err := json.NewDecoder(strings.NewReader(`{"name": "Ann",}`)).Decode(&v)
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send(w, BadSyntax(err))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+
+		if expected := `{"error":"malformed request","offset":16}`; w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("omits unknown offsets", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send(w, BadSyntax(errors.New("unexpected EOF")))
+
+		if expected := `{"error":"malformed request"}`; w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("sends text messages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, BadSyntax(&json.SyntaxError{Offset: 3}))
+
+		if w.Body.String() != "malformed request at offset 3" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
+
+func TestUnprocessable(t *testing.T) {
+	fields := []FieldError{
+		{Field: "email", Message: "must be an email address"},
+		{Field: "age", Message: "must be positive"},
+	}
+
+	t.Run("reports the field errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().Send(w, Unprocessable(fields...))
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+		}
+
+		expected := `{"error":"validation failed","errors":[` +
+			`{"field":"email","message":"must be an email address"},` +
+			`{"field":"age","message":"must be positive"}]}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("sends text messages", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Unprocessable(fields...))
+
+		if expected := "validation failed: email: must be an email address, age: must be positive"; w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("keeps the shape in verbose mode", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-Debug", "secret")
+
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithDebugToken("X-Debug", func(v string) bool { return v == "secret" })).
+			WithRequest(req).
+			Send(w, Unprocessable(fields[0]))
+
+		if !strings.HasPrefix(w.Body.String(), `{"error":"validation failed","errors":[{"field":"email"`) {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
+
+func TestSendValidation(t *testing.T) {
+	fields := []FieldError{
+		{Field: "email", Message: "must be an <email> address"},
+		{Field: "age", Message: "must be positive"},
+	}
+
+	t.Run("sends the same list of errors across content types", func(t *testing.T) {
+		tests := []struct {
+			responder Responder
+			expected  string
+		}{
+			{
+				JSONResponder(),
+				`{"error":"validation failed","errors":[` +
+					`{"field":"email","message":"must be an \u003cemail\u003e address"},` +
+					`{"field":"age","message":"must be positive"}]}`,
+			},
+			{
+				XMLResponder(),
+				`<errors><error><field>email</field><message>must be an &lt;email&gt; address</message></error>` +
+					`<error><field>age</field><message>must be positive</message></error></errors>`,
+			},
+			{
+				HTMLResponder(),
+				`<ul class="errors"><li data-field="email">must be an &lt;email&gt; address</li>` +
+					`<li data-field="age">must be positive</li></ul>`,
+			},
+		}
+
+		for _, tt := range tests {
+			w := httptest.NewRecorder()
+
+			tt.responder.SendValidation(w, fields)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+			}
+
+			if w.Body.String() != tt.expected {
+				t.Errorf("expected body %q, got %q", tt.expected, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("leaves the other error messages untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HTMLResponder().Send404(w, nil, "not found")
+
+		if w.Body.String() != "not found" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("reports the failures of checked responders", func(t *testing.T) {
+		err := Checked(JSONResponder()).SendValidation(nil, fields)
+		if !errors.Is(err, ErrNilWriter) {
+			t.Errorf("expected ErrNilWriter, got %v", err)
+		}
+	})
+}
+
+func TestValidationError(t *testing.T) {
+	t.Run("describes the field, the message and the rule", func(t *testing.T) {
+		err := &ValidationError{Field: "email", Rule: "required", Message: "is required"}
+
+		if err.Error() != "email: is required (required)" {
+			t.Errorf("unexpected error %q", err.Error())
+		}
+	})
+
+	t.Run("is mapped to a 422 reporting the joined fields", func(t *testing.T) {
+		err := fmt.Errorf("invalid user: %w", errors.Join(
+			&ValidationError{Field: "email", Rule: "required", Message: "is required"},
+			errors.New("unrelated"),
+			&ValidationError{Field: "age", Rule: "min", Message: "must be positive"},
+		))
+
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendError(w, err)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+		}
+
+		expected := `{"error":"validation failed","errors":[` +
+			`{"field":"email","message":"is required"},` +
+			`{"field":"age","message":"must be positive"}]}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+}
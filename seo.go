@@ -0,0 +1,63 @@
+package responder
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithRobotsTag sets the X-Robots-Tag header of the HTML responses,
+// e.g. WithRobotsTag("noindex", "nofollow").
+func WithRobotsTag(directives ...string) OptionsModifier {
+	return func(o *options) {
+		o.seo.robots = strings.Join(directives, ", ")
+	}
+}
+
+// WithContentLanguage sets the Content-Language header of the HTML responses,
+// e.g. WithContentLanguage("en-GB").
+func WithContentLanguage(languages ...string) OptionsModifier {
+	return func(o *options) {
+		o.seo.language = strings.Join(languages, ", ")
+	}
+}
+
+// WithCanonicalLink adds a canonical Link header to the HTML responses.
+// The function receives the request the responder is bound to and returns
+// the canonical URL of the page, or an empty string when there is none.
+// It only applies to responders bound to a request with WithRequest.
+func WithCanonicalLink(f func(*http.Request) string) OptionsModifier {
+	return func(o *options) {
+		o.seo.canonical = f
+	}
+}
+
+// seoOptions holds the headers managing how search engines index the pages.
+type seoOptions struct {
+	robots    string
+	language  string
+	canonical func(*http.Request) string
+}
+
+// setSEOHeaders sets the SEO headers of HTML responses,
+// unless they were already set on the writer.
+func (r *responder) setSEOHeaders(h http.Header, contentType string) {
+	if mediaType(contentType) != "text/html" {
+		return
+	}
+
+	seo := &r.options.seo
+
+	if seo.robots != "" && h.Get("X-Robots-Tag") == "" {
+		h.Set("X-Robots-Tag", seo.robots)
+	}
+
+	if seo.language != "" && h.Get("Content-Language") == "" {
+		h.Set("Content-Language", seo.language)
+	}
+
+	if seo.canonical != nil && r.request != nil {
+		if u := seo.canonical(r.request); u != "" {
+			h.Add("Link", "<"+u+`>; rel="canonical"`)
+		}
+	}
+}
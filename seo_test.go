@@ -0,0 +1,72 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSEOHeaders(t *testing.T) {
+	canonical := func(r *http.Request) string {
+		return "https://example.com" + r.URL.Path
+	}
+
+	options := []OptionsModifier{
+		WithRobotsTag("noindex", "nofollow"),
+		WithContentLanguage("en-GB", "fr"),
+		WithCanonicalLink(canonical),
+	}
+
+	t.Run("sets the headers on HTML responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/about?ref=home", nil)
+
+		HTMLResponder(options...).WithRequest(req).Send200(w, "<p>about</p>")
+
+		want := map[string]string{
+			"X-Robots-Tag":     "noindex, nofollow",
+			"Content-Language": "en-GB, fr",
+			"Link":             `<https://example.com/about>; rel="canonical"`,
+		}
+
+		for k, v := range want {
+			if got := w.Header().Get(k); got != v {
+				t.Errorf("expected %s %q, got %q", k, v, got)
+			}
+		}
+	})
+
+	t.Run("does not override the headers set on the writer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		w.Header().Set("X-Robots-Tag", "all")
+
+		HTMLResponder(options...).Send200(w, "<p>about</p>")
+
+		if got := w.Header().Get("X-Robots-Tag"); got != "all" {
+			t.Errorf("expected X-Robots-Tag %q, got %q", "all", got)
+		}
+	})
+
+	t.Run("requires a request for the canonical link", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		HTMLResponder(options...).Send200(w, "<p>about</p>")
+
+		if got := w.Header().Get("Link"); got != "" {
+			t.Errorf("expected no Link header, got %q", got)
+		}
+	})
+
+	t.Run("ignores non-HTML responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/about", nil)
+
+		JSONResponder(options...).WithRequest(req).Send200(w, "data")
+
+		for _, k := range []string{"X-Robots-Tag", "Content-Language", "Link"} {
+			if got := w.Header().Get(k); got != "" {
+				t.Errorf("expected no %s header, got %q", k, got)
+			}
+		}
+	})
+}
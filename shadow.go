@@ -0,0 +1,131 @@
+package responder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+)
+
+// WithShadow also renders the responses with the secondary responder,
+// e.g. configured with a new envelope or codec, and writes the differences
+// with the responses actually sent to the sink, enabling the validation of
+// format changes against production traffic before rolling them out.
+// The status codes, content types and bodies are compared, the shadow
+// responses never reaching the client. The streamed bodies and the redirects
+// are not shadowed. The secondary responder should not log nor journal the
+// responses since they are sent twice. See WithShadowRate to sample the responses.
+func WithShadow(secondary Responder, sink io.Writer) OptionsModifier {
+	return func(o *options) {
+		o.shadow = &shadow{secondary: secondary, sink: sink}
+	}
+}
+
+// WithShadowRate sets the fraction of the responses rendered with the
+// secondary responder of WithShadow, from 0 to 1. All are by default.
+func WithShadowRate(rate float64) OptionsModifier {
+	return func(o *options) {
+		o.shadowRate = rate
+	}
+}
+
+// shadow is the configuration of the response shadowing.
+type shadow struct {
+	secondary Responder
+	sink      io.Writer
+	mu        sync.Mutex
+}
+
+// teeWriter records the response written to the underlying writer.
+type teeWriter struct {
+	http.ResponseWriter
+	rec *cacheRecorder
+}
+
+func (t *teeWriter) WriteHeader(code int) {
+	t.rec.WriteHeader(code)
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *teeWriter) Write(b []byte) (int, error) {
+	_, _ = t.rec.Write(b)
+
+	return t.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (t *teeWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// shadowed sends the response with the send function, then renders it
+// with the secondary responder for the sampled responses and reports the differences.
+func (r *responder) shadowed(rw responseWriter, resp Response, send func(responseWriter) error) error {
+	s := r.options.shadow
+	if s == nil || s.secondary == nil || s.sink == nil || rand.Float64() >= r.options.shadowRate {
+		return send(rw)
+	}
+
+	tee := &teeWriter{ResponseWriter: rw, rec: newCacheRecorder()}
+
+	if err := send(tee); err != nil {
+		return err
+	}
+
+	tee.rec.header.Set("Content-Type", rw.Header().Get("Content-Type"))
+
+	s.compare(r.request, tee.rec, resp)
+
+	return nil
+}
+
+// compare renders the response with the secondary responder
+// and writes its differences with the primary response to the sink.
+func (s *shadow) compare(req *http.Request, primary *cacheRecorder, resp Response) {
+	var b bytes.Buffer
+
+	shadowed := newCacheRecorder()
+
+	func() {
+		defer func() {
+			if v := recover(); v != nil {
+				fmt.Fprintf(&b, "panic: %v\n", v)
+			}
+		}()
+
+		secondary := s.secondary
+		if req != nil {
+			secondary = secondary.WithRequest(req)
+		}
+
+		secondary.Send(shadowed, resp)
+	}()
+
+	if primary.code != shadowed.code {
+		fmt.Fprintf(&b, "status: %d != %d\n", primary.code, shadowed.code)
+	}
+
+	if p, s := primary.header.Get("Content-Type"), shadowed.header.Get("Content-Type"); p != s {
+		fmt.Fprintf(&b, "content-type: %q != %q\n", p, s)
+	}
+
+	if !bytes.Equal(primary.body.Bytes(), shadowed.body.Bytes()) {
+		fmt.Fprintf(&b, "body: %q != %q\n", primary.body.Bytes(), shadowed.body.Bytes())
+	}
+
+	if b.Len() == 0 {
+		return
+	}
+
+	title := "shadow diff\n"
+	if req != nil {
+		title = fmt.Sprintf("shadow diff %s %s\n", req.Method, req.URL.RequestURI())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, _ = io.WriteString(s.sink, title+b.String())
+}
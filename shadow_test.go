@@ -0,0 +1,101 @@
+package responder
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithShadow(t *testing.T) {
+	t.Run("reports the differences with the secondary responses", func(t *testing.T) {
+		var sink bytes.Buffer
+
+		secondary := New("application/vnd.api+json", WithDataFormatter(func(any) []byte { return []byte(`{"data":1}`) }))
+		responder := JSONResponder(WithShadow(secondary, &sink))
+		req := httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
+		w := httptest.NewRecorder()
+
+		responder.WithRequest(req).Send200(w, 1)
+
+		if w.Body.String() != "1" || w.Header().Get("Content-Type") != JSONContentType {
+			t.Errorf("expected the primary response to be sent, got %q", w.Body.String())
+		}
+
+		expected := "shadow diff GET /users?page=2\n" +
+			"content-type: \"application/json; charset=utf-8\" != \"application/vnd.api+json\"\n" +
+			"body: \"1\" != \"{\\\"data\\\":1}\"\n"
+		if sink.String() != expected {
+			t.Errorf("expected report %q, got %q", expected, sink.String())
+		}
+	})
+
+	t.Run("extends the write deadline of the primary writer", func(t *testing.T) {
+		var sink bytes.Buffer
+
+		w := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+
+		JSONResponder(WithShadow(JSONResponder(), &sink), WithWriteDeadline(time.Minute)).Send200(w, 1)
+
+		if len(w.deadlines) != 1 || !w.Flushed {
+			t.Errorf("expected the deadline to be extended through the shadowed writer, got %v", w.deadlines)
+		}
+	})
+
+	t.Run("reports nothing when the responses match", func(t *testing.T) {
+		var sink bytes.Buffer
+
+		responder := JSONResponder(WithShadow(JSONResponder(), &sink))
+
+		responder.Send200(httptest.NewRecorder(), map[string]int{"id": 1})
+		responder.Send404(httptest.NewRecorder(), errors.New("no row"), "missing")
+
+		if sink.Len() != 0 {
+			t.Errorf("expected no report, got %q", sink.String())
+		}
+	})
+
+	t.Run("shadows the error responses", func(t *testing.T) {
+		var sink bytes.Buffer
+
+		responder := JSONResponder(WithShadow(TextResponder(), &sink))
+
+		responder.Send(httptest.NewRecorder(), Error(http.StatusConflict, nil, "taken"))
+
+		if !strings.Contains(sink.String(), `body: "{\"error\":\"taken\"}" != "taken"`) {
+			t.Errorf("unexpected report %q", sink.String())
+		}
+	})
+
+	t.Run("samples the responses", func(t *testing.T) {
+		var sink bytes.Buffer
+
+		responder := JSONResponder(WithShadow(TextResponder(), &sink), WithShadowRate(0))
+
+		responder.Send200(httptest.NewRecorder(), "ok")
+
+		if sink.Len() != 0 {
+			t.Errorf("expected no report, got %q", sink.String())
+		}
+	})
+
+	t.Run("recovers the panics of the secondary responder", func(t *testing.T) {
+		var sink bytes.Buffer
+
+		secondary := JSONResponder(WithDataFormatter(func(any) []byte { panic("broken") }))
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithShadow(secondary, &sink)).Send200(w, "ok")
+
+		if w.Body.String() != "ok" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+
+		if !strings.Contains(sink.String(), "panic: broken") {
+			t.Errorf("expected the panic to be reported, got %q", sink.String())
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// sitemapMaxURLs is the maximum number of URLs of a sitemap.
+const sitemapMaxURLs = 50000
+
+// sitemapNamespace is the XML namespace of the sitemaps and sitemap indexes.
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// ChangeFreq is how frequently the page of a sitemap URL is likely to change.
+type ChangeFreq string
+
+// The change frequencies defined by the sitemap protocol.
+const (
+	ChangeFreqAlways  ChangeFreq = "always"
+	ChangeFreqHourly  ChangeFreq = "hourly"
+	ChangeFreqDaily   ChangeFreq = "daily"
+	ChangeFreqWeekly  ChangeFreq = "weekly"
+	ChangeFreqMonthly ChangeFreq = "monthly"
+	ChangeFreqYearly  ChangeFreq = "yearly"
+	ChangeFreqNever   ChangeFreq = "never"
+)
+
+// SitemapURL is a URL of a sitemap.
+type SitemapURL struct {
+	// Loc is the absolute URL of the page.
+	Loc string
+	// LastMod is the date of the last modification of the page, omitted when zero.
+	LastMod time.Time
+	// ChangeFreq is how frequently the page is likely to change, omitted when empty.
+	ChangeFreq ChangeFreq
+	// Priority is the priority of the page relative to the other pages of the site,
+	// between 0.0 and 1.0. It is omitted when zero, the crawlers assuming 0.5.
+	Priority float64
+}
+
+// WithSitemapBaseURL sets the absolute URL of the site, e.g. https://example.com,
+// the sitemap indexes link their parts from, the path of the request being appended
+// to it. It defaults to the scheme and the host of the first URL of the sitemap,
+// the Host header of the request being controlled by the client.
+func WithSitemapBaseURL(base string) OptionsModifier {
+	return func(o *options) {
+		o.sitemapBaseURL = base
+	}
+}
+
+// sitemapURLSet is the XML encoding of a sitemap.
+type sitemapURLSet struct {
+	XMLName xml.Name        `xml:"urlset"`
+	Xmlns   string          `xml:"xmlns,attr"`
+	URLs    []sitemapURLXML `xml:"url"`
+}
+
+type sitemapURLXML struct {
+	Loc        string     `xml:"loc"`
+	LastMod    string     `xml:"lastmod,omitempty"`
+	ChangeFreq ChangeFreq `xml:"changefreq,omitempty"`
+	Priority   string     `xml:"priority,omitempty"`
+}
+
+// sitemapIndex is the XML encoding of a sitemap index.
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Xmlns    string            `xml:"xmlns,attr"`
+	Sitemaps []sitemapIndexXML `xml:"sitemap"`
+}
+
+type sitemapIndexXML struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// sendSitemap sends the sitemap of the URLs, or the sitemap index of its parts
+// when there are too many URLs for a single sitemap.
+func (r *responder) sendSitemap(rw responseWriter, urls []SitemapURL) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	var doc any = newSitemapURLSet(urls)
+
+	if len(urls) > sitemapMaxURLs {
+		part, ok := r.sitemapPart(len(urls))
+		if !ok {
+			return r.sendError(rw, status404, nil, http.StatusText(status404))
+		}
+
+		if part == 0 {
+			index, err := r.newSitemapIndex(urls)
+			if err != nil {
+				return r.sendFailure(rw, fmt.Errorf("%w: %w", ErrInvalidContent, err))
+			}
+
+			doc = index
+		} else {
+			doc = newSitemapURLSet(urls[(part-1)*sitemapMaxURLs : min(part*sitemapMaxURLs, len(urls))])
+		}
+	}
+
+	b := bytes.NewBufferString(xml.Header)
+	if err := xml.NewEncoder(b).Encode(doc); err != nil {
+		return r.sendFailure(rw, fmt.Errorf("%w: %w", ErrInvalidContent, err))
+	}
+
+	return r.withContentType(XMLContentType).send(rw, status200, b.Bytes(), time.Since(start))
+}
+
+// sitemapPart returns the part of the sitemap requested with the sitemap query
+// parameter, 0 meaning the sitemap index.
+func (r *responder) sitemapPart(count int) (int, bool) {
+	if r.request == nil || !r.request.URL.Query().Has("sitemap") {
+		return 0, true
+	}
+
+	part, err := strconv.Atoi(r.request.URL.Query().Get("sitemap"))
+	if err != nil || part < 1 || (part-1)*sitemapMaxURLs >= count {
+		return 0, false
+	}
+
+	return part, true
+}
+
+// newSitemapIndex returns the sitemap index linking to the parts of the sitemap,
+// which are served by the same URL with a sitemap query parameter.
+func (r *responder) newSitemapIndex(urls []SitemapURL) (sitemapIndex, error) {
+	u, err := r.sitemapURL(urls)
+	if err != nil {
+		return sitemapIndex{}, err
+	}
+
+	index := sitemapIndex{Xmlns: sitemapNamespace}
+
+	for part := 1; (part-1)*sitemapMaxURLs < len(urls); part++ {
+		q := u.Query()
+		q.Set("sitemap", strconv.Itoa(part))
+		u.RawQuery = q.Encode()
+
+		var lastMod time.Time
+		for _, s := range urls[(part-1)*sitemapMaxURLs : min(part*sitemapMaxURLs, len(urls))] {
+			if s.LastMod.After(lastMod) {
+				lastMod = s.LastMod
+			}
+		}
+
+		index.Sitemaps = append(index.Sitemaps, sitemapIndexXML{Loc: u.String(), LastMod: w3cDatetime(lastMod)})
+	}
+
+	return index, nil
+}
+
+// sitemapURL returns the absolute URL of the sitemap, as required by the sitemap indexes:
+// the path of the request on the configured base URL or on the site of the first URL.
+func (r *responder) sitemapURL(urls []SitemapURL) (*url.URL, error) {
+	base := r.options.sitemapBaseURL
+	if base == "" {
+		base = urls[0].Loc
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("sitemap base URL %q is not absolute", base)
+	}
+
+	if r.options.sitemapBaseURL == "" {
+		u.Path = ""
+	}
+
+	u.RawPath, u.RawQuery, u.Fragment = "", "", ""
+
+	path := "/"
+	if r.request != nil {
+		path = r.request.URL.Path
+	}
+
+	return u.JoinPath(path), nil
+}
+
+// newSitemapURLSet returns the sitemap of the URLs.
+func newSitemapURLSet(urls []SitemapURL) sitemapURLSet {
+	set := sitemapURLSet{Xmlns: sitemapNamespace, URLs: make([]sitemapURLXML, len(urls))}
+
+	for i, u := range urls {
+		set.URLs[i] = sitemapURLXML{
+			Loc:        u.Loc,
+			LastMod:    w3cDatetime(u.LastMod),
+			ChangeFreq: u.ChangeFreq,
+		}
+
+		if u.Priority > 0 {
+			set.URLs[i].Priority = strconv.FormatFloat(min(u.Priority, 1), 'f', 1, 64)
+		}
+	}
+
+	return set
+}
+
+// w3cDatetime formats the time in the W3C Datetime format, an empty string for the zero time.
+func w3cDatetime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,99 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendSitemap(t *testing.T) {
+	lastMod := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("sends the urlset document", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendSitemap(w, []SitemapURL{
+			{Loc: "https://example.com/?a=1&b=2", LastMod: lastMod, ChangeFreq: ChangeFreqDaily, Priority: 0.8},
+			{Loc: "https://example.com/about"},
+		})
+
+		want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+			`<url><loc>https://example.com/?a=1&amp;b=2</loc><lastmod>2024-05-01T10:00:00Z</lastmod>` +
+			`<changefreq>daily</changefreq><priority>0.8</priority></url>` +
+			`<url><loc>https://example.com/about</loc></url></urlset>`
+		if b := w.Body.String(); b != want {
+			t.Errorf("expected body %s, got %s", want, b)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != XMLContentType {
+			t.Errorf("expected Content-Type %q, got %q", XMLContentType, ct)
+		}
+	})
+
+	urls := make([]SitemapURL, sitemapMaxURLs+1)
+	for i := range urls {
+		urls[i] = SitemapURL{Loc: fmt.Sprintf("https://example.com/%d", i)}
+	}
+
+	urls[len(urls)-1].LastMod = lastMod
+
+	t.Run("sends the sitemap index above the URL limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "http://attacker.example/sitemap.xml", nil)
+
+		XMLResponder().WithRequest(req).SendSitemap(w, urls)
+
+		want := `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+			`<sitemap><loc>https://example.com/sitemap.xml?sitemap=1</loc></sitemap>` +
+			`<sitemap><loc>https://example.com/sitemap.xml?sitemap=2</loc><lastmod>2024-05-01T10:00:00Z</lastmod></sitemap>` +
+			`</sitemapindex>`
+		if b := w.Body.String(); !strings.HasSuffix(b, want) {
+			t.Errorf("expected body ending with %s, got %s", want, b)
+		}
+	})
+
+	t.Run("links the parts from the configured base URL", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+
+		XMLResponder(WithSitemapBaseURL("https://www.example.com/blog")).WithRequest(req).SendSitemap(w, urls)
+
+		if b := w.Body.String(); !strings.Contains(b, "<loc>https://www.example.com/blog/sitemap.xml?sitemap=1</loc>") {
+			t.Errorf("expected the parts to be linked from the base URL, got %s", b)
+		}
+	})
+
+	t.Run("reports the relative base URLs", func(t *testing.T) {
+		err := Checked(XMLResponder(WithSitemapBaseURL("/blog"))).SendSitemap(httptest.NewRecorder(), urls)
+		if !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("expected ErrInvalidContent, got %v", err)
+		}
+	})
+
+	t.Run("sends the parts of the sitemap", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/sitemap.xml?sitemap=2", nil)
+
+		XMLResponder().WithRequest(req).SendSitemap(w, urls)
+
+		if b := w.Body.String(); strings.Count(b, "<url>") != 1 || !strings.Contains(b, "/50000</loc>") {
+			t.Errorf("unexpected body %s", b)
+		}
+	})
+
+	t.Run("sends 404 for the missing parts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/sitemap.xml?sitemap=3", nil)
+
+		XMLResponder().WithRequest(req).SendSitemap(w, urls)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package responder
+
+import "time"
+
+// SLOClass is the classification of a response against an SLO policy.
+type SLOClass string
+
+const (
+	// SLOGood is the class of the responses meeting the objective.
+	SLOGood SLOClass = "good"
+	// SLOBad is the class of the responses consuming the error budget.
+	SLOBad SLOClass = "bad"
+)
+
+// SLOPolicy classifies responses against a service level objective,
+// enabling burn-rate alerting directly from the responder data.
+//
+// The latency is measured from the moment the responder was bound to the
+// request with WithRequest, e.g. by a middleware. Responders that are not bound
+// to a request report a zero latency and are only classified on the status code.
+type SLOPolicy struct {
+	// LatencyThreshold is the latency above which a response is bad.
+	// Zero disables the latency objective.
+	LatencyThreshold time.Duration
+	// Classify overrides the default classification,
+	// which considers 5xx responses and slow responses bad.
+	Classify func(status int, latency time.Duration) SLOClass
+	// Observe receives the class of each response, e.g. to feed metrics or logs.
+	Observe func(status int, latency time.Duration, class SLOClass)
+	// Header is the name of an optional header exposing the class,
+	// typically for internal consumption by a proxy.
+	Header string
+}
+
+// WithSLOPolicy sets the policy classifying the responses.
+func WithSLOPolicy(p SLOPolicy) OptionsModifier {
+	return func(o *options) {
+		o.slo = &p
+	}
+}
+
+func (p *SLOPolicy) classify(status int, latency time.Duration) SLOClass {
+	if p.Classify != nil {
+		return p.Classify(status, latency)
+	}
+
+	if Is5xx(status) || (p.LatencyThreshold > 0 && latency > p.LatencyThreshold) {
+		return SLOBad
+	}
+
+	return SLOGood
+}
@@ -0,0 +1,78 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithSLOPolicy(t *testing.T) {
+	t.Run("classifies server errors as bad", func(t *testing.T) {
+		var observed []SLOClass
+
+		responder := TextResponder(WithSLOPolicy(SLOPolicy{
+			Header: "X-SLO-Class",
+			Observe: func(_ int, _ time.Duration, class SLOClass) {
+				observed = append(observed, class)
+			},
+		}))
+
+		w := httptest.NewRecorder()
+		responder.Send200(w, "ok")
+
+		if v := w.Header().Get("X-SLO-Class"); v != string(SLOGood) {
+			t.Errorf("expected class %q, got %q", SLOGood, v)
+		}
+
+		w = httptest.NewRecorder()
+		responder.Send500(w, errors.New("down"), "unavailable")
+
+		if v := w.Header().Get("X-SLO-Class"); v != string(SLOBad) {
+			t.Errorf("expected class %q, got %q", SLOBad, v)
+		}
+
+		if len(observed) != 2 || observed[0] != SLOGood || observed[1] != SLOBad {
+			t.Errorf("unexpected observations %v", observed)
+		}
+	})
+
+	t.Run("classifies slow responses as bad", func(t *testing.T) {
+		responder := TextResponder(WithSLOPolicy(SLOPolicy{
+			LatencyThreshold: time.Millisecond,
+			Header:           "X-SLO-Class",
+		}))
+
+		bound := responder.WithRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+
+		time.Sleep(5 * time.Millisecond)
+
+		w := httptest.NewRecorder()
+		bound.Send200(w, "ok")
+
+		if v := w.Header().Get("X-SLO-Class"); v != string(SLOBad) {
+			t.Errorf("expected class %q, got %q", SLOBad, v)
+		}
+	})
+
+	t.Run("uses the custom classification", func(t *testing.T) {
+		responder := TextResponder(WithSLOPolicy(SLOPolicy{
+			Header: "X-SLO-Class",
+			Classify: func(status int, _ time.Duration) SLOClass {
+				if status == http.StatusTooManyRequests {
+					return SLOBad
+				}
+
+				return SLOGood
+			},
+		}))
+
+		w := httptest.NewRecorder()
+		responder.SendQueued(w, -1, time.Second)
+
+		if v := w.Header().Get("X-SLO-Class"); v != string(SLOBad) {
+			t.Errorf("expected class %q, got %q", SLOBad, v)
+		}
+	})
+}
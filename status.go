@@ -0,0 +1,59 @@
+package responder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidStatus is returned when a response is sent with a status code
+// that does not belong to the expected class.
+var ErrInvalidStatus = errors.New("responder: invalid status code")
+
+func (r *responder) SendSuccessStatus(rw responseWriter, code int, data any) {
+	_ = r.sendSuccessStatus(rw, code, data)
+}
+
+func (r *responder) SendErrorStatus(rw responseWriter, code int, err error, message any) {
+	_ = r.sendErrorStatus(rw, code, err, message)
+}
+
+// sendSuccessStatus sends the data with the 2xx status code.
+func (r *responder) sendSuccessStatus(rw responseWriter, code int, data any) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	if !Is2xx(code) {
+		return r.sendFailure(rw, fmt.Errorf("%w: %d is not a success status", ErrInvalidStatus, code))
+	}
+
+	return r.sendData(rw, code, data)
+}
+
+// sendErrorStatus sends the error with the 4xx or 5xx status code.
+func (r *responder) sendErrorStatus(rw responseWriter, code int, err error, message any) error {
+	if rerr := r.ready(rw); rerr != nil {
+		return rerr
+	}
+
+	if !validErrorStatus(code) {
+		return r.sendFailure(rw, fmt.Errorf("%w: %d is not an error status", ErrInvalidStatus, code))
+	}
+
+	return r.sendError(rw, code, err, message)
+}
+
+// redirectStatus redirects with the 3xx status code.
+func (r *responder) redirectStatus(rw responseWriter, req *http.Request, loc string, code int) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	switch code {
+	case http.StatusMultipleChoices, status301, status302, status303, status307, status308:
+		return r.redirect(rw, req, loc, code)
+	default:
+		return r.sendFailure(rw, fmt.Errorf("%w: %d is not a redirect status", ErrInvalidStatus, code))
+	}
+}
@@ -0,0 +1,107 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendSuccessStatus(t *testing.T) {
+	t.Run("sends arbitrary success codes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendSuccessStatus(w, http.StatusMultiStatus, []string{"a", "b"})
+
+		if w.Code != http.StatusMultiStatus || w.Body.String() != `["a","b"]` {
+			t.Errorf("expected a 207 with the body, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("rejects other codes", func(t *testing.T) {
+		for _, code := range []int{0, 101, 302, 404, 1000} {
+			w := httptest.NewRecorder()
+
+			TextResponder().SendSuccessStatus(w, code, "data")
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected status %d for %d, got %d", http.StatusInternalServerError, code, w.Code)
+			}
+
+			err := Checked(TextResponder()).SendSuccessStatus(httptest.NewRecorder(), code, "data")
+			if !errors.Is(err, ErrInvalidStatus) {
+				t.Errorf("expected ErrInvalidStatus for %d, got %v", code, err)
+			}
+		}
+	})
+}
+
+func TestSendErrorStatus(t *testing.T) {
+	t.Run("sends arbitrary error codes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendErrorStatus(w, http.StatusTeapot, nil, "short and stout")
+
+		if w.Code != http.StatusTeapot || w.Body.String() != `{"error":"short and stout"}` {
+			t.Errorf("expected a 418 with the message, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("rejects other codes", func(t *testing.T) {
+		for _, code := range []int{200, 304, 600} {
+			w := httptest.NewRecorder()
+
+			TextResponder().SendErrorStatus(w, code, nil, "error")
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected status %d for %d, got %d", http.StatusInternalServerError, code, w.Code)
+			}
+
+			err := Checked(TextResponder()).SendErrorStatus(httptest.NewRecorder(), code, nil, "error")
+			if !errors.Is(err, ErrInvalidStatus) {
+				t.Errorf("expected ErrInvalidStatus for %d, got %v", code, err)
+			}
+		}
+	})
+}
+
+func TestRedirect(t *testing.T) {
+	t.Run("Redirect308 preserves the method", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Redirect308(w, httptest.NewRequest(http.MethodPost, "/v1/users", nil), "/v2/users")
+
+		if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != "/v2/users" {
+			t.Errorf("expected a 308 to /v2/users, got %d to %q", w.Code, w.Header().Get("Location"))
+		}
+	})
+
+	t.Run("sends redirect codes", func(t *testing.T) {
+		for _, code := range []int{300, 301, 302, 303, 307, 308} {
+			w := httptest.NewRecorder()
+
+			TextResponder().Redirect(w, httptest.NewRequest(http.MethodGet, "/", nil), "/elsewhere", code)
+
+			if w.Code != code || w.Header().Get("Location") != "/elsewhere" {
+				t.Errorf("expected a %d to /elsewhere, got %d to %q", code, w.Code, w.Header().Get("Location"))
+			}
+		}
+	})
+
+	t.Run("rejects other codes", func(t *testing.T) {
+		for _, code := range []int{200, 304, 305, 404} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			TextResponder().Redirect(w, req, "/elsewhere", code)
+
+			if w.Code != http.StatusInternalServerError || w.Header().Get("Location") != "" {
+				t.Errorf("expected a 500 without Location for %d, got %d to %q", code, w.Code, w.Header().Get("Location"))
+			}
+
+			if err := Checked(TextResponder()).Redirect(httptest.NewRecorder(), req, "/", code); !errors.Is(err, ErrInvalidStatus) {
+				t.Errorf("expected ErrInvalidStatus for %d, got %v", code, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package responder
+
+import "strconv"
+
+// Is1xx reports whether the status code is informational.
+func Is1xx(status int) bool {
+	return status >= 100 && status < 200
+}
+
+// Is2xx reports whether the status code is successful. Like the other
+// classification helpers, it can be used as a hook directly, e.g. WithNoStore(Is5xx).
+func Is2xx(status int) bool {
+	return status >= 200 && status < 300
+}
+
+// Is3xx reports whether the status code is a redirection.
+func Is3xx(status int) bool {
+	return status >= 300 && status < 400
+}
+
+// Is4xx reports whether the status code is a client error.
+func Is4xx(status int) bool {
+	return status >= 400 && status < 500
+}
+
+// Is5xx reports whether the status code is a server error.
+func Is5xx(status int) bool {
+	return status >= 500 && status < 600
+}
+
+// StatusClass returns the class of the status code, from "1xx" to "5xx",
+// e.g. to label metrics. It returns "unknown" for the codes out of range.
+func StatusClass(status int) string {
+	if status < 100 || status >= 600 {
+		return "unknown"
+	}
+
+	return strconv.Itoa(status/100) + "xx"
+}
@@ -0,0 +1,68 @@
+package responder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		status int
+		class  string
+		is     func(int) bool
+	}{
+		{http.StatusContinue, "1xx", Is1xx},
+		{http.StatusOK, "2xx", Is2xx},
+		{http.StatusNoContent, "2xx", Is2xx},
+		{http.StatusPermanentRedirect, "3xx", Is3xx},
+		{http.StatusNotFound, "4xx", Is4xx},
+		{http.StatusGatewayTimeout, "5xx", Is5xx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			if c := StatusClass(tt.status); c != tt.class {
+				t.Errorf("expected class %q for %d, got %q", tt.class, tt.status, c)
+			}
+
+			if !tt.is(tt.status) {
+				t.Errorf("expected %d to be classified as %s", tt.status, tt.class)
+			}
+
+			matches := 0
+
+			for _, is := range []func(int) bool{Is1xx, Is2xx, Is3xx, Is4xx, Is5xx} {
+				if is(tt.status) {
+					matches++
+				}
+			}
+
+			if matches != 1 {
+				t.Errorf("expected %d to belong to a single class, got %d", tt.status, matches)
+			}
+		})
+	}
+
+	t.Run("out of range codes", func(t *testing.T) {
+		for _, status := range []int{0, 99, 600} {
+			if c := StatusClass(status); c != "unknown" {
+				t.Errorf("expected class %q for %d, got %q", "unknown", status, c)
+			}
+
+			if Is1xx(status) || Is2xx(status) || Is3xx(status) || Is4xx(status) || Is5xx(status) {
+				t.Errorf("expected %d not to be classified", status)
+			}
+		}
+	})
+
+	t.Run("usable as hooks", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithNoStore(Is5xx)).Send404(w, nil, "missing")
+
+		if v := w.Header().Get("Cache-Control"); v != "" {
+			t.Errorf("expected no Cache-Control, got %q", v)
+		}
+	})
+}
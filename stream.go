@@ -0,0 +1,185 @@
+package responder
+
+import (
+	"encoding/json"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mickaelvieira/responder/internal"
+)
+
+// NDJSONContentType is the content type for newline-delimited JSON streams.
+const NDJSONContentType = "application/x-ndjson"
+
+// streamFlushEvery is the number of items after which
+// a stream fed by an iterator is flushed to the client.
+const streamFlushEvery = 64
+
+func (r *responder) SendStream(rw responseWriter, ch <-chan any) {
+	// Channel based streams are flushed whenever the producer has nothing
+	// ready, so that slow producers do not keep items in the buffer.
+	_ = r.streamNDJSON(rw, channelSeq(ch), func(int) bool { return len(ch) == 0 })
+}
+
+// channelSeq returns a sequence of the values received from the channel.
+func channelSeq(ch <-chan any) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (r *responder) SendSeq(rw responseWriter, seq iter.Seq[any]) {
+	_ = r.streamNDJSON(rw, seq, func(n int) bool { return n%streamFlushEvery == 0 })
+}
+
+// streamNDJSON writes each item of the sequence as a line of JSON.
+// The flush function is called after each item with the number of items
+// written so far and reports whether the buffered data should be flushed.
+func (r *responder) streamNDJSON(rw responseWriter, seq iter.Seq[any], flush func(int) bool) error {
+	if err := r.ready(rw); err != nil {
+		return err
+	}
+
+	if err := r.canceled(status200); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	rc := http.NewResponseController(rw)
+
+	r.prepareHeader(rw, status200, NDJSONContentType)
+	rw.Header().Del("Content-Length")
+
+	w, digest := r.bodyWriter(rw)
+	w, journaled := r.journalWriter(r.throttle(r.extendDeadline(rw, w)))
+	cw := &countingWriter{w: w}
+
+	rw.WriteHeader(status200)
+
+	enc := json.NewEncoder(cw)
+	enc.SetEscapeHTML(r.options.jsonEscapeHTML)
+
+	n := 0
+
+	for v := range seq {
+		if err := enc.Encode(v); err != nil {
+			r.logStreamError(err, n)
+			r.record(rw, status200, journaled, err, "")
+			elapsed := time.Since(start)
+			r.recordMetrics(rw, status200, cw.n, elapsed, elapsed)
+			r.afterSend(status200, cw.n, err)
+
+			return err
+		}
+
+		n++
+
+		if flush(n) {
+			_ = rc.Flush()
+		}
+	}
+
+	if digest != nil {
+		internal.SetDigestTrailer(rw.Header(), digest)
+	}
+
+	_ = rc.Flush()
+	r.record(rw, status200, journaled, nil, "")
+	elapsed := time.Since(start)
+	r.recordMetrics(rw, status200, cw.n, elapsed, elapsed)
+	r.afterSend(status200, cw.n, nil)
+
+	return nil
+}
+
+// streamBody copies the body to the writer without loading it in memory.
+// The Content-Length is only sent when the body reports its length and
+// no digest trailer is due, otherwise the response is sent with chunked
+// encoding, net/http dropping the trailers of the other responses.
+// The body is closed once written when it implements io.Closer.
+func (r *responder) streamBody(rw responseWriter, code int, body any) error {
+	if c, ok := body.(io.Closer); ok {
+		defer func() {
+			_ = c.Close()
+		}()
+	}
+
+	if !bodyAllowed(code) {
+		return r.send(rw, code, nil, 0)
+	}
+
+	if err := r.canceled(code); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	r.prepareHeader(rw, code, r.contentType)
+
+	if l, ok := body.(interface{ Len() int }); ok && !r.options.digestTrailer {
+		rw.Header().Set("Content-Length", strconv.Itoa(l.Len()))
+	} else {
+		rw.Header().Del("Content-Length")
+	}
+
+	w, digest := r.bodyWriter(rw)
+	w, journaled := r.journalWriter(r.throttle(r.extendDeadline(rw, w)))
+	cw := &countingWriter{w: w}
+
+	rw.WriteHeader(code)
+
+	var err error
+
+	switch v := body.(type) {
+	case io.WriterTo:
+		_, err = v.WriteTo(cw)
+	case io.Reader:
+		_, err = io.Copy(cw, v)
+	}
+
+	if err != nil {
+		if r.options.logger != nil {
+			r.options.logger.Error("failed to write response",
+				"status", code,
+				"error", err,
+			)
+		}
+
+		r.record(rw, code, journaled, err, "")
+		elapsed := time.Since(start)
+		r.recordMetrics(rw, code, cw.n, elapsed, elapsed)
+		r.afterSend(code, cw.n, err)
+
+		return err
+	}
+
+	if digest != nil {
+		internal.SetDigestTrailer(rw.Header(), digest)
+	}
+
+	r.record(rw, code, journaled, nil, "")
+	elapsed := time.Since(start)
+	r.recordMetrics(rw, code, cw.n, elapsed, elapsed)
+	r.afterSend(code, cw.n, nil)
+
+	return nil
+}
+
+func (r *responder) logStreamError(err error, written int) {
+	if r.options.logger == nil {
+		return
+	}
+
+	r.options.logger.Error("failed to stream response",
+		"status", status200,
+		"items", written,
+		"error", err,
+	)
+}
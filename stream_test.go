@@ -0,0 +1,155 @@
+package responder
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSendStream(t *testing.T) {
+	t.Run("streams channel values as NDJSON", func(t *testing.T) {
+		ch := make(chan any, 3)
+		ch <- map[string]int{"id": 1}
+		ch <- map[string]int{"id": 2}
+		ch <- "three"
+		close(ch)
+
+		w := httptest.NewRecorder()
+
+		JSONResponder().SendStream(w, ch)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+			t.Errorf("expected Content-Type %q, got %q", NDJSONContentType, ct)
+		}
+
+		expected := "{\"id\":1}\n{\"id\":2}\n\"three\"\n"
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+
+		if !w.Flushed {
+			t.Error("expected the stream to be flushed")
+		}
+	})
+
+	t.Run("streams iterator values as NDJSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		seq := func(yield func(any) bool) {
+			for _, v := range []int{1, 2, 3} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		JSONResponder().SendSeq(w, seq)
+
+		if w.Body.String() != "1\n2\n3\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("stops and logs on encoding errors", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithLogger(logger)).SendSeq(w, slices.Values([]any{1, make(chan int), 3}))
+
+		if w.Body.String() != "1\n" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+
+		if !strings.Contains(logs.String(), "failed to stream response") {
+			t.Errorf("expected the error to be logged, got %q", logs.String())
+		}
+	})
+}
+
+type writerToOnly struct{ data string }
+
+func (w writerToOnly) WriteTo(dst io.Writer) (int64, error) {
+	n, err := io.WriteString(dst, w.data)
+
+	return int64(n), err
+}
+
+type closingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closingReader) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestReaderBodies(t *testing.T) {
+	t.Run("streams io.Reader bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := &closingReader{Reader: io.LimitReader(strings.NewReader("large report"), 5)}
+
+		CSVResponder().Send200(w, body)
+
+		if w.Body.String() != "large" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+
+		if v := w.Header().Get("Content-Length"); v != "" {
+			t.Errorf("expected no Content-Length, got %q", v)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != CSVContentType {
+			t.Errorf("expected Content-Type %q, got %q", CSVContentType, ct)
+		}
+
+		if !body.closed {
+			t.Error("expected the body to be closed")
+		}
+	})
+
+	t.Run("keeps the Content-Length of readers reporting their length", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send201(w, strings.NewReader("created"))
+
+		if w.Code != http.StatusCreated || w.Body.String() != "created" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+
+		if v := w.Header().Get("Content-Length"); v != "7" {
+			t.Errorf("expected Content-Length %q, got %q", "7", v)
+		}
+	})
+
+	t.Run("streams io.WriterTo bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Success(http.StatusOK, writerToOnly{data: "written"}))
+
+		if w.Body.String() != "written" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("does not write bodies for bodyless statuses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder().Send(w, Success(http.StatusNoContent, strings.NewReader("ignored")))
+
+		if w.Body.Len() != 0 {
+			t.Errorf("expected an empty body, got %q", w.Body.String())
+		}
+	})
+}
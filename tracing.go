@@ -0,0 +1,63 @@
+package responder
+
+import (
+	"context"
+	"net/http"
+)
+
+// Span is the span of the request annotated by the responder, typically a thin
+// adapter over the span OpenTelemetry stores in the request context:
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) RecordError(err error) { s.Span.RecordError(err) }
+//	func (s otelSpan) SetError(desc string)  { s.Span.SetStatus(codes.Error, desc) }
+//	func (s otelSpan) TraceID() string       { return s.SpanContext().TraceID().String() }
+type Span interface {
+	// RecordError records the error as an event of the span.
+	RecordError(err error)
+	// SetError sets the status of the span to error with the description.
+	SetError(description string)
+	// TraceID returns the ID of the trace of the span, or an empty string when invalid.
+	TraceID() string
+}
+
+// WithTracing annotates the span the function extracts from the request context
+// with the error responses: the internal error is recorded as a span event and,
+// for the 5xx responses, the span status is set to error, the client errors being
+// left unset as prescribed by the OpenTelemetry semantic conventions for servers.
+// The function returns nil when the context carries no span.
+// The trace ID is also passed to the metrics recorder, see ResponseMetrics.
+// It only applies to responders bound to a request with WithRequest.
+func WithTracing(spanFromContext func(context.Context) Span) OptionsModifier {
+	return func(o *options) {
+		o.spanFromContext = spanFromContext
+	}
+}
+
+// span returns the span of the request the responder is bound to, if any.
+func (r *responder) span() Span {
+	if r.options.spanFromContext == nil || r.request == nil {
+		return nil
+	}
+
+	return r.options.spanFromContext(r.request.Context())
+}
+
+// traceError annotates the span of the request with the error response.
+func (r *responder) traceError(code int, err error) {
+	if err == nil {
+		return
+	}
+
+	span := r.span()
+	if span == nil {
+		return
+	}
+
+	span.RecordError(err)
+
+	if Is5xx(code) {
+		span.SetError(http.StatusText(code))
+	}
+}
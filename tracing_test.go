@@ -0,0 +1,91 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type spanKey struct{}
+
+type memorySpan struct {
+	errors []error
+	status string
+}
+
+func (s *memorySpan) RecordError(err error)       { s.errors = append(s.errors, err) }
+func (s *memorySpan) SetError(description string) { s.status = description }
+func (s *memorySpan) TraceID() string             { return "4bf92f3577b34da6a3ce929d0e0e4736" }
+
+func TestWithTracing(t *testing.T) {
+	spanFromContext := func(ctx context.Context) Span {
+		if s, ok := ctx.Value(spanKey{}).(*memorySpan); ok {
+			return s
+		}
+
+		return nil
+	}
+
+	newRequest := func(span *memorySpan) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		return req.WithContext(context.WithValue(req.Context(), spanKey{}, span))
+	}
+
+	t.Run("records the errors of server errors and sets the status", func(t *testing.T) {
+		span := &memorySpan{}
+		err := errors.New("connection refused")
+
+		JSONResponder(WithTracing(spanFromContext)).
+			WithRequest(newRequest(span)).
+			Send502(httptest.NewRecorder(), err, "bad gateway")
+
+		if len(span.errors) != 1 || span.errors[0] != err {
+			t.Errorf("expected the error to be recorded, got %v", span.errors)
+		}
+
+		if span.status != "Bad Gateway" {
+			t.Errorf("expected status %q, got %q", "Bad Gateway", span.status)
+		}
+	})
+
+	t.Run("leaves the status of client errors unset", func(t *testing.T) {
+		span := &memorySpan{}
+
+		JSONResponder(WithTracing(spanFromContext)).
+			WithRequest(newRequest(span)).
+			Send404(httptest.NewRecorder(), errors.New("no row"), "not found")
+
+		if len(span.errors) != 1 || span.status != "" {
+			t.Errorf("unexpected span %+v", span)
+		}
+	})
+
+	t.Run("ignores the responses without error or span", func(t *testing.T) {
+		span := &memorySpan{}
+		responder := JSONResponder(WithTracing(spanFromContext))
+
+		responder.WithRequest(newRequest(span)).Send500(httptest.NewRecorder(), nil, "error")
+		responder.WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).
+			Send500(httptest.NewRecorder(), errors.New("boom"), "error")
+		responder.Send500(httptest.NewRecorder(), errors.New("boom"), "error")
+
+		if len(span.errors) != 0 || span.status != "" {
+			t.Errorf("unexpected span %+v", span)
+		}
+	})
+
+	t.Run("passes the trace ID to the metrics recorder", func(t *testing.T) {
+		rec := &memoryMetrics{}
+
+		TextResponder(WithTracing(spanFromContext), WithMetrics(rec)).
+			WithRequest(newRequest(&memorySpan{})).
+			Send200(httptest.NewRecorder(), "ok")
+
+		if len(rec.metrics) != 1 || rec.metrics[0].TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("unexpected records %+v", rec.metrics)
+		}
+	})
+}
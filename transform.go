@@ -0,0 +1,53 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// JSONTransform rewrites the decoded JSON object of a response body,
+// e.g. to rename or remove fields.
+type JSONTransform func(map[string]any) map[string]any
+
+// WithJSONTransform sets a transformation applied to the formatted body
+// of JSON responses, for instance to map fields in gateway deployments.
+// It is only applied when the content type is JSON and the body is a JSON object,
+// other bodies are sent untouched. Note that the object keys of transformed
+// bodies are sorted once encoded again.
+func WithJSONTransform(f JSONTransform) OptionsModifier {
+	return func(o *options) {
+		o.jsonTransform = f
+	}
+}
+
+// isJSON reports whether the content type denotes a JSON document.
+func isJSON(contentType string) bool {
+	mt := mediaType(contentType)
+
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+// transformJSON applies the transformation to the body when it holds a JSON object.
+// The body is returned unchanged when it does not or when the result cannot be encoded.
+func transformJSON(body []byte, f JSONTransform) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+
+	var tree map[string]any
+	if err := dec.Decode(&tree); err != nil {
+		return body
+	}
+
+	out, err := json.Marshal(f(tree))
+	if err != nil {
+		return body
+	}
+
+	return out
+}
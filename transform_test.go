@@ -0,0 +1,68 @@
+package responder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithJSONTransform(t *testing.T) {
+	rename := func(m map[string]any) map[string]any {
+		if v, ok := m["user_id"]; ok {
+			m["userId"] = v
+			delete(m, "user_id")
+		}
+
+		delete(m, "internal")
+
+		return m
+	}
+
+	t.Run("transforms JSON objects", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONTransform(rename)).Send200(w, map[string]any{
+			"user_id":  12345678901234567,
+			"internal": true,
+		})
+
+		expected := `{"userId":12345678901234567}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("transforms error bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		wrap := func(m map[string]any) map[string]any {
+			return map[string]any{"failure": m["error"]}
+		}
+
+		JSONResponder(WithJSONTransform(wrap)).Send400(w, errors.New("bad"), "invalid input")
+
+		expected := `{"failure":"invalid input"}`
+		if w.Body.String() != expected {
+			t.Errorf("expected body %q, got %q", expected, w.Body.String())
+		}
+	})
+
+	t.Run("leaves other JSON values untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		JSONResponder(WithJSONTransform(rename)).Send200(w, []int{1, 2})
+
+		if w.Body.String() != "[1,2]" {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("leaves non-JSON responses untouched", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		TextResponder(WithJSONTransform(rename)).Send200(w, `{"user_id":1}`)
+
+		if w.Body.String() != `{"user_id":1}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package responder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// WatermarkField is the field holding the watermark of the JSON objects.
+const WatermarkField = "_watermark"
+
+// Watermarker derives the watermark of the responses sent to a principal,
+// e.g. an HMAC of its identifier. No watermark is embedded when it returns
+// an empty string.
+type Watermarker func(principal any) string
+
+// WithWatermark embeds a watermark derived from the authenticated principal
+// in the successful responses, so that leaked exports can be traced back to
+// the client they were sent to. The principal is read from the request context
+// under the key set with WithPrincipalKey, it therefore only applies to
+// responders bound to a request with WithRequest.
+// The watermark is embedded once the body is formatted, as the WatermarkField
+// of the JSON objects, an HTML comment appended to the HTML documents and
+// a comment line appended to the CSV documents, the other bodies being sent
+// untouched. The watermarks embedded are recorded in the journal, if any.
+func WithWatermark(f Watermarker) OptionsModifier {
+	return func(o *options) {
+		o.watermarker = f
+	}
+}
+
+// principal returns the authenticated principal of the request the responder is bound to.
+func (r *responder) principal() any {
+	if r.request == nil || r.options.principalKey == nil {
+		return nil
+	}
+
+	return r.request.Context().Value(r.options.principalKey)
+}
+
+// watermark embeds the watermark of the principal in the body of the successful responses.
+// It returns the body along with the watermark embedded, if any.
+func (r *responder) watermark(code int, body []byte) ([]byte, string) {
+	if r.options.watermarker == nil || !Is2xx(code) || len(body) == 0 {
+		return body, ""
+	}
+
+	p := r.principal()
+	if p == nil {
+		return body, ""
+	}
+
+	mark := r.options.watermarker(p)
+	if mark == "" {
+		return body, ""
+	}
+
+	var marked []byte
+
+	switch mt := mediaType(r.contentType); {
+	case isJSON(r.contentType):
+		marked = watermarkJSON(body, mark)
+	case mt == "text/html":
+		// A comment cannot contain a double hyphen.
+		marked = fmt.Appendf(bytes.Clone(body), "\n<!-- %s -->\n", strings.ReplaceAll(mark, "--", "- -"))
+	case mt == "text/csv":
+		marked = fmt.Appendf(bytes.Clone(body), "# %s\n", strings.Join(strings.Fields(mark), " "))
+	}
+
+	if marked == nil {
+		return body, ""
+	}
+
+	return marked, mark
+}
+
+// watermarkJSON inserts the watermark field at the start of the JSON object.
+// It returns nil when the body does not hold a JSON object.
+func watermarkJSON(body []byte, mark string) []byte {
+	i := len(body) - len(bytes.TrimLeft(body, " \t\r\n"))
+	if i == len(body) || body[i] != '{' {
+		return nil
+	}
+
+	field, err := json.Marshal(map[string]string{WatermarkField: mark})
+	if err != nil {
+		return nil
+	}
+
+	out := make([]byte, 0, len(body)+len(field)+1)
+	out = append(out, body[:i]...)
+	out = append(out, field[:len(field)-1]...)
+
+	if rest := bytes.TrimLeft(body[i+1:], " \t\r\n"); len(rest) == 0 || rest[0] != '}' {
+		out = append(out, ',')
+	}
+
+	return append(out, body[i+1:]...)
+}
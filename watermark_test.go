@@ -0,0 +1,80 @@
+package responder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithWatermark(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	req = req.WithContext(context.WithValue(req.Context(), principalKey{}, "user-42"))
+	mark := func(p any) string { return "wm-" + p.(string) }
+
+	t.Run("embeds the watermark in the bodies", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			responder Responder
+			data      any
+			expected  string
+		}{
+			{"JSON object", JSONResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark)), map[string]int{"id": 1}, `{"_watermark":"wm-user-42","id":1}`},
+			{"empty JSON object", JSONResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark)), struct{}{}, `{"_watermark":"wm-user-42"}`},
+			{"JSON array", JSONResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark)), []int{1}, `[1]`},
+			{"HTML", HTMLResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark)), "<p>report</p>", "<p>report</p>\n<!-- wm-user-42 -->\n"},
+			{"CSV", CSVResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark)), "id\n1\n", "id\n1\n# wm-user-42\n"},
+			{"text", TextResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark)), "report", "report"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := httptest.NewRecorder()
+
+				tt.responder.WithRequest(req).Send200(w, tt.data)
+
+				if w.Body.String() != tt.expected {
+					t.Errorf("expected body %q, got %q", tt.expected, w.Body.String())
+				}
+			})
+		}
+	})
+
+	t.Run("does not watermark anonymous or error responses", func(t *testing.T) {
+		responder := JSONResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark))
+
+		w := httptest.NewRecorder()
+		responder.WithRequest(httptest.NewRequest(http.MethodGet, "/", nil)).Send200(w, map[string]int{"id": 1})
+
+		if w.Body.String() != `{"id":1}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		responder.WithRequest(req).Send404(w, nil, "missing")
+
+		if w.Body.String() != `{"error":"missing"}` {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("records the watermark in the journal", func(t *testing.T) {
+		journal := &memoryJournal{}
+		responder := CSVResponder(WithPrincipalKey(principalKey{}), WithWatermark(mark), WithJournal(journal))
+
+		responder.WithRequest(req).Send200(httptest.NewRecorder(), "id\n1\n")
+		responder.Send200(httptest.NewRecorder(), "id\n1\n")
+
+		if len(journal.entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(journal.entries))
+		}
+
+		if v := journal.entries[0].Watermark; v != "wm-user-42" {
+			t.Errorf("expected watermark %q, got %q", "wm-user-42", v)
+		}
+
+		if v := journal.entries[1].Watermark; v != "" {
+			t.Errorf("expected no watermark, got %q", v)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package responder
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// XLSXContentType is the content type for Excel workbook responses.
+const XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// XLSXResponder creates a new responder sending the data as Excel workbooks of
+// a single sheet, as attachments named "export.xlsx" unless another filename
+// is set with WithAttachmentFilename.
+// Slices of structs are encoded with a header row, the columns being named
+// after the csv struct tags as for the CSV responders. Slices of []any or
+// []string are encoded as rows, and the other values in a single cell.
+// Numbers and booleans are sent as such, the other values as text.
+// The error messages are sent as plain text.
+func XLSXResponder(options ...OptionsModifier) Responder {
+	o := []OptionsModifier{WithAttachmentFilename("export.xlsx")}
+	o = append(o, options...)
+
+	return New(XLSXContentType, o...)
+}
+
+// formatXLSX is the default data formatter of the XLSX responders.
+func formatXLSX(c any) ([]byte, error) {
+	return formatLocalizedXLSX(nil, c)
+}
+
+// formatLocalizedXLSX formats the data as formatXLSX does, the text cells
+// holding floats and dates being formatted with the conventions of the locale, if any.
+func formatLocalizedXLSX(l *Locale, c any) ([]byte, error) {
+	if b, ok := c.([]byte); ok {
+		return b, nil
+	}
+
+	rows, err := xlsxRows(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeXLSX(rows, l)
+}
+
+// xlsxRows returns the rows of cells of the data.
+func xlsxRows(c any) ([][]any, error) {
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		text, err := formatText(c)
+
+		return [][]any{{string(text)}}, err
+	}
+
+	t := v.Type().Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Struct {
+		fields, header := csvFields(t)
+		rows := make([][]any, 0, v.Len()+1)
+		rows = append(rows, make([]any, len(header)))
+
+		for i, h := range header {
+			rows[0][i] = h
+		}
+
+		for i := range v.Len() {
+			elem := reflect.Indirect(v.Index(i))
+			row := make([]any, len(fields))
+
+			if elem.IsValid() {
+				for j, f := range fields {
+					if fv, err := elem.FieldByIndexErr(f.Index); err == nil {
+						row[j] = fv.Interface()
+					}
+				}
+			}
+
+			rows = append(rows, row)
+		}
+
+		return rows, nil
+	}
+
+	rows := make([][]any, 0, v.Len())
+
+	for i := range v.Len() {
+		elem := reflect.Indirect(v.Index(i))
+		if elem.Kind() == reflect.Interface {
+			elem = elem.Elem()
+		}
+
+		if elem.Kind() != reflect.Slice && elem.Kind() != reflect.Array {
+			rows = append(rows, []any{v.Index(i).Interface()})
+
+			continue
+		}
+
+		row := make([]any, elem.Len())
+		for j := range elem.Len() {
+			row[j] = elem.Index(j).Interface()
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// xlsxParts holds the static parts of the workbooks.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// encodeXLSX encodes the rows as a workbook of a single sheet.
+func encodeXLSX(rows [][]any, l *Locale) ([]byte, error) {
+	var b bytes.Buffer
+
+	zw := zip.NewWriter(&b)
+
+	for _, p := range xlsxParts {
+		w, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := io.WriteString(w, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeSheet(w, rows, l); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// writeSheet writes the worksheet holding the rows, the strings being inlined.
+func writeSheet(w io.Writer, rows [][]any, l *Locale) error {
+	var b bytes.Buffer
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	for i, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+
+		for j, cell := range row {
+			ref := xlsxColumn(j) + strconv.Itoa(i+1)
+			if err := writeCell(&b, ref, cell, l); err != nil {
+				return err
+			}
+		}
+
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+
+	_, err := w.Write(b.Bytes())
+
+	return err
+}
+
+// writeCell writes the cell holding the value, the text being formatted
+// with the conventions of the locale, if any. Nil values are left out.
+func writeCell(b *bytes.Buffer, ref string, value any, l *Locale) error {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+			break
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	var number string
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		// NaN and infinities cannot be stored as numbers.
+		if f := v.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			number = strconv.FormatFloat(f, 'g', -1, v.Type().Bits())
+		}
+	case reflect.Bool:
+		number = "0"
+		if v.Bool() {
+			number = "1"
+		}
+
+		fmt.Fprintf(b, `<c r="%s" t="b"><v>%s</v></c>`, ref, number)
+
+		return nil
+	}
+
+	if number != "" {
+		fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, number)
+
+		return nil
+	}
+
+	text, err := csvValue(v, l)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+
+	if err := xml.EscapeText(b, []byte(text)); err != nil {
+		return err
+	}
+
+	b.WriteString(`</t></is></c>`)
+
+	return nil
+}
+
+// xlsxColumn returns the name of the column at the index, e.g. A, Z or AA.
+func xlsxColumn(i int) string {
+	name := ""
+
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
@@ -0,0 +1,135 @@
+package responder
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readSheet returns the worksheet of the workbook.
+func readSheet(t *testing.T, body []byte) string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+
+	names := make(map[string]bool)
+
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if !names[name] {
+			t.Errorf("expected the workbook to contain %s", name)
+		}
+	}
+
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("expected a worksheet: %v", err)
+	}
+	defer f.Close()
+
+	sheet, _ := io.ReadAll(f)
+
+	return string(sheet)
+}
+
+func TestXLSXResponder(t *testing.T) {
+	type row struct {
+		Name   string    `csv:"name"`
+		Amount float64   `csv:"amount"`
+		Paid   bool      `csv:"paid"`
+		Due    time.Time `csv:"due"`
+		Notes  *string   `csv:"notes"`
+	}
+
+	t.Run("encodes slices of structs with a header row", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		due := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		XLSXResponder().Send200(w, []row{{Name: "A & B <Ltd>", Amount: 12.5, Paid: true, Due: due}})
+
+		if ct := w.Header().Get("Content-Type"); ct != XLSXContentType {
+			t.Errorf("expected Content-Type %q, got %q", XLSXContentType, ct)
+		}
+
+		if v := w.Header().Get("Content-Disposition"); v != `attachment; filename=export.xlsx` {
+			t.Errorf("unexpected Content-Disposition %q", v)
+		}
+
+		sheet := readSheet(t, w.Body.Bytes())
+
+		for _, cell := range []string{
+			`<c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+			`<c r="E1" t="inlineStr"><is><t xml:space="preserve">notes</t></is></c>`,
+			`<c r="A2" t="inlineStr"><is><t xml:space="preserve">A &amp; B &lt;Ltd&gt;</t></is></c>`,
+			`<c r="B2"><v>12.5</v></c>`,
+			`<c r="C2" t="b"><v>1</v></c>`,
+			`<c r="D2" t="inlineStr"><is><t xml:space="preserve">2024-01-31T00:00:00Z</t></is></c>`,
+		} {
+			if !strings.Contains(sheet, cell) {
+				t.Errorf("expected the sheet to contain %s, got %s", cell, sheet)
+			}
+		}
+
+		if strings.Contains(sheet, `r="E2"`) {
+			t.Errorf("expected nil values to be left out, got %s", sheet)
+		}
+	})
+
+	t.Run("encodes rows of values", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		rows := make([][]any, 1)
+		rows[0] = make([]any, 28)
+		rows[0][0] = uint8(7)
+		rows[0][27] = math.Inf(1)
+
+		XLSXResponder(WithAttachmentFilename("report.xlsx")).Send200(w, rows)
+
+		sheet := readSheet(t, w.Body.Bytes())
+
+		if !strings.Contains(sheet, `<c r="A1"><v>7</v></c>`) || !strings.Contains(sheet, `<c r="AB1" t="inlineStr"><is><t xml:space="preserve">+Inf</t></is></c>`) {
+			t.Errorf("unexpected sheet %s", sheet)
+		}
+
+		if v := w.Header().Get("Content-Disposition"); v != `attachment; filename=report.xlsx` {
+			t.Errorf("unexpected Content-Disposition %q", v)
+		}
+	})
+
+	t.Run("sends errors as text", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		XLSXResponder().Send404(w, nil, "no report")
+
+		if v := w.Header().Get("Content-Disposition"); v != "" {
+			t.Errorf("expected no Content-Disposition, got %q", v)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != TextContentType {
+			t.Errorf("expected Content-Type %q, got %q", TextContentType, ct)
+		}
+
+		if w.Code != http.StatusNotFound || w.Body.String() != "no report" {
+			t.Errorf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if c := xlsxColumn(i); c != name {
+			t.Errorf("expected column %q for %d, got %q", name, i, c)
+		}
+	}
+}
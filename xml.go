@@ -0,0 +1,106 @@
+package responder
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+)
+
+// defaultXMLRoot is the root element wrapping the slices when none is configured.
+const defaultXMLRoot = "items"
+
+// WithXMLHeader prepends the standard XML declaration to the documents
+// marshaled by the XML responders. The strings and byte slices are sent
+// as is since they are expected to be documents already.
+func WithXMLHeader(enabled bool) OptionsModifier {
+	return func(o *options) {
+		o.xml.header = enabled
+	}
+}
+
+// WithXMLRootElement sets the root element of the documents marshaled by
+// the XML responders. It replaces the element named after the type of the
+// structs, and wraps the elements of the slices, "items" being used by default.
+func WithXMLRootElement(name string) OptionsModifier {
+	return func(o *options) {
+		o.xml.root = name
+	}
+}
+
+// xmlOptions holds the configuration of the XML encoding.
+type xmlOptions struct {
+	header bool
+	root   string
+}
+
+// formatXML is the default data formatter of the XML responders.
+// The strings and byte slices are sent as is, the other values are marshaled
+// with encoding/xml, the slices being wrapped in a root element.
+func (o *options) formatXML(c any) ([]byte, error) {
+	switch c.(type) {
+	case nil, string, []byte:
+		return formatData(c)
+	}
+
+	b := getBuffer()
+	if err := o.writeXML(b, c); err != nil {
+		return nil, err
+	}
+
+	return releaseBytes(b), nil
+}
+
+// encodeXML encodes the value to the writer as formatXML does,
+// unless it is sent as is.
+func (o *options) encodeXML(w io.Writer, c any) (bool, error) {
+	switch c.(type) {
+	case nil, string, []byte:
+		return false, nil
+	}
+
+	return true, o.writeXML(w, c)
+}
+
+// writeXML marshals the value to the writer.
+func (o *options) writeXML(w io.Writer, c any) error {
+	if o.xml.header {
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+	}
+
+	enc := xml.NewEncoder(w)
+	v := reflect.ValueOf(c)
+
+	switch {
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		root := xml.StartElement{Name: xml.Name{Local: o.xml.root}}
+		if root.Name.Local == "" {
+			root.Name.Local = defaultXMLRoot
+		}
+
+		if err := enc.EncodeToken(root); err != nil {
+			return err
+		}
+
+		for i := range v.Len() {
+			if err := enc.Encode(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+
+		if err := enc.EncodeToken(root.End()); err != nil {
+			return err
+		}
+	case o.xml.root != "":
+		if err := enc.EncodeElement(c, xml.StartElement{Name: xml.Name{Local: o.xml.root}}); err != nil {
+			return err
+		}
+	default:
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}